@@ -31,9 +31,27 @@ type Client struct {
 	// this flags controls which IPMI version (1.5 or 2.0) be used by Client to send Request
 	v20 bool
 
+	// responder of the requests, nil for the BMC, see withResponder
+	responder *responder
+
 	udpClient  *UDPClient
 	timeout    time.Duration
 	bufferSize int
+
+	// SDR Repository cache, see WithSDRCacheDir and WithSDRCacheFile
+	sdrCacheDir  string
+	sdrCacheFile string
+	sdrCacheKey  string
+	sdrCache     *SDRCache
+
+	// see WithSensorErrorTolerance, WithSkipSensorThresholds and WithSkipSensorHysteresis
+	sensorErrorTolerance bool
+	skipSensorThresholds bool
+	skipSensorHysteresis bool
+
+	// see WithSELOEMDecoder and GetSELOEMDecoder
+	selOEMDecoder       SELOEMDecoder
+	selOEMDecoderLoaded bool
 }
 
 func NewOpenClient() (*Client, error) {
@@ -117,6 +135,45 @@ func (c *Client) WithBufferSize(bufferSize int) *Client {
 	return c
 }
 
+// WithSDRCacheDir enables the SDR Repository cache, the cache files are stored under dir,
+// one file per BMC (identified by Device ID and GUID).
+// Use DefaultSDRCacheDir() if you have no preference.
+func (c *Client) WithSDRCacheDir(dir string) *Client {
+	c.sdrCacheDir = dir
+	return c
+}
+
+// WithSDRCacheFile enables the SDR Repository cache and uses the specified file as the cache file,
+// like the "-S" option of ipmitool. It takes precedence over WithSDRCacheDir.
+// The file (a JSON cache written by SDRCache.Save, or the dump file of "ipmitool sdr dump")
+// is only read, it is never written.
+func (c *Client) WithSDRCacheFile(file string) *Client {
+	c.sdrCacheFile = file
+	return c
+}
+
+// WithSensorErrorTolerance makes GetSensors return all the sensors it could read
+// instead of aborting on the first failed sensor. The error of a failed sensor is attached
+// to Sensor.Err, and GetSensors returns a *SensorsError summarizing the failed sensors.
+func (c *Client) WithSensorErrorTolerance(tolerance bool) *Client {
+	c.sensorErrorTolerance = tolerance
+	return c
+}
+
+// WithSkipSensorThresholds skips the Get Sensor Thresholds command when reading threshold sensors,
+// the thresholds in the Full SDRs are used instead.
+func (c *Client) WithSkipSensorThresholds(skip bool) *Client {
+	c.skipSensorThresholds = skip
+	return c
+}
+
+// WithSkipSensorHysteresis skips the Get Sensor Hysteresis command when reading threshold sensors,
+// the hysteresis in the Full SDRs are used instead.
+func (c *Client) WithSkipSensorHysteresis(skip bool) *Client {
+	c.skipSensorHysteresis = skip
+	return c
+}
+
 func (c *Client) SessionPrivilegeLevel() PrivilegeLevel {
 	return c.session.v20.maxPrivilegeLevel
 }
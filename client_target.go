@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ClientTarget describes how to reach a BMC, like a target in the configuration files of
+// the collector and the forwarder packages.
+type ClientTarget struct {
+	Interface Interface
+	// Host is "host" or "host:port", it is ignored for open interface.
+	Host string
+	// Port is used when Host does not contain a port, defaults to 623.
+	Port     int
+	Username string
+	Password string
+	// Timeout of each request, the default timeout is used if zero.
+	Timeout time.Duration
+	// SDRCacheDir enables the SDR Repository cache, see WithSDRCacheDir.
+	SDRCacheDir string
+}
+
+// NewClientForTarget creates and connects a client to the target.
+func NewClientForTarget(target *ClientTarget) (*Client, error) {
+	var client *Client
+
+	switch target.Interface {
+	case "", InterfaceOpen:
+		c, err := NewOpenClient()
+		if err != nil {
+			return nil, fmt.Errorf("create open client failed, err: %s", err)
+		}
+		client = c
+
+	default:
+		if target.Host == "" {
+			return nil, fmt.Errorf("empty host for interface (%s)", target.Interface)
+		}
+
+		host, port := target.Host, target.Port
+		if port == 0 {
+			port = 623
+		}
+		if h, p, err := net.SplitHostPort(target.Host); err == nil {
+			i, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host port (%s)", p)
+			}
+			host, port = h, i
+		}
+
+		c, err := NewClient(host, port, target.Username, target.Password)
+		if err != nil {
+			return nil, fmt.Errorf("create lan or lanplus client failed, err: %s", err)
+		}
+		if target.Timeout > 0 {
+			c.WithTimeout(target.Timeout)
+		}
+		client = c
+	}
+
+	client.WithInterface(target.Interface)
+	if target.SDRCacheDir != "" {
+		client.WithSDRCacheDir(target.SDRCacheDir)
+	}
+
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("client connect failed, err: %s", err)
+	}
+	return client, nil
+}
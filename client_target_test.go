@@ -0,0 +1,25 @@
+package ipmi
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_NewClientForTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target *ClientTarget
+		err    string
+	}{
+		{"empty host", &ClientTarget{Interface: InterfaceLanplus, Username: "admin", Password: "admin"}, "empty host for interface (lanplus)"},
+		{"invalid port", &ClientTarget{Interface: InterfaceLan, Host: "10.0.0.1:ipmi", Username: "admin", Password: "admin"}, "invalid host port (ipmi)"},
+		{"empty password", &ClientTarget{Interface: InterfaceLan, Host: "10.0.0.1", Username: "admin"}, "empty password"},
+	}
+
+	for _, test := range tests {
+		_, err := NewClientForTarget(test.target)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("test %s expected error (%s), got: %v", test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ipmi
+
+import "fmt"
+
+// 33.13 Add SDR Command
+type AddSDRRequest struct {
+	// SDR Data, the whole record including the record header.
+	// The Record ID field in the record header is ignored by the BMC.
+	RecordData []byte
+}
+
+type AddSDRResponse struct {
+	RecordID uint16
+}
+
+func (req *AddSDRRequest) Command() Command {
+	return CommandAddSDR
+}
+
+func (req *AddSDRRequest) Pack() []byte {
+	out := make([]byte, len(req.RecordData))
+	packBytes(req.RecordData, out, 0)
+	return out
+}
+
+func (res *AddSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *AddSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "operation not supported for this Record Type",
+		0x81: "cannot execute command, SDR Repository in update mode",
+	}
+}
+
+func (res *AddSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %#04x", res.RecordID)
+}
+
+// AddSDR adds the specified sensor record to the SDR Repository and returns its Record ID.
+func (c *Client) AddSDR(recordData []byte) (response *AddSDRResponse, err error) {
+	request := &AddSDRRequest{
+		RecordData: recordData,
+	}
+	response = &AddSDRResponse{}
+	err = c.Exchange(request, response)
+	return
+}
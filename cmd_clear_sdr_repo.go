@@ -0,0 +1,78 @@
+package ipmi
+
+import "fmt"
+
+// 33.16 Clear SDR Repository Command
+type ClearSDRRepoRequest struct {
+	ReservationID        uint16 // LS Byte first
+	GetErasureStatusFlag bool
+}
+
+type ClearSDRRepoResponse struct {
+	// [3:0] Erasure progress.
+	// 0h = erasure in progress.
+	// 1h = erase completed.
+	ErasureProgressStatus uint8
+}
+
+func (req *ClearSDRRepoRequest) Command() Command {
+	return CommandClearSDRRepo
+}
+
+func (req *ClearSDRRepoRequest) Pack() []byte {
+	var out = make([]byte, 6)
+	packUint16L(req.ReservationID, out, 0)
+	packUint8('C', out, 2) // fixed 'C' char
+	packUint8('L', out, 3) // fixed 'L' char
+	packUint8('R', out, 4) // fixed 'R' char
+	if req.GetErasureStatusFlag {
+		packUint8(0x00, out, 5) //  get erasure status
+	} else {
+		packUint8(0xaa, out, 5) //  initiate erase
+	}
+	return out
+}
+
+func (res *ClearSDRRepoResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShort
+	}
+
+	b, _, _ := unpackUint8(msg, 0)
+	res.ErasureProgressStatus = b & 0x0f
+	return nil
+}
+
+func (res *ClearSDRRepoResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *ClearSDRRepoResponse) Completed() bool {
+	return res.ErasureProgressStatus == 0x01
+}
+
+func (res *ClearSDRRepoResponse) Format() string {
+	return fmt.Sprintf("Erasure Progress : %s", formatBool(res.Completed(), "erase completed", "erasure in progress"))
+}
+
+// ClearSDRRepo initiates the erasure of the SDR Repository.
+func (c *Client) ClearSDRRepo(reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	request := &ClearSDRRepoRequest{
+		ReservationID:        reservationID,
+		GetErasureStatusFlag: false,
+	}
+	response = &ClearSDRRepoResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// GetSDRRepoErasureStatus returns the erasure status of the SDR Repository.
+func (c *Client) GetSDRRepoErasureStatus(reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	request := &ClearSDRRepoRequest{
+		ReservationID:        reservationID,
+		GetErasureStatusFlag: true,
+	}
+	response = &ClearSDRRepoResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -9,6 +9,9 @@ type ClearSELRequest struct {
 }
 
 type ClearSELResponse struct {
+	// [3:0] Erasure progress.
+	// 0h = erasure in progress.
+	// 1h = erase completed.
 	ErasureProgressStatus uint8
 }
 
@@ -35,7 +38,8 @@ func (res *ClearSELResponse) Unpack(msg []byte) error {
 		return ErrUnpackedDataTooShort
 	}
 
-	res.ErasureProgressStatus, _, _ = unpackUint8(msg, 0)
+	b, _, _ := unpackUint8(msg, 0)
+	res.ErasureProgressStatus = b & 0x0f
 	return nil
 }
 
@@ -44,10 +48,16 @@ func (res *ClearSELResponse) CompletionCodes() map[uint8]string {
 	return map[uint8]string{}
 }
 
+func (res *ClearSELResponse) Completed() bool {
+	return res.ErasureProgressStatus == 0x01
+}
+
 func (res *ClearSELResponse) Format() string {
-	return fmt.Sprintf("%v", res)
+	return fmt.Sprintf("Erasure Progress : %s", formatBool(res.Completed(), "erase completed", "erasure in progress"))
 }
 
+// ClearSEL initiates the erasure of SEL.
+// See ClearSELSafely to archive the records before clearing and wait for the erasure to complete.
 func (c *Client) ClearSEL(reservationID uint16) (response *ClearSELResponse, err error) {
 	request := &ClearSELRequest{
 		ReservationID:        reservationID,
@@ -57,3 +67,14 @@ func (c *Client) ClearSEL(reservationID uint16) (response *ClearSELResponse, err
 	err = c.Exchange(request, response)
 	return
 }
+
+// GetSELErasureStatus returns the erasure status of SEL.
+func (c *Client) GetSELErasureStatus(reservationID uint16) (response *ClearSELResponse, err error) {
+	request := &ClearSELRequest{
+		ReservationID:        reservationID,
+		GetErasureStatusFlag: true,
+	}
+	response = &ClearSELResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,52 @@
+package ipmi
+
+import "fmt"
+
+// 33.15 Delete SDR Command
+type DeleteSDRRequest struct {
+	ReservationID uint16
+	RecordID      uint16
+}
+
+type DeleteSDRResponse struct {
+	RecordID uint16
+}
+
+func (req *DeleteSDRRequest) Command() Command {
+	return CommandDeleteSDR
+}
+
+func (req *DeleteSDRRequest) Pack() []byte {
+	out := make([]byte, 4)
+	packUint16L(req.ReservationID, out, 0)
+	packUint16L(req.RecordID, out, 2)
+	return out
+}
+
+func (res *DeleteSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *DeleteSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *DeleteSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %#04x", res.RecordID)
+}
+
+// DeleteSDR deletes the sensor record specified by recordID from the SDR Repository.
+func (c *Client) DeleteSDR(reservationID uint16, recordID uint16) (response *DeleteSDRResponse, err error) {
+	request := &DeleteSDRRequest{
+		ReservationID: reservationID,
+		RecordID:      recordID,
+	}
+	response = &DeleteSDRResponse{}
+	err = c.Exchange(request, response)
+	return
+}
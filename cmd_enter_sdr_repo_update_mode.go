@@ -0,0 +1,38 @@
+package ipmi
+
+// 33.19 Enter SDR Repository Update Mode Command
+type EnterSDRRepoUpdateModeRequest struct {
+	// empty
+}
+
+type EnterSDRRepoUpdateModeResponse struct {
+}
+
+func (req *EnterSDRRepoUpdateModeRequest) Command() Command {
+	return CommandEnterSDRRepoUpateMode
+}
+
+func (req *EnterSDRRepoUpdateModeRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) Format() string {
+	return ""
+}
+
+// EnterSDRRepoUpdateMode enters the SDR Repository Update mode.
+// In update mode, the SDR Repository can be written, and the commands that read the SDR Repository may be unavailable.
+func (c *Client) EnterSDRRepoUpdateMode() (response *EnterSDRRepoUpdateModeResponse, err error) {
+	request := &EnterSDRRepoUpdateModeRequest{}
+	response = &EnterSDRRepoUpdateModeResponse{}
+	err = c.Exchange(request, response)
+	return
+}
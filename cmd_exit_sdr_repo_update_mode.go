@@ -0,0 +1,37 @@
+package ipmi
+
+// 33.20 Exit SDR Repository Update Mode Command
+type ExitSDRRepoUpdateModeRequest struct {
+	// empty
+}
+
+type ExitSDRRepoUpdateModeResponse struct {
+}
+
+func (req *ExitSDRRepoUpdateModeRequest) Command() Command {
+	return CommandExitSDRRepoUpdateMode
+}
+
+func (req *ExitSDRRepoUpdateModeRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) Format() string {
+	return ""
+}
+
+// ExitSDRRepoUpdateMode exits the SDR Repository Update mode.
+func (c *Client) ExitSDRRepoUpdateMode() (response *ExitSDRRepoUpdateModeResponse, err error) {
+	request := &ExitSDRRepoUpdateModeRequest{}
+	response = &ExitSDRRepoUpdateModeResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,158 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuxLogType is the type of the auxiliary logs of the BMC.
+type AuxLogType uint8
+
+const (
+	// Machine Check Architecture log
+	AuxLogTypeMCA  AuxLogType = 0x00
+	AuxLogTypeOEM1 AuxLogType = 0x01
+	AuxLogTypeOEM2 AuxLogType = 0x02
+)
+
+// AuxLogTypes are all the auxiliary log types defined by the specification.
+var AuxLogTypes = []AuxLogType{AuxLogTypeMCA, AuxLogTypeOEM1, AuxLogTypeOEM2}
+
+func (t AuxLogType) String() string {
+	return eventDataString(auxLogTypes, uint8(t))
+}
+
+// 31.14 Get Auxiliary Log Status Command
+type GetAuxLogStatusRequest struct {
+	LogType AuxLogType
+}
+
+type GetAuxLogStatusResponse struct {
+	// The log type of the request, it determines the format of the log-type specific data.
+	LogType AuxLogType
+
+	// Timestamp when the log was last updated, zero if unspecified.
+	Timestamp time.Time
+
+	// Number of entries in the MCA log, only for AuxLogTypeMCA.
+	MCAEntries uint32
+
+	// OEM-defined log status, only for OEM log types.
+	OEMData []byte
+}
+
+func (req *GetAuxLogStatusRequest) Command() Command {
+	return CommandGetAuxLogStatus
+}
+
+func (req *GetAuxLogStatusRequest) Pack() []byte {
+	return []byte{uint8(req.LogType) & 0x0f}
+}
+
+func (res *GetAuxLogStatusResponse) Unpack(msg []byte) error {
+	if len(msg) < 4 {
+		return ErrUnpackedDataTooShort
+	}
+
+	ts, _, _ := unpackUint32L(msg, 0)
+	res.Timestamp = parseAuxLogTimestamp(ts)
+
+	if res.LogType == AuxLogTypeMCA {
+		if len(msg) < 8 {
+			return ErrUnpackedDataTooShort
+		}
+		res.MCAEntries, _, _ = unpackUint32L(msg, 4)
+		return nil
+	}
+
+	res.OEMData, _, _ = unpackBytes(msg, 4, len(msg)-4)
+	return nil
+}
+
+func (res *GetAuxLogStatusResponse) CompletionCodes() map[uint8]string {
+	// no command-specific cc
+	return map[uint8]string{}
+}
+
+func (res *GetAuxLogStatusResponse) Format() string {
+	lastUpdate := "unspecified"
+	if !res.Timestamp.IsZero() {
+		lastUpdate = res.Timestamp.Format(time.RFC3339)
+	}
+
+	out := fmt.Sprintf(`Log Type                     : %s
+Last Update                  : %s`, res.LogType, lastUpdate)
+
+	if res.LogType == AuxLogTypeMCA {
+		out += fmt.Sprintf("\nEntries                      : %d", res.MCAEntries)
+	} else {
+		out += fmt.Sprintf("\nOEM Data                     : % x", res.OEMData)
+	}
+	return out
+}
+
+// parseAuxLogTimestamp parses the timestamp, the unspecified time value is parsed to the zero time.
+func parseAuxLogTimestamp(ts uint32) time.Time {
+	if ts == timestampUnspecified {
+		return time.Time{}
+	}
+	return parseTimestamp(ts)
+}
+
+// GetAuxLogStatus returns the status of the auxiliary log, like the MCA log,
+// the last update timestamp tells whether there is fresh data in the log.
+func (c *Client) GetAuxLogStatus(logType AuxLogType) (response *GetAuxLogStatusResponse, err error) {
+	request := &GetAuxLogStatusRequest{
+		LogType: logType,
+	}
+	response = &GetAuxLogStatusResponse{
+		LogType: logType,
+	}
+	err = c.Exchange(request, response)
+	return
+}
+
+// GetAuxLogStatuses returns the statuses of the auxiliary logs supported by the BMC,
+// the log types not supported are skipped.
+//
+// A failure of one log type does not stop the others, the statuses read are returned
+// along with the error of the last failed log type.
+func (c *Client) GetAuxLogStatuses() ([]*GetAuxLogStatusResponse, error) {
+	return getAuxLogStatuses(c)
+}
+
+// AuxLogSource is the command to get auxiliary log status, it is implemented by Client.
+type AuxLogSource interface {
+	GetAuxLogStatus(logType AuxLogType) (*GetAuxLogStatusResponse, error)
+}
+
+func getAuxLogStatuses(source AuxLogSource) ([]*GetAuxLogStatusResponse, error) {
+	out := make([]*GetAuxLogStatusResponse, 0)
+	var lastErr error
+	for _, logType := range AuxLogTypes {
+		res, err := source.GetAuxLogStatus(logType)
+		if err != nil {
+			if IsAuxLogUnsupported(err) {
+				continue
+			}
+			lastErr = fmt.Errorf("GetAuxLogStatus for %s failed, err: %s", logType, err)
+			continue
+		}
+		out = append(out, res)
+	}
+	return out, lastErr
+}
+
+// IsAuxLogUnsupported reports whether the error of GetAuxLogStatus means the BMC does not support the aux log type.
+func IsAuxLogUnsupported(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	if !ok {
+		return false
+	}
+	switch resErr.CompletionCode() {
+	case CompletionCodeInvalidCommand, CompletionCodeParameterOutOfRange, CompletionCodeRequestDataFieldInvalid,
+		CompletionCodeCannotExecuteCommandNotSupported:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_GetAuxLogStatusResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		logType    AuxLogType
+		msg        []byte
+		timestamp  time.Time
+		mcaEntries uint32
+		oemData    []byte
+	}{
+		{"MCA log", AuxLogTypeMCA, []byte{0x00, 0xf1, 0x53, 0x65, 0x03, 0x00, 0x00, 0x00}, time.Unix(0x6553f100, 0), 3, nil},
+		{"MCA log unspecified timestamp", AuxLogTypeMCA, []byte{0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}, time.Time{}, 0, nil},
+		{"OEM log", AuxLogTypeOEM1, []byte{0x00, 0xf1, 0x53, 0x65, 0xaa, 0xbb}, time.Unix(0x6553f100, 0), 0, []byte{0xaa, 0xbb}},
+	}
+
+	for _, test := range tests {
+		res := &GetAuxLogStatusResponse{LogType: test.logType}
+		if err := res.Unpack(test.msg); err != nil {
+			t.Errorf("test %s unpack failed, err: %s", test.name, err)
+			continue
+		}
+		if !res.Timestamp.Equal(test.timestamp) || res.MCAEntries != test.mcaEntries || !bytes.Equal(res.OEMData, test.oemData) {
+			t.Errorf("test %s not matched, got: %+v", test.name, res)
+		}
+	}
+
+	if err := (&GetAuxLogStatusResponse{LogType: AuxLogTypeMCA}).Unpack([]byte{0x00, 0xf1, 0x53, 0x65}); err == nil {
+		t.Errorf("test short MCA log status should fail")
+	}
+}
+
+func Test_SetAuxLogStatusRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *SetAuxLogStatusRequest
+		expected []byte
+	}{
+		{"MCA log", &SetAuxLogStatusRequest{LogType: AuxLogTypeMCA, Timestamp: time.Unix(0x6553f100, 0), MCAEntries: 3},
+			[]byte{0x00, 0x00, 0xf1, 0x53, 0x65, 0x03, 0x00, 0x00, 0x00}},
+		{"MCA log unspecified timestamp", &SetAuxLogStatusRequest{LogType: AuxLogTypeMCA},
+			[]byte{0x00, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}},
+		{"OEM log", &SetAuxLogStatusRequest{LogType: AuxLogTypeOEM2, Timestamp: time.Unix(0x6553f100, 0), OEMData: []byte{0x01, 0x02}},
+			[]byte{0x02, 0x00, 0xf1, 0x53, 0x65, 0x01, 0x02}},
+		{"OEM log unspecified timestamp", &SetAuxLogStatusRequest{LogType: AuxLogTypeOEM1},
+			[]byte{0x01, 0xff, 0xff, 0xff, 0xff}},
+	}
+
+	for _, test := range tests {
+		if got := test.request.Pack(); !bytes.Equal(got, test.expected) {
+			t.Errorf("test %s not matched, got: % x, expected: % x", test.name, got, test.expected)
+		}
+	}
+}
+
+func Test_AuxLogStatus_RoundTrip(t *testing.T) {
+	requests := []*SetAuxLogStatusRequest{
+		{LogType: AuxLogTypeMCA, Timestamp: time.Unix(0x6553f100, 0), MCAEntries: 3},
+		{LogType: AuxLogTypeOEM1, Timestamp: time.Unix(0x6553f100, 0), OEMData: []byte{0xaa, 0xbb, 0xcc}},
+		{LogType: AuxLogTypeOEM2, OEMData: []byte{0x01}},
+	}
+
+	for _, request := range requests {
+		// the log status set by Set Auxiliary Log Status is returned by Get Auxiliary Log Status,
+		// the request data is the log type followed by the log status.
+		res := &GetAuxLogStatusResponse{LogType: request.LogType}
+		if err := res.Unpack(request.Pack()[1:]); err != nil {
+			t.Errorf("test %s unpack failed, err: %s", request.LogType, err)
+			continue
+		}
+		if !res.Timestamp.Equal(request.Timestamp) || res.MCAEntries != request.MCAEntries || !bytes.Equal(res.OEMData, request.OEMData) {
+			t.Errorf("test %s not matched, got: %+v, expected: %+v", request.LogType, res, request)
+		}
+	}
+}
+
+// auxLogStatusFunc is an adapter to use the function as AuxLogSource.
+type auxLogStatusFunc func(logType AuxLogType) (*GetAuxLogStatusResponse, error)
+
+func (f auxLogStatusFunc) GetAuxLogStatus(logType AuxLogType) (*GetAuxLogStatusResponse, error) {
+	return f(logType)
+}
+
+func Test_getAuxLogStatuses(t *testing.T) {
+	// the MCA log is read, OEM log 1 fails and OEM log 2 is not supported
+	source := auxLogStatusFunc(func(logType AuxLogType) (*GetAuxLogStatusResponse, error) {
+		switch logType {
+		case AuxLogTypeMCA:
+			return &GetAuxLogStatusResponse{LogType: logType, Timestamp: time.Unix(0x6553f100, 0), MCAEntries: 3}, nil
+		case AuxLogTypeOEM1:
+			return nil, &ResponseError{completionCode: CompletionCodeNodeBusy}
+		}
+		return nil, &ResponseError{completionCode: CompletionCodeParameterOutOfRange}
+	})
+
+	statuses, err := getAuxLogStatuses(source)
+	if err == nil {
+		t.Errorf("test expected the error of OEM log 1")
+	}
+	if len(statuses) != 1 || statuses[0].LogType != AuxLogTypeMCA || statuses[0].MCAEntries != 3 {
+		t.Errorf("test the statuses read should be returned, got: %+v", statuses)
+	}
+}
@@ -0,0 +1,85 @@
+package ipmi
+
+import "fmt"
+
+// 24.10 Get Channel OEM Payload Info Command
+type GetChannelOEMPayloadInfoRequest struct {
+	ChannelNumber uint8
+
+	// Payload Type number. 02h (OEM Explicit) or 20h-27h (OEM payload type handles).
+	PayloadType PayloadType
+
+	// OEM IANA. Only used if Payload Type is 02h (OEM Explicit), otherwise 000000h.
+	OEMIANA uint32
+
+	// OEM Payload ID. Only used if Payload Type is 02h (OEM Explicit), otherwise 0000h.
+	OEMPayloadID uint16
+}
+
+type GetChannelOEMPayloadInfoResponse struct {
+	// Payload Type number. Returns the payload type handle (20h-27h) if the
+	// requested OEM payload is supported.
+	PayloadType  PayloadType
+	OEMIANA      uint32
+	OEMPayloadID uint16
+
+	// [7:4] Major Format Version
+	// [3:0] Minor Format Version
+	MajorVersion uint8
+	MinorVersion uint8
+}
+
+func (req *GetChannelOEMPayloadInfoRequest) Command() Command {
+	return CommandGetChannelOEMPayloadInfo
+}
+
+func (req *GetChannelOEMPayloadInfoRequest) Pack() []byte {
+	out := make([]byte, 7)
+	packUint8(req.ChannelNumber&0x0f, out, 0)
+	packUint8(uint8(req.PayloadType), out, 1)
+	packUint24L(req.OEMIANA, out, 2)
+	packUint16L(req.OEMPayloadID, out, 5)
+	return out
+}
+
+func (res *GetChannelOEMPayloadInfoResponse) Unpack(msg []byte) error {
+	if len(msg) < 7 {
+		return ErrUnpackedDataTooShort
+	}
+
+	b, _, _ := unpackUint8(msg, 0)
+	res.PayloadType = PayloadType(b)
+	res.OEMIANA, _, _ = unpackUint24L(msg, 1)
+	res.OEMPayloadID, _, _ = unpackUint16L(msg, 4)
+
+	v, _, _ := unpackUint8(msg, 6)
+	res.MajorVersion = v >> 4
+	res.MinorVersion = v & 0x0f
+	return nil
+}
+
+func (res *GetChannelOEMPayloadInfoResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "OEM Payload IANA and/or Payload ID not supported",
+	}
+}
+
+func (res *GetChannelOEMPayloadInfoResponse) Format() string {
+	return fmt.Sprintf(`Payload Type   : %s (%#02x)
+OEM IANA       : %d (%s)
+OEM Payload ID : %#04x
+Format Version : %d.%d`,
+		res.PayloadType, uint8(res.PayloadType),
+		res.OEMIANA, OEM(res.OEMIANA),
+		res.OEMPayloadID,
+		res.MajorVersion, res.MinorVersion,
+	)
+}
+
+// GetChannelOEMPayloadInfo is used to look up whether a given OEM payload is supported,
+// and to return its payload type handle and version information.
+func (c *Client) GetChannelOEMPayloadInfo(request *GetChannelOEMPayloadInfoRequest) (response *GetChannelOEMPayloadInfoResponse, err error) {
+	response = &GetChannelOEMPayloadInfoResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,161 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// 24.8 Get Channel Payload Support Command
+type GetChannelPayloadSupportRequest struct {
+	ChannelNumber uint8
+}
+
+type GetChannelPayloadSupportResponse struct {
+	// Supported payload types, in the order of
+	// standard payload types (00h-0Fh), session setup payload types (10h-1Fh) and OEM payload types (20h-2Fh).
+	PayloadTypes []PayloadType
+}
+
+func (req *GetChannelPayloadSupportRequest) Command() Command {
+	return CommandGetChannelPayloadSupport
+}
+
+func (req *GetChannelPayloadSupportRequest) Pack() []byte {
+	return []byte{req.ChannelNumber & 0x0f}
+}
+
+func (res *GetChannelPayloadSupportResponse) Unpack(msg []byte) error {
+	if len(msg) < 6 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.PayloadTypes = make([]PayloadType, 0)
+	for i, base := range []uint8{0x00, 0x10, 0x20} {
+		bits, _, _ := unpackUint16L(msg, i*2)
+		for j := 0; j < 16; j++ {
+			if bits&(1<<j) != 0 {
+				res.PayloadTypes = append(res.PayloadTypes, PayloadType(base+uint8(j)))
+			}
+		}
+	}
+	return nil
+}
+
+func (res *GetChannelPayloadSupportResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+// Supports reports whether the payload type is supported on the channel.
+func (res *GetChannelPayloadSupportResponse) Supports(payloadType PayloadType) bool {
+	for _, v := range res.PayloadTypes {
+		if v == payloadType {
+			return true
+		}
+	}
+	return false
+}
+
+func (res *GetChannelPayloadSupportResponse) Format() string {
+	payloads := []string{}
+	for _, payloadType := range res.PayloadTypes {
+		payloads = append(payloads, fmt.Sprintf("  %s (%#02x)", payloadType, uint8(payloadType)))
+	}
+
+	return fmt.Sprintf(`Supported Payloads :
+%s`, strings.Join(payloads, "\n"))
+}
+
+// GetChannelPayloadSupport returns which standard payload type numbers and OEM payload
+// type handles are available on a given channel of a BMC.
+func (c *Client) GetChannelPayloadSupport(channelNumber uint8) (response *GetChannelPayloadSupportResponse, err error) {
+	request := &GetChannelPayloadSupportRequest{
+		ChannelNumber: channelNumber,
+	}
+	response = &GetChannelPayloadSupportResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// ChannelPayload represents a payload type supported on a channel.
+type ChannelPayload struct {
+	PayloadType  PayloadType
+	MajorVersion uint8
+	MinorVersion uint8
+
+	// only filled for activatable payloads (SOL and OEM payloads)
+	InstanceCapacity   uint8
+	ActivatedInstances []uint8
+}
+
+// GetChannelPayloads returns the payload types supported on the channel,
+// with their format versions and activation status.
+func (c *Client) GetChannelPayloads(channelNumber uint8) ([]*ChannelPayload, error) {
+	supportRes, err := c.GetChannelPayloadSupport(channelNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetChannelPayloadSupport failed, err: %s", err)
+	}
+
+	out := make([]*ChannelPayload, 0)
+	for _, payloadType := range supportRes.PayloadTypes {
+		payload := &ChannelPayload{
+			PayloadType: payloadType,
+		}
+
+		versionRes, err := c.GetChannelPayloadVersion(channelNumber, payloadType)
+		if err != nil {
+			c.Debugf("GetChannelPayloadVersion for payload (%s) failed, err: %s", payloadType, err)
+		} else {
+			payload.MajorVersion = versionRes.MajorVersion
+			payload.MinorVersion = versionRes.MinorVersion
+		}
+
+		if payloadType == PayloadTypeSOL || (payloadType >= PayloadTypeOEM0 && payloadType <= PayloadTypeOEM7) {
+			statusRes, err := c.GetPayloadActivationStatus(payloadType)
+			if err != nil {
+				c.Debugf("GetPayloadActivationStatus for payload (%s) failed, err: %s", payloadType, err)
+			} else {
+				payload.InstanceCapacity = statusRes.InstanceCapacity
+				payload.ActivatedInstances = statusRes.ActivatedInstances()
+			}
+		}
+
+		out = append(out, payload)
+	}
+	return out, nil
+}
+
+func FormatChannelPayloads(payloads []*ChannelPayload) string {
+	var buf = new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+
+	headers := []string{
+		"Payload Type",
+		"Name",
+		"Version",
+		"Capacity",
+		"Activated",
+	}
+	table.SetHeader(headers)
+	table.SetFooter(headers)
+
+	for _, payload := range payloads {
+		activated := []string{}
+		for _, v := range payload.ActivatedInstances {
+			activated = append(activated, fmt.Sprintf("%d", v))
+		}
+		table.Append([]string{
+			fmt.Sprintf("%#02x", uint8(payload.PayloadType)),
+			payload.PayloadType.String(),
+			fmt.Sprintf("%d.%d", payload.MajorVersion, payload.MinorVersion),
+			fmt.Sprintf("%d", payload.InstanceCapacity),
+			strings.Join(activated, ","),
+		})
+	}
+
+	table.Render()
+	return buf.String()
+}
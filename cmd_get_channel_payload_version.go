@@ -0,0 +1,57 @@
+package ipmi
+
+import "fmt"
+
+// 24.9 Get Channel Payload Version Command
+type GetChannelPayloadVersionRequest struct {
+	ChannelNumber uint8
+	PayloadType   PayloadType
+}
+
+type GetChannelPayloadVersionResponse struct {
+	// Format Version Number
+	// [7:4] Major Format Version
+	// [3:0] Minor Format Version
+	MajorVersion uint8
+	MinorVersion uint8
+}
+
+func (req *GetChannelPayloadVersionRequest) Command() Command {
+	return CommandGetChannelPayloadVersion
+}
+
+func (req *GetChannelPayloadVersionRequest) Pack() []byte {
+	return []byte{req.ChannelNumber & 0x0f, uint8(req.PayloadType)}
+}
+
+func (res *GetChannelPayloadVersionResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShort
+	}
+
+	b, _, _ := unpackUint8(msg, 0)
+	res.MajorVersion = b >> 4
+	res.MinorVersion = b & 0x0f
+	return nil
+}
+
+func (res *GetChannelPayloadVersionResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "Payload type not available on given channel",
+	}
+}
+
+func (res *GetChannelPayloadVersionResponse) Format() string {
+	return fmt.Sprintf("Format Version : %d.%d", res.MajorVersion, res.MinorVersion)
+}
+
+// GetChannelPayloadVersion returns the format version number for a given payload type on the channel.
+func (c *Client) GetChannelPayloadVersion(channelNumber uint8, payloadType PayloadType) (response *GetChannelPayloadVersionResponse, err error) {
+	request := &GetChannelPayloadVersionRequest{
+		ChannelNumber: channelNumber,
+		PayloadType:   payloadType,
+	}
+	response = &GetChannelPayloadVersionResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -66,14 +66,15 @@ func (c *Client) GetDeviceSDRBySensorID(sensorNumber uint8) (*SDR, error) {
 		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
 	}
 
+	reader := c.newDeviceSDRReader()
 	var recordID uint16 = 0
 	for {
-		res, err := c.GetDeviceSDR(recordID)
+		data, nextRecordID, err := reader.ReadRecord(recordID)
 		if err != nil {
-			return nil, fmt.Errorf("GetDeviceSDR for recordID (%#0x) failed, err: %s", recordID, err)
+			return nil, fmt.Errorf("read device SDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
 
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
+		sdr, err := ParseSDR(data, nextRecordID)
 		if err != nil {
 			return nil, fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
@@ -81,7 +82,7 @@ func (c *Client) GetDeviceSDRBySensorID(sensorNumber uint8) (*SDR, error) {
 			return sdr, nil
 		}
 
-		recordID = res.NextRecordID
+		recordID = nextRecordID
 		if recordID == 0xffff {
 			break
 		}
@@ -92,14 +93,15 @@ func (c *Client) GetDeviceSDRBySensorID(sensorNumber uint8) (*SDR, error) {
 
 func (c *Client) GetDeviceSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
 	var out = make([]*SDR, 0)
+	reader := c.newDeviceSDRReader()
 	var recordID uint16 = 0
 	for {
-		res, err := c.GetDeviceSDR(recordID)
+		data, nextRecordID, err := reader.ReadRecord(recordID)
 		if err != nil {
-			return nil, fmt.Errorf("GetDeviceSDR for recordID (%#0x) failed, err: %s", recordID, err)
+			return nil, fmt.Errorf("read device SDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
 
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
+		sdr, err := ParseSDR(data, nextRecordID)
 		if err != nil {
 			return nil, fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
@@ -115,7 +117,7 @@ func (c *Client) GetDeviceSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
 			}
 		}
 
-		recordID = res.NextRecordID
+		recordID = nextRecordID
 		if recordID == 0xffff {
 			break
 		}
@@ -0,0 +1,81 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 24.4 Get Payload Activation Status Command
+type GetPayloadActivationStatusRequest struct {
+	PayloadType PayloadType
+}
+
+type GetPayloadActivationStatusResponse struct {
+	// [3:0] Number of instances of given payload type that can be simultaneously activated on BMC. 1-based. 0h = reserved.
+	InstanceCapacity uint8
+
+	// Activation status of instance 1 to 16, index 0 for instance 1.
+	InstanceActivated [16]bool
+}
+
+func (req *GetPayloadActivationStatusRequest) Command() Command {
+	return CommandGetPayloadActivationStatus
+}
+
+func (req *GetPayloadActivationStatusRequest) Pack() []byte {
+	return []byte{uint8(req.PayloadType) & 0x3f}
+}
+
+func (res *GetPayloadActivationStatusResponse) Unpack(msg []byte) error {
+	if len(msg) < 3 {
+		return ErrUnpackedDataTooShort
+	}
+
+	b, _, _ := unpackUint8(msg, 0)
+	res.InstanceCapacity = b & 0x0f
+
+	status, _, _ := unpackUint16L(msg, 1)
+	for i := 0; i < 16; i++ {
+		res.InstanceActivated[i] = status&(1<<i) != 0
+	}
+	return nil
+}
+
+func (res *GetPayloadActivationStatusResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+// ActivatedInstances returns the activated payload instance numbers (1-based).
+func (res *GetPayloadActivationStatusResponse) ActivatedInstances() []uint8 {
+	out := make([]uint8, 0)
+	for i := 0; i < int(res.InstanceCapacity) && i < 16; i++ {
+		if res.InstanceActivated[i] {
+			out = append(out, uint8(i+1))
+		}
+	}
+	return out
+}
+
+func (res *GetPayloadActivationStatusResponse) Format() string {
+	instances := []string{}
+	for i := 0; i < int(res.InstanceCapacity) && i < 16; i++ {
+		instances = append(instances, fmt.Sprintf("  Instance %-2d : %s", i+1, formatBool(res.InstanceActivated[i], "activated", "deactivated")))
+	}
+
+	return fmt.Sprintf(`Instance Capacity : %d
+%s`,
+		res.InstanceCapacity,
+		strings.Join(instances, "\n"),
+	)
+}
+
+// GetPayloadActivationStatus returns how many instances of a given payload type
+// are presently activated, and how many total instances can be activated.
+func (c *Client) GetPayloadActivationStatus(payloadType PayloadType) (response *GetPayloadActivationStatusResponse, err error) {
+	request := &GetPayloadActivationStatusRequest{
+		PayloadType: payloadType,
+	}
+	response = &GetPayloadActivationStatusResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,155 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// 24.5 Get Payload Instance Info Command
+type GetPayloadInstanceInfoRequest struct {
+	PayloadType     PayloadType
+	PayloadInstance uint8
+}
+
+type GetPayloadInstanceInfoResponse struct {
+	// Session ID of session that the payload instance is activated under.
+	// 0 = payload instance not activated.
+	SessionID uint32
+
+	// Payload Type specific information, 8 bytes.
+	//
+	// For Payload Type = SOL:
+	//  - byte 1: Port Number. A number representing the system serial port
+	//    that is being used for the payload instance. 1-based. 00h = unspecified.
+	//  - byte 2:8 reserved
+	PayloadSpecificData []byte
+}
+
+func (req *GetPayloadInstanceInfoRequest) Command() Command {
+	return CommandGetPayloadInstanceInfo
+}
+
+func (req *GetPayloadInstanceInfoRequest) Pack() []byte {
+	return []byte{uint8(req.PayloadType) & 0x3f, req.PayloadInstance}
+}
+
+func (res *GetPayloadInstanceInfoResponse) Unpack(msg []byte) error {
+	if len(msg) < 4 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.SessionID, _, _ = unpackUint32L(msg, 0)
+	res.PayloadSpecificData, _, _ = unpackBytesMost(msg, 4, 8)
+	return nil
+}
+
+func (res *GetPayloadInstanceInfoResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+// PortNumber returns the system serial port number used by a SOL payload instance.
+func (res *GetPayloadInstanceInfoResponse) PortNumber() uint8 {
+	if len(res.PayloadSpecificData) < 1 {
+		return 0
+	}
+	return res.PayloadSpecificData[0]
+}
+
+func (res *GetPayloadInstanceInfoResponse) Format() string {
+	return fmt.Sprintf(`Session ID    : %#08x
+Payload Data  : % 02x`,
+		res.SessionID,
+		res.PayloadSpecificData,
+	)
+}
+
+// GetPayloadInstanceInfo returns information about a specific activated instance of a payload type.
+func (c *Client) GetPayloadInstanceInfo(payloadType PayloadType, payloadInstance uint8) (response *GetPayloadInstanceInfoResponse, err error) {
+	request := &GetPayloadInstanceInfoRequest{
+		PayloadType:     payloadType,
+		PayloadInstance: payloadInstance,
+	}
+	response = &GetPayloadInstanceInfoResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// PayloadInstance represents an activated instance of a payload type.
+type PayloadInstance struct {
+	PayloadType PayloadType
+	Instance    uint8
+	SessionID   uint32
+
+	// only meaningful for SOL payload
+	PortNumber uint8
+}
+
+// GetPayloadInstances returns all activated instances of the payload type,
+// together with the session ID they are activated under.
+func (c *Client) GetPayloadInstances(payloadType PayloadType) ([]*PayloadInstance, error) {
+	statusRes, err := c.GetPayloadActivationStatus(payloadType)
+	if err != nil {
+		return nil, fmt.Errorf("GetPayloadActivationStatus failed, err: %s", err)
+	}
+
+	out := make([]*PayloadInstance, 0)
+	for _, instance := range statusRes.ActivatedInstances() {
+		infoRes, err := c.GetPayloadInstanceInfo(payloadType, instance)
+		if err != nil {
+			return nil, fmt.Errorf("GetPayloadInstanceInfo for instance (%d) failed, err: %s", instance, err)
+		}
+		out = append(out, &PayloadInstance{
+			PayloadType: payloadType,
+			Instance:    instance,
+			SessionID:   infoRes.SessionID,
+			PortNumber:  infoRes.PortNumber(),
+		})
+	}
+	return out, nil
+}
+
+// KickPayloadInstance closes the session that the activated payload instance is running under,
+// which releases the payload instance. It can be used to kick stale SOL instances.
+func (c *Client) KickPayloadInstance(payloadType PayloadType, payloadInstance uint8) error {
+	infoRes, err := c.GetPayloadInstanceInfo(payloadType, payloadInstance)
+	if err != nil {
+		return fmt.Errorf("GetPayloadInstanceInfo failed, err: %s", err)
+	}
+	if infoRes.SessionID == 0 {
+		return fmt.Errorf("payload instance (%d) of %s is not activated", payloadInstance, payloadType)
+	}
+
+	if _, err := c.CloseSession(&CloseSessionRequest{SessionID: infoRes.SessionID}); err != nil {
+		return fmt.Errorf("CloseSession (%#08x) failed, err: %s", infoRes.SessionID, err)
+	}
+	return nil
+}
+
+func FormatPayloadInstances(instances []*PayloadInstance) string {
+	var buf = new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+
+	headers := []string{
+		"Payload",
+		"Instance",
+		"Session ID",
+		"Port",
+	}
+	table.SetHeader(headers)
+	table.SetFooter(headers)
+
+	for _, instance := range instances {
+		table.Append([]string{
+			instance.PayloadType.String(),
+			fmt.Sprintf("%d", instance.Instance),
+			fmt.Sprintf("%#08x", instance.SessionID),
+			fmt.Sprintf("%d", instance.PortNumber),
+		})
+	}
+
+	table.Render()
+	return buf.String()
+}
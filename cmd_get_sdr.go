@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"fmt"
+	"path/filepath"
+)
 
 // 33.12 Get SDR Command
 type GetSDRRequest struct {
@@ -59,24 +62,58 @@ func (c *Client) GetSDR(recordID uint16) (response *GetSDRResponse, err error) {
 	return
 }
 
+// GetSDRBySensorID returns the SDR of the sensor number.
+// As sensors owned by different management controllers may have the same sensor number,
+// the sensor owned by the BMC (LUN 0) is preferred, use GetSDRBySensorKey to choose the owner.
 func (c *Client) GetSDRBySensorID(sensorNumber uint8) (*SDR, error) {
 	if SensorNumber(sensorNumber) == SensorNumberReserved {
 		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
 	}
 
+	bmcKey := GeneratorBMC.SensorKey(SensorNumber(sensorNumber))
+	var found *SDR
+
+	if c.sdrCacheEnabled() {
+		sdrs, err := c.getAllSDRs()
+		if err != nil {
+			return nil, err
+		}
+		for _, sdr := range sdrs {
+			if uint8(sdr.SensorNumber()) != sensorNumber {
+				continue
+			}
+			if sdr.SensorKey() == bmcKey {
+				return sdr, nil
+			}
+			if found == nil {
+				found = sdr
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+		return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
+	}
+
+	reader := c.newSDRRepoReader()
 	var recordID uint16 = 0
 	for {
-		res, err := c.GetSDR(recordID)
+		data, nextRecordID, err := reader.ReadRecord(recordID)
 		if err != nil {
-			return nil, fmt.Errorf("GetSDRCondtionally failed, err: %s", err)
+			return nil, fmt.Errorf("read SDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
+		sdr, err := ParseSDR(data, nextRecordID)
 		if err != nil {
 			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
 		}
 
 		if uint8(sdr.SensorNumber()) == sensorNumber {
-			return sdr, nil
+			if sdr.SensorKey() == bmcKey {
+				return sdr, nil
+			}
+			if found == nil {
+				found = sdr
+			}
 		}
 
 		recordID = sdr.NextRecordID
@@ -85,17 +122,49 @@ func (c *Client) GetSDRBySensorID(sensorNumber uint8) (*SDR, error) {
 		}
 	}
 
+	if found != nil {
+		return found, nil
+	}
 	return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
 }
 
+// GetSDRBySensorKey returns the SDR of the sensor specified by the owner, the LUN and the sensor number.
+func (c *Client) GetSDRBySensorKey(key SensorKey) (*SDR, error) {
+	sdrs, err := c.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor, SDRRecordTypeEventOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sdr := range sdrs {
+		if sdr.SensorKey() == key {
+			return sdr, nil
+		}
+	}
+	return nil, fmt.Errorf("not found SDR for sensor (%s)", key)
+}
+
 func (c *Client) GetSDRBySensorName(sensorName string) (*SDR, error) {
+	if c.sdrCacheEnabled() {
+		sdrs, err := c.getAllSDRs()
+		if err != nil {
+			return nil, err
+		}
+		for _, sdr := range sdrs {
+			if sdr.SensorName() == sensorName {
+				return sdr, nil
+			}
+		}
+		return nil, fmt.Errorf("not found SDR for sensor name (%s)", sensorName)
+	}
+
+	reader := c.newSDRRepoReader()
 	var recordID uint16 = 0
 	for {
-		res, err := c.GetSDR(recordID)
+		data, nextRecordID, err := reader.ReadRecord(recordID)
 		if err != nil {
-			return nil, fmt.Errorf("GetSDRCondtionally failed, err: %s", err)
+			return nil, fmt.Errorf("read SDR for recordID (%#0x) failed, err: %s", recordID, err)
 		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
+		sdr, err := ParseSDR(data, nextRecordID)
 		if err != nil {
 			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
 		}
@@ -110,67 +179,60 @@ func (c *Client) GetSDRBySensorName(sensorName string) (*SDR, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("not found SDR for sensor name (%#0x)", sensorName)
+	return nil, fmt.Errorf("not found SDR for sensor name (%s)", sensorName)
 }
 
 // GetSDRs fetches the SDR records with the specified RecordTypes.
 // The parameter is a slice of SDRRecordType used as filter.
 // Empty means to get all SDR records.
 func (c *Client) GetSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
-	var recordID uint16 = 0
-	var out = make([]*SDR, 0)
-	for {
-		res, err := c.GetSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
-		}
+	sdrs, err := c.getAllSDRs()
+	if err != nil {
+		return nil, err
+	}
 
-		if len(recordTypes) == 0 {
-			out = append(out, sdr)
-		} else {
-			for _, v := range recordTypes {
-				if sdr.RecordHeader.RecordType == v {
-					out = append(out, sdr)
-					break
-				}
-			}
-		}
+	if len(recordTypes) == 0 {
+		return sdrs, nil
+	}
 
-		recordID = sdr.NextRecordID
-		if recordID == 0xffff {
-			break
+	var out = make([]*SDR, 0)
+	for _, sdr := range sdrs {
+		for _, v := range recordTypes {
+			if sdr.RecordHeader.RecordType == v {
+				out = append(out, sdr)
+				break
+			}
 		}
 	}
-
 	return out, nil
 }
 
+// GetEntityTree returns the entities of the system organized by containment,
+// with the sensors, FRU devices and other devices attached to them.
+func (c *Client) GetEntityTree() (*EntityTree, error) {
+	sdrs, err := c.GetSDRs()
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRs failed, err: %s", err)
+	}
+	return NewEntityTree(sdrs), nil
+}
+
 // GetSDRsMap returns all Full/Compact SDRs grouped by GeneratorID and SensorNumber.
 // The sensor name can only be got from SDR record. So use this method to construct a map from which
 // you can get sensor name.
 func (c *Client) GetSDRsMap() (SDRMapBySensorNumber, error) {
 	var out = make(map[GeneratorID]map[SensorNumber]*SDR)
 
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
-		}
+	sdrs, err := c.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
+	if err != nil {
+		return nil, err
+	}
 
+	for _, sdr := range sdrs {
 		var generatorID GeneratorID
 		var sensorNumber SensorNumber
 
-		recordType := sdr.RecordHeader.RecordType
-		switch recordType {
+		switch sdr.RecordHeader.RecordType {
 		case SDRRecordTypeFullSensor:
 			generatorID = sdr.Full.GeneratorID
 			sensorNumber = sdr.Full.SensorNumber
@@ -178,21 +240,164 @@ func (c *Client) GetSDRsMap() (SDRMapBySensorNumber, error) {
 			generatorID = sdr.Compact.GeneratorID
 			sensorNumber = sdr.Compact.SensorNumber
 		}
-		if recordType != SDRRecordTypeFullSensor && recordType != SDRRecordTypeCompactSensor {
-			// ignored the SDR
-			continue
-		}
 
 		if _, ok := out[generatorID]; !ok {
 			out[generatorID] = make(map[SensorNumber]*SDR)
 		}
 		out[generatorID][sensorNumber] = sdr
+	}
+
+	return out, nil
+}
+
+func (c *Client) sdrCacheEnabled() bool {
+	return c.sdrCacheFile != "" || c.sdrCacheDir != ""
+}
+
+// getAllSDRs returns all the SDR records of the SDR Repository.
+// The SDR Repository cache is used if enabled.
+func (c *Client) getAllSDRs() ([]*SDR, error) {
+	if c.sdrCacheEnabled() {
+		cache, err := c.GetSDRCache()
+		if err != nil {
+			return nil, fmt.Errorf("GetSDRCache failed, err: %s", err)
+		}
+		return cache.SDRs()
+	}
+
+	cache, err := c.ReadSDRRepo()
+	if err != nil {
+		return nil, err
+	}
+	return cache.SDRs()
+}
+
+// ReadSDRRepo walks the SDR Repository and returns all the records, the SDR Repository cache is not used.
+// The records can be written to file in the "ipmitool sdr dump" format by SDRCache.Dump.
+func (c *Client) ReadSDRRepo() (*SDRCache, error) {
+	repoInfo, err := c.GetSDRRepoInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRRepoInfo failed, err: %s", err)
+	}
+
+	cache := NewSDRCache("", repoInfo)
+	if err := c.walkSDRRepo(cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// walkSDRRepo reads all the SDR records from the SDR Repository and adds them to the cache.
+// The records are read by partial reads with reservation, see sdrReader.
+func (c *Client) walkSDRRepo(cache *SDRCache) error {
+	reader := c.newSDRRepoReader()
+	var recordID uint16 = 0
+	for {
+		data, nextRecordID, err := reader.ReadRecord(recordID)
+		if err != nil {
+			return fmt.Errorf("read SDR for recordID (%#0x) failed, err: %s", recordID, err)
+		}
+		sdr, err := cache.Add(data, nextRecordID)
+		if err != nil {
+			return fmt.Errorf("add SDR for recordID (%#0x) failed, err: %s", recordID, err)
+		}
 
 		recordID = sdr.NextRecordID
 		if recordID == 0xffff {
 			break
 		}
 	}
+	return nil
+}
 
-	return out, nil
+// GetSDRCache returns the SDR Repository cache of the BMC.
+//
+// The cache is reused (from memory or from the cache file) if the most recent addition/erase
+// timestamps of the SDR Repository are unchanged, otherwise the SDR Repository is walked again
+// and the cache file under the cache dir is refreshed.
+//
+// The cache file set by WithSDRCacheFile is only read, it is never written.
+func (c *Client) GetSDRCache() (*SDRCache, error) {
+	repoInfo, err := c.GetSDRRepoInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRRepoInfo failed, err: %s", err)
+	}
+
+	if c.sdrCache != nil && c.sdrCache.IsFresh(repoInfo) {
+		return c.sdrCache, nil
+	}
+
+	if c.sdrCacheFile != "" {
+		cache, err := LoadSDRCache(c.sdrCacheFile)
+		if err != nil {
+			return nil, fmt.Errorf("LoadSDRCache failed, err: %s", err)
+		}
+		if !cache.IsFresh(repoInfo) {
+			c.Debugf("sdr cache %s is stale, read the SDR Repository\n", c.sdrCacheFile)
+			cache = NewSDRCache(cache.Key, repoInfo)
+			if err := c.walkSDRRepo(cache); err != nil {
+				return nil, err
+			}
+		}
+		c.sdrCache = cache
+		return cache, nil
+	}
+
+	dir := c.sdrCacheDir
+	if dir == "" {
+		dir = DefaultSDRCacheDir()
+	}
+	key, err := c.getSDRCacheKey()
+	if err != nil {
+		return nil, fmt.Errorf("getSDRCacheKey failed, err: %s", err)
+	}
+	file := filepath.Join(dir, sdrCacheFileName(key))
+
+	cache, err := LoadSDRCache(file)
+	if err != nil {
+		c.Debugf("load sdr cache from %s failed, err: %s\n", file, err)
+	} else if cache.Key == key && cache.IsFresh(repoInfo) {
+		c.Debugf("use sdr cache from %s\n", file)
+		c.sdrCache = cache
+		return cache, nil
+	} else {
+		c.Debugf("sdr cache %s is stale\n", file)
+	}
+
+	cache = NewSDRCache(key, repoInfo)
+	if err := c.walkSDRRepo(cache); err != nil {
+		return nil, err
+	}
+	c.sdrCache = cache
+
+	if err := cache.Save(file); err != nil {
+		c.Debugf("save sdr cache to %s failed, err: %s\n", file, err)
+	}
+	return cache, nil
+}
+
+// getSDRCacheKey returns the key identifying the BMC, composed of the
+// Manufacturer ID, Product ID, Device ID and the Device GUID (or System GUID).
+func (c *Client) getSDRCacheKey() (string, error) {
+	if c.sdrCacheKey != "" {
+		return c.sdrCacheKey, nil
+	}
+
+	deviceRes, err := c.GetDeviceID()
+	if err != nil {
+		return "", fmt.Errorf("GetDeviceID failed, err: %s", err)
+	}
+
+	var guid string
+	if guidRes, err := c.GetDeviceGUID(); err == nil {
+		guid = fmt.Sprintf("%x", guidRes.GUID)
+	} else if sysGUIDRes, err := c.GetSystemGUID(); err == nil {
+		guid = fmt.Sprintf("%x", sysGUIDRes.GUID)
+	} else {
+		// GUID not available, fallback to host
+		guid = c.Host
+	}
+
+	c.sdrCacheKey = fmt.Sprintf("%d-%d-%d-%s", deviceRes.ManufacturerID, deviceRes.ProductID, deviceRes.DeviceID, guid)
+	return c.sdrCacheKey, nil
 }
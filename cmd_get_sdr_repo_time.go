@@ -0,0 +1,49 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// 33.17 Get SDR Repository Time Command
+type GetSDRRepoTimeRequest struct {
+	// empty
+}
+
+type GetSDRRepoTimeResponse struct {
+	// Present Timestamp clock reading
+	Time time.Time
+}
+
+func (req *GetSDRRepoTimeRequest) Command() Command {
+	return CommandGetSDRRepoTime
+}
+
+func (req *GetSDRRepoTimeRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *GetSDRRepoTimeResponse) Unpack(msg []byte) error {
+	if len(msg) < 4 {
+		return ErrUnpackedDataTooShort
+	}
+
+	t, _, _ := unpackUint32L(msg, 0)
+	res.Time = parseTimestamp(t)
+	return nil
+}
+
+func (res *GetSDRRepoTimeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *GetSDRRepoTimeResponse) Format() string {
+	return fmt.Sprintf("%v", res.Time)
+}
+
+func (c *Client) GetSDRRepoTime() (response *GetSDRRepoTimeResponse, err error) {
+	request := &GetSDRRepoTimeRequest{}
+	response = &GetSDRRepoTimeResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -115,3 +115,28 @@ func (c *Client) GetSELEntries(startRecordID uint16) ([]*SEL, error) {
 
 	return out, nil
 }
+
+// walkSEL reads the SEL records starting from startRecordID in the order of SEL,
+// and calls fn for each record until fn returns false or the last record is read.
+func walkSEL(source selSource, startRecordID uint16, fn func(sel *SEL) bool) error {
+	recordID := startRecordID
+	for {
+		entry, err := source.GetSELEntry(0, recordID)
+		if err != nil {
+			return fmt.Errorf("GetSELEntry for record (%#04x) failed, err: %s", recordID, err)
+		}
+
+		sel, err := ParseSEL(entry.Data)
+		if err != nil {
+			return fmt.Errorf("ParseSEL failed, err: %s", err)
+		}
+		if !fn(sel) {
+			return nil
+		}
+
+		recordID = entry.NextRecordID
+		if recordID == 0xffff {
+			return nil
+		}
+	}
+}
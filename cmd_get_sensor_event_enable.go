@@ -147,3 +147,12 @@ func (c *Client) GetSensorEventEnable(sensorNumber uint8) (response *GetSensorEv
 	err = c.Exchange(request, response)
 	return
 }
+
+// GetSensorEventEnableBySensor is like GetSensorEventEnable, but the request is sent to the owner of the sensor.
+func (c *Client) GetSensorEventEnableBySensor(sensor *Sensor) (response *GetSensorEventEnableResponse, err error) {
+	err = c.withSensorOwner(sensor.GeneratorID, func() error {
+		response, err = c.GetSensorEventEnable(sensor.Number)
+		return err
+	})
+	return
+}
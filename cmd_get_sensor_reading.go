@@ -101,23 +101,24 @@ func (r *GetSensorReadingResponse) CompletionCodes() map[uint8]string {
 }
 
 func (r *GetSensorReadingResponse) ThresholdStatus() SensorThresholdStatus {
+	// more severe status takes precedence
+	if r.Above_UNR {
+		return SensorThresholdStatus_UNR
+	}
 	if r.Above_UCR {
 		return SensorThresholdStatus_UCR
 	}
 	if r.Above_UNC {
-		return SensorThresholdStatus_UCR
+		return SensorThresholdStatus_UNC
 	}
-	if r.Above_UNR {
-		return SensorThresholdStatus_UCR
+	if r.Below_LNR {
+		return SensorThresholdStatus_LNR
 	}
 	if r.Below_LCR {
-		return SensorThresholdStatus_UCR
+		return SensorThresholdStatus_LCR
 	}
 	if r.Below_LNC {
-		return SensorThresholdStatus_UCR
-	}
-	if r.Below_LNR {
-		return SensorThresholdStatus_UCR
+		return SensorThresholdStatus_LNC
 	}
 	return SensorThresholdStatus_OK
 }
@@ -0,0 +1,25 @@
+package ipmi
+
+import "testing"
+
+func Test_GetSensorReadingResponse_ThresholdStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      *GetSensorReadingResponse
+		expected SensorThresholdStatus
+	}{
+		{"ok", &GetSensorReadingResponse{}, SensorThresholdStatus_OK},
+		{"unc", &GetSensorReadingResponse{Above_UNC: true}, SensorThresholdStatus_UNC},
+		{"ucr", &GetSensorReadingResponse{Above_UNC: true, Above_UCR: true}, SensorThresholdStatus_UCR},
+		{"unr", &GetSensorReadingResponse{Above_UNC: true, Above_UCR: true, Above_UNR: true}, SensorThresholdStatus_UNR},
+		{"lnc", &GetSensorReadingResponse{Below_LNC: true}, SensorThresholdStatus_LNC},
+		{"lcr", &GetSensorReadingResponse{Below_LNC: true, Below_LCR: true}, SensorThresholdStatus_LCR},
+		{"lnr", &GetSensorReadingResponse{Below_LNC: true, Below_LCR: true, Below_LNR: true}, SensorThresholdStatus_LNR},
+	}
+
+	for _, test := range tests {
+		if got := test.res.ThresholdStatus(); got != test.expected {
+			t.Errorf("test %s not matched, got: %s, expected: %s", test.name, got, test.expected)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package ipmi
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
 
+// SensorFilterOption filters sensors after their readings are fetched.
 type SensorFilterOption func(sensor *Sensor) bool
 
 func SensorFilterOptionIsThreshold(sensor *Sensor) bool {
@@ -12,11 +17,140 @@ func SensorFilterOptionIsReadingValid(sensor *Sensor) bool {
 	return sensor.IsReadingValid()
 }
 
+// SDRFilterOption filters sensors by their SDRs only.
+// They are applied before any reading command is sent, so the sensors
+// filtered out cost nothing.
+type SDRFilterOption func(sdr *SDR) bool
+
+func SDRFilterOptionIsThreshold(sdr *SDR) bool {
+	return sdr.EventReadingType().IsThreshold()
+}
+
+// SDRFilterOptionEntity returns a filter option which chooses the sensors monitoring
+// the entity of entityID. If instances are specified, the entity instance must also match.
+func SDRFilterOptionEntity(entityID EntityID, instances ...EntityInstance) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		id, instance := sdr.Entity()
+		if id != entityID {
+			return false
+		}
+		if len(instances) == 0 {
+			return true
+		}
+		for _, v := range instances {
+			if v == instance {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SDRFilterOptionSensorType returns a filter option which chooses the sensors of any of sensorTypes.
+func SDRFilterOptionSensorType(sensorTypes ...SensorType) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		for _, v := range sensorTypes {
+			if sdr.SensorType() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SDRFilterOptionEventReadingType returns a filter option which chooses the sensors of any of eventReadingTypes.
+func SDRFilterOptionEventReadingType(eventReadingTypes ...EventReadingType) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		for _, v := range eventReadingTypes {
+			if sdr.EventReadingType() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SDRFilterOptionOwnerID returns a filter option which chooses the sensors owned by any of ownerIDs,
+// the owner ID is the Slave Address or Software ID of the sensor owner, regardless of the channel and LUN.
+func SDRFilterOptionOwnerID(ownerIDs ...uint8) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		for _, v := range ownerIDs {
+			if sdr.GeneratorID().OwnerID() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SDRFilterOptionOwnerLUN returns a filter option which chooses the sensors of any of the owner luns.
+func SDRFilterOptionOwnerLUN(luns ...uint8) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		for _, v := range luns {
+			if sdr.GeneratorID().LUN() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SDRFilterOptionNameGlob returns a filter option which chooses the sensors whose name
+// matches the shell pattern, see path.Match for the pattern syntax.
+func SDRFilterOptionNameGlob(pattern string) (SDRFilterOption, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid sensor name pattern (%s), err: %s", pattern, err)
+	}
+	return func(sdr *SDR) bool {
+		matched, _ := path.Match(pattern, sdr.SensorName())
+		return matched
+	}, nil
+}
+
+// SDRFilterOptionNameRegexp returns a filter option which chooses the sensors whose name matches re.
+func SDRFilterOptionNameRegexp(re *regexp.Regexp) SDRFilterOption {
+	return func(sdr *SDR) bool {
+		return re.MatchString(sdr.SensorName())
+	}
+}
+
+// FilterSDRs returns the SDRs those passed ALL filter options.
+func FilterSDRs(sdrs []*SDR, filterOptions ...SDRFilterOption) []*SDR {
+	if len(filterOptions) == 0 {
+		return sdrs
+	}
+
+	var out = make([]*SDR, 0)
+	for _, sdr := range sdrs {
+		var choose bool = true
+		for _, filterOption := range filterOptions {
+			if !filterOption(sdr) {
+				choose = false
+				break
+			}
+		}
+
+		if choose {
+			out = append(out, sdr)
+		}
+	}
+	return out
+}
+
 // GetSensors returns all sensors with their current readings and status.
 // If there's no filter options, it returns all sensors.
 // If there exists filter options, it only returns the sensors those
 // passed ALL filter options (filter option function returns true)
 func (c *Client) GetSensors(filterOptions ...SensorFilterOption) ([]*Sensor, error) {
+	return c.GetSensorsWithFilter(nil, filterOptions...)
+}
+
+// GetSensorsWithFilter is like GetSensors, but the sensors are firstly filtered by sdrFilterOptions,
+// only the sensors whose SDRs passed ALL sdrFilterOptions are read, then filtered by filterOptions.
+//
+// If the sensor error tolerance is enabled (see WithSensorErrorTolerance), the sensors failed to read
+// are still returned with the error attached to Sensor.Err, and the returned error is *SensorsError.
+func (c *Client) GetSensorsWithFilter(sdrFilterOptions []SDRFilterOption, filterOptions ...SensorFilterOption) ([]*Sensor, error) {
 	var out = make([]*Sensor, 0)
 
 	sdrs, err := c.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
@@ -24,10 +158,19 @@ func (c *Client) GetSensors(filterOptions ...SensorFilterOption) ([]*Sensor, err
 		return nil, fmt.Errorf("GetSDRs failed, err: %s", err)
 	}
 
-	for _, sdr := range sdrs {
+	sensorsErr := &SensorsError{}
+
+	for _, sdr := range FilterSDRs(sdrs, sdrFilterOptions...) {
+		sensorsErr.Total++
+
 		sensor, err := c.sdrToSensor(sdr)
 		if err != nil {
-			return nil, fmt.Errorf("GetSensorFromSDR failed, err: %s", err)
+			sensorErr, ok := err.(*SensorError)
+			if !ok || !c.sensorErrorTolerance {
+				return nil, fmt.Errorf("GetSensorFromSDR failed, err: %s", err)
+			}
+			sensor.Err = sensorErr
+			sensorsErr.Errors = append(sensorsErr.Errors, sensorErr)
 		}
 
 		var choose bool = true
@@ -43,6 +186,9 @@ func (c *Client) GetSensors(filterOptions ...SensorFilterOption) ([]*Sensor, err
 		}
 	}
 
+	if len(sensorsErr.Errors) > 0 {
+		return out, sensorsErr
+	}
 	return out, nil
 }
 
@@ -61,6 +207,21 @@ func (c *Client) GetSensorByID(sensorNumber uint8) (*Sensor, error) {
 	return sensor, nil
 }
 
+// GetSensorByKey returns the sensor with current reading and status by specified sensor owner, LUN and number.
+func (c *Client) GetSensorByKey(key SensorKey) (*Sensor, error) {
+	sdr, err := c.GetSDRBySensorKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRBySensorKey failed, err: %s", err)
+	}
+
+	sensor, err := c.sdrToSensor(sdr)
+	if err != nil {
+		return nil, fmt.Errorf("GetSensorFromSDR failed, err: %s", err)
+	}
+
+	return sensor, nil
+}
+
 // GetSensor returns the sensor with current reading and status by specified sensor name.
 func (c *Client) GetSensorByName(sensorName string) (*Sensor, error) {
 	sdr, err := c.GetSDRBySensorName(sensorName)
@@ -78,7 +239,31 @@ func (c *Client) GetSensorByName(sensorName string) (*Sensor, error) {
 
 // sdrToSensor convert SDR record to Sensor struct.
 // Only Full and Compact SDR records are meaningful here. Pass SDRs with other record types will return error.
+//
+// If a command failed when reading the sensor, the error is *SensorError,
+// and the sensor with the attributes retrieved before the error is also returned.
 func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
+	sensor, err := newSensorFromSDR(sdr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Debug("Get Sensor", fmt.Sprintf("Sensor Name: %s, Sensor Number: %#02x, Sensor Owner: %#04x\n", sensor.Name, sensor.Number, sensor.GeneratorID))
+
+	// The sensor is accessed at the owner and LUN given in the SDR,
+	// which may be a management controller other than the BMC.
+	if err := c.withSensorOwner(sensor.GeneratorID, func() error {
+		return c.setSensorReading(sensor)
+	}); err != nil {
+		// the sensor is also returned with the attributes retrieved before the error.
+		return sensor, err
+	}
+
+	return sensor, nil
+}
+
+// newSensorFromSDR returns the sensor with the attributes given in the SDR, the reading is not retrieved.
+func newSensorFromSDR(sdr *SDR) (*Sensor, error) {
 	if sdr == nil {
 		return nil, fmt.Errorf("nil sdr parameter")
 	}
@@ -87,6 +272,8 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 		SDRRecordType:    sdr.RecordHeader.RecordType,
 		HasAnalogReading: sdr.HasAnalogReading(),
 	}
+	sensor.GeneratorID = sdr.GeneratorID()
+	sensor.EntityID, sensor.EntityInstance = sdr.Entity()
 
 	switch sdr.RecordHeader.RecordType {
 	case SDRRecordTypeFullSensor:
@@ -98,9 +285,25 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 		sensor.SensorInitialization = sdr.Full.SensorInitialization
 		sensor.SensorCapabilitites = sdr.Full.SensorCapabilitites
 
+		sensor.Threshold.Mask = sdr.Full.Mask.Threshold
 		sensor.Threshold.LinearizationFunc = sdr.Full.LinearizationFunc
 		sensor.Threshold.ReadingFactors = sdr.Full.ReadingFactors
 
+		// The thresholds and hysteresis in SDR are used if they are not read from the sensor,
+		// see WithSkipSensorThresholds and WithSkipSensorHysteresis.
+		if sensor.hasSDRThresholds() {
+			sensor.Threshold.LNC_Raw = sdr.Full.LNC_Raw
+			sensor.Threshold.LCR_Raw = sdr.Full.LCR_Raw
+			sensor.Threshold.LNR_Raw = sdr.Full.LNR_Raw
+			sensor.Threshold.UNC_Raw = sdr.Full.UNC_Raw
+			sensor.Threshold.UCR_Raw = sdr.Full.UCR_Raw
+			sensor.Threshold.UNR_Raw = sdr.Full.UNR_Raw
+		}
+		if sensor.hasSDRHysteresis() {
+			sensor.Threshold.PositiveHysteresisRaw = sdr.Full.PositiveHysteresisRaw
+			sensor.Threshold.NegativeHysteresisRaw = sdr.Full.NegativeHysteresisRaw
+		}
+
 	case SDRRecordTypeCompactSensor:
 		sensor.Number = uint8(sdr.Compact.SensorNumber)
 		sensor.Name = string(sdr.Compact.IDStringBytes)
@@ -110,12 +313,19 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 		sensor.SensorInitialization = sdr.Compact.SensorInitialization
 		sensor.SensorCapabilitites = sdr.Compact.SensorCapabilitites
 
+		sensor.Threshold.Mask = sdr.Compact.Mask.Threshold
+		// Compact SDRs have no reading factors, the thresholds can not be converted.
+		sensor.setThresholdsUnavailable()
+
 	default:
 		return nil, fmt.Errorf("only support Full or Compact SDR record type, input is %s", sdr.RecordHeader.RecordType)
 	}
 
-	c.Debug("Get Sensor", fmt.Sprintf("Sensor Name: %s, Sensor Number: %#02x\n", sensor.Name, sensor.Number))
+	return sensor, nil
+}
 
+// setSensorReading retrieves the reading and the discrete or threshold attributes of the sensor.
+func (c *Client) setSensorReading(sensor *Sensor) error {
 	readingRes, err := c.GetSensorReading(sensor.Number)
 	if err != nil {
 		if resErr, ok := err.(*ResponseError); ok {
@@ -124,14 +334,16 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 				// above completion codes CAN be ignored
 				// it normally means the sensor device does not exist.
 				c.Debug(fmt.Sprintf("GetSensorReading for sensor %#02x failed", sensor.Number), err)
-				return sensor, nil
+				return nil
 			} else {
 				// other completion codes CANNOT be ignored
-				return nil, fmt.Errorf("GetSensorReading for sensor %#02x failed, err: %s", sensor.Number, err)
+				sensor.readingUnavailable = true
+				return newSensorError(sensor, CommandGetSensorReading, err)
 			}
 		} else {
 			// for all other errors
-			return nil, fmt.Errorf("GetSensorReading for sensor %#02x failed, err: %s", sensor.Number, err)
+			sensor.readingUnavailable = true
+			return newSensorError(sensor, CommandGetSensorReading, err)
 		}
 	} else {
 		sensor.Raw = readingRes.AnalogReading
@@ -149,27 +361,27 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 	if sensor.scanningDisabled {
 		// Sensor scanning disabled, no need to continue
 		c.Debug(fmt.Sprintf(":( Sensor [%s](%#02x) scanning disabled\n", sensor.Name, sensor.Number), "")
-		return sensor, nil
+		return nil
 	}
 
 	if !sensor.EventReadingType.IsThreshold() || !sensor.SensorUnit.IsAnalog() {
 		if err := c.setSensorDiscrete(sensor); err != nil {
-			return nil, fmt.Errorf("setSensorDiscrete failed, err: %s", err)
+			return err
 		}
 	} else {
 		if err := c.setSensorThreshold(sensor); err != nil {
-			return nil, fmt.Errorf("setSensorThreshold failed, err: %s", err)
+			return err
 		}
 	}
 
-	return sensor, nil
+	return nil
 }
 
 // setSensorDiscrete retrieves sensor attributes for discrete sensors.
 func (c *Client) setSensorDiscrete(sensor *Sensor) error {
 	statusRes, err := c.GetSensorEventStatus(sensor.Number)
 	if err != nil {
-		return fmt.Errorf("GetSensorEventStatus for sensor %#02x failed, err: %s", sensor.Number, err)
+		return newSensorError(sensor, CommandGetSensorEventStatus, err)
 	}
 	sensor.OccuredEvents = statusRes.SensorEventFlag.TrueEvents()
 	return nil
@@ -186,42 +398,50 @@ func (c *Client) setSensorThreshold(sensor *Sensor) error {
 	if sensor.Threshold.LinearizationFunc.IsNonLinear() {
 		factorsRes, err := c.GetSensorReadingFactors(sensor.Number, sensor.Raw)
 		if err != nil {
-			return fmt.Errorf("GetSensorReadingFactors for sensor %#02x failed, err: %s", sensor.Number, err)
+			return newSensorError(sensor, CommandGetSensorReadingFactors, err)
 		}
 		sensor.Threshold.ReadingFactors = factorsRes.ReadingFactors
 	}
 
-	thesholdRes, err := c.GetSensorThresholds(sensor.Number)
-	if err != nil {
-		return fmt.Errorf("GetSensorThresholds for sensor %#02x failed, err: %s", sensor.Number, err)
-	}
-	sensor.Threshold.Mask.UNR.Readable = thesholdRes.UNR_Readable
-	sensor.Threshold.Mask.UCR.Readable = thesholdRes.UCR_Readable
-	sensor.Threshold.Mask.UNC.Readable = thesholdRes.UNC_Readable
-	sensor.Threshold.Mask.LNR.Readable = thesholdRes.LNR_Readable
-	sensor.Threshold.Mask.LCR.Readable = thesholdRes.LCR_Readable
-	sensor.Threshold.Mask.LNC.Readable = thesholdRes.LNC_Readable
-	sensor.Threshold.LNC_Raw = thesholdRes.LNC_Raw
-	sensor.Threshold.LCR_Raw = thesholdRes.LCR_Raw
-	sensor.Threshold.LNR_Raw = thesholdRes.LNR_Raw
-	sensor.Threshold.UNC_Raw = thesholdRes.UNC_Raw
-	sensor.Threshold.UCR_Raw = thesholdRes.UCR_Raw
-	sensor.Threshold.UNR_Raw = thesholdRes.UNR_Raw
-	sensor.Threshold.LNC = sensor.ConvertReading(thesholdRes.LNC_Raw)
-	sensor.Threshold.LCR = sensor.ConvertReading(thesholdRes.LCR_Raw)
-	sensor.Threshold.LNR = sensor.ConvertReading(thesholdRes.LNR_Raw)
-	sensor.Threshold.UNC = sensor.ConvertReading(thesholdRes.UNC_Raw)
-	sensor.Threshold.UCR = sensor.ConvertReading(thesholdRes.UCR_Raw)
-	sensor.Threshold.UNR = sensor.ConvertReading(thesholdRes.UNR_Raw)
-
-	hysteresisRes, err := c.GetSensorHysteresis(sensor.Number)
-	if err != nil {
-		return fmt.Errorf("GetSensorHysteresis for sensor %#02x failed, err: %s", sensor.Number, err)
+	if c.skipSensorThresholds {
+		if !sensor.hasSDRThresholds() {
+			sensor.setThresholdsUnavailable()
+		}
+	} else {
+		thesholdRes, err := c.GetSensorThresholds(sensor.Number)
+		if err != nil {
+			return newSensorError(sensor, CommandGetSensorThresholds, err)
+		}
+		sensor.Threshold.Mask.UNR.Readable = thesholdRes.UNR_Readable
+		sensor.Threshold.Mask.UCR.Readable = thesholdRes.UCR_Readable
+		sensor.Threshold.Mask.UNC.Readable = thesholdRes.UNC_Readable
+		sensor.Threshold.Mask.LNR.Readable = thesholdRes.LNR_Readable
+		sensor.Threshold.Mask.LCR.Readable = thesholdRes.LCR_Readable
+		sensor.Threshold.Mask.LNC.Readable = thesholdRes.LNC_Readable
+		sensor.Threshold.LNC_Raw = thesholdRes.LNC_Raw
+		sensor.Threshold.LCR_Raw = thesholdRes.LCR_Raw
+		sensor.Threshold.LNR_Raw = thesholdRes.LNR_Raw
+		sensor.Threshold.UNC_Raw = thesholdRes.UNC_Raw
+		sensor.Threshold.UCR_Raw = thesholdRes.UCR_Raw
+		sensor.Threshold.UNR_Raw = thesholdRes.UNR_Raw
+	}
+	sensor.Threshold.LNC = sensor.ConvertReading(sensor.Threshold.LNC_Raw)
+	sensor.Threshold.LCR = sensor.ConvertReading(sensor.Threshold.LCR_Raw)
+	sensor.Threshold.LNR = sensor.ConvertReading(sensor.Threshold.LNR_Raw)
+	sensor.Threshold.UNC = sensor.ConvertReading(sensor.Threshold.UNC_Raw)
+	sensor.Threshold.UCR = sensor.ConvertReading(sensor.Threshold.UCR_Raw)
+	sensor.Threshold.UNR = sensor.ConvertReading(sensor.Threshold.UNR_Raw)
+
+	if !c.skipSensorHysteresis {
+		hysteresisRes, err := c.GetSensorHysteresis(sensor.Number)
+		if err != nil {
+			return newSensorError(sensor, CommandGetSensorHysteresis, err)
+		}
+		sensor.Threshold.PositiveHysteresisRaw = hysteresisRes.PositiveRaw
+		sensor.Threshold.NegativeHysteresisRaw = hysteresisRes.NegativeRaw
 	}
-	sensor.Threshold.PositiveHysteresisRaw = hysteresisRes.PositiveRaw
-	sensor.Threshold.NegativeHysteresisRaw = hysteresisRes.NegativeRaw
-	sensor.Threshold.PositiveHysteresis = sensor.ConvertSensorHysteresis(hysteresisRes.PositiveRaw)
-	sensor.Threshold.NegativeHysteresis = sensor.ConvertSensorHysteresis(hysteresisRes.NegativeRaw)
+	sensor.Threshold.PositiveHysteresis = sensor.ConvertSensorHysteresis(sensor.Threshold.PositiveHysteresisRaw)
+	sensor.Threshold.NegativeHysteresis = sensor.ConvertSensorHysteresis(sensor.Threshold.NegativeHysteresisRaw)
 
 	return nil
 }
@@ -0,0 +1,182 @@
+package ipmi
+
+import (
+	"encoding/hex"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_FilterSDRs(t *testing.T) {
+	sdrs := make([]*SDR, 0, len(syntheticSDRRecords))
+	for _, record := range syntheticSDRRecords {
+		raw, _ := hex.DecodeString(record.raw)
+		sdr, err := ParseSDR(raw, 0xffff)
+		if err != nil {
+			t.Fatalf("test %s ParseSDR failed, err: %s", record.name, err)
+		}
+		sdrs = append(sdrs, sdr)
+	}
+	// the 12V sensor is on LUN 1 of the BMC
+	sdrs[1].Full.GeneratorID = 0x0120
+
+	nameGlob, err := SDRFilterOptionNameGlob("PS* Status")
+	if err != nil {
+		t.Fatalf("test SDRFilterOptionNameGlob failed, err: %s", err)
+	}
+
+	tests := []struct {
+		name          string
+		filterOptions []SDRFilterOption
+		expected      []string
+	}{
+		{"threshold", []SDRFilterOption{SDRFilterOptionIsThreshold}, []string{"CPU1 Temp", "12V", "Airflow"}},
+		{"sensor type", []SDRFilterOption{SDRFilterOptionSensorType(SensorTypeVoltage)}, []string{"12V"}},
+		{"entity", []SDRFilterOption{SDRFilterOptionEntity(0x03, 1)}, []string{"CPU1 Temp"}},
+		{"entity without instance", []SDRFilterOption{SDRFilterOptionEntity(0x07)}, []string{"12V", "Airflow"}},
+		{"event reading type", []SDRFilterOption{SDRFilterOptionEventReadingType(EventReadingTypeSensorSpecific)}, []string{"PS1 Status", "DIMM", "Watchdog"}},
+		{"name glob", []SDRFilterOption{nameGlob}, []string{"PS1 Status"}},
+		{"name regexp", []SDRFilterOption{SDRFilterOptionNameRegexp(regexp.MustCompile(`^(CPU|DIMM)`))}, []string{"CPU1 Temp", "DIMM"}},
+		{"owner", []SDRFilterOption{SDRFilterOptionOwnerID(0x20), SDRFilterOptionIsThreshold}, []string{"CPU1 Temp", "12V", "Airflow"}},
+		{"owner not matched", []SDRFilterOption{SDRFilterOptionOwnerID(0x2c)}, []string{}},
+		{"owner lun", []SDRFilterOption{SDRFilterOptionOwnerLUN(1)}, []string{"12V"}},
+		{"combined", []SDRFilterOption{SDRFilterOptionIsThreshold, SDRFilterOptionEntity(0x07, 1)}, []string{"12V", "Airflow"}},
+	}
+
+	for _, test := range tests {
+		got := FilterSDRs(sdrs, test.filterOptions...)
+		if len(got) != len(test.expected) {
+			t.Errorf("test %s not matched, got: %d sdrs, expected: %d", test.name, len(got), len(test.expected))
+			continue
+		}
+		for i, sdr := range got {
+			if sdr.SensorName() != test.expected[i] {
+				t.Errorf("test %s not matched, got: %s, expected: %s", test.name, sdr.SensorName(), test.expected[i])
+			}
+		}
+	}
+}
+
+func Test_ParseSensorType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SensorType
+	}{
+		{"fan", SensorTypeFan},
+		{"Temperature", SensorTypeTemperature},
+		{"power supply", SensorTypePowserSupply},
+		{"0x04", SensorTypeFan},
+		{"1", SensorTypeTemperature},
+	}
+
+	for _, test := range tests {
+		got, err := ParseSensorType(test.input)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.input, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("test %s not matched, got: %d, expected: %d", test.input, got, test.expected)
+		}
+	}
+
+	if _, err := ParseSensorType("nosuchtype"); err == nil {
+		t.Errorf("test nosuchtype expected error")
+	}
+}
+
+func Test_newSensorFromSDR_thresholds(t *testing.T) {
+	readable := Mask_Thresholds{}
+	readable.UNC.Readable = true
+	readable.UCR.Readable = true
+
+	newFull := func(initThresholds bool, thresholdAccess SensorThresholdAccess) *SDR {
+		return &SDR{
+			RecordHeader: &SDRHeader{RecordType: SDRRecordTypeFullSensor},
+			Full: &SDRFull{
+				SensorEventReadingType: EventReadingTypeThreshold,
+				SensorInitialization:   SensorInitialization{InitThresholds: initThresholds},
+				SensorCapabilitites:    SensorCapabilitites{ThresholdAccess: thresholdAccess},
+				Mask:                   Mask{Threshold: readable},
+				ReadingFactors:         ReadingFactors{M: 1},
+				UNC_Raw:                85,
+				UCR_Raw:                95,
+				PositiveHysteresisRaw:  2,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sdr      *SDR
+		expected string // UCR of the sensor
+	}{
+		{"full initialized", newFull(true, SensorThresholdAccess_Readable), "95.000"},
+		{"full fixed", newFull(false, SensorThresholdAccess_Fixed), "95.000"},
+		{"full not initialized", newFull(false, SensorThresholdAccess_Readable), "N/A"},
+		{"compact", &SDR{
+			RecordHeader: &SDRHeader{RecordType: SDRRecordTypeCompactSensor},
+			Compact: &SDRCompact{
+				SensorEventReadingType: EventReadingTypeThreshold,
+				SensorInitialization:   SensorInitialization{InitThresholds: true},
+				Mask:                   Mask{Threshold: readable},
+			},
+		}, "N/A"},
+	}
+
+	// the thresholds are not read from the sensor, so only the ones in SDR are available
+	c := &Client{skipSensorThresholds: true, skipSensorHysteresis: true}
+	for _, test := range tests {
+		sensor, err := newSensorFromSDR(test.sdr)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+		if err := c.setSensorThreshold(sensor); err != nil {
+			t.Errorf("test %s setSensorThreshold failed, err: %s", test.name, err)
+			continue
+		}
+		if got := sensor.ThresholdStr(SensorThresholdType_UCR); got != test.expected {
+			t.Errorf("test %s not matched, got: %s, expected: %s", test.name, got, test.expected)
+		}
+		// no hysteresis is initialized by the SDRs
+		if sensor.Threshold.PositiveHysteresisRaw != 0 {
+			t.Errorf("test %s hysteresis not matched, got: %d, expected: 0", test.name, sensor.Threshold.PositiveHysteresisRaw)
+		}
+	}
+}
+
+func Test_setSensorReading_Failed(t *testing.T) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fakeLANPeer(t, conn, lanResponseCC(NetFnSensorEventResponse, CommandGetSensorReading.ID, CompletionCodeUnspecifiedError, nil))
+	}()
+
+	c, err := NewClient("127.0.0.1", conn.LocalAddr().(*net.UDPAddr).Port, "admin", "admin")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	c.Interface = InterfaceLan
+	c.v20 = false
+	c.WithTimeout(200 * time.Millisecond)
+	defer c.udpClient.Close()
+
+	sensor := &Sensor{Number: 0x30, Name: "CPU1 Temp", EventReadingType: EventReadingTypeThreshold}
+	err = c.setSensorReading(sensor)
+	<-done
+
+	if _, ok := err.(*SensorError); !ok {
+		t.Fatalf("test expected SensorError, got: %v", err)
+	}
+	// the zero value of the failed reading must not be taken as valid
+	if sensor.IsReadingValid() {
+		t.Errorf("test failed reading expected to be invalid")
+	}
+}
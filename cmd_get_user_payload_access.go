@@ -0,0 +1,56 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 24.7 Get User Payload Access Command
+type GetUserPayloadAccessRequest struct {
+	ChannelNumber uint8
+	UserID        uint8
+}
+
+type GetUserPayloadAccessResponse struct {
+	PayloadAccess
+}
+
+func (req *GetUserPayloadAccessRequest) Command() Command {
+	return CommandGetUserPayloadAccess
+}
+
+func (req *GetUserPayloadAccessRequest) Pack() []byte {
+	return []byte{req.ChannelNumber & 0x0f, req.UserID & 0x3f}
+}
+
+func (res *GetUserPayloadAccessResponse) Unpack(msg []byte) error {
+	return res.PayloadAccess.Unpack(msg)
+}
+
+func (res *GetUserPayloadAccessResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *GetUserPayloadAccessResponse) Format() string {
+	payloads := []string{}
+	for _, payloadType := range res.EnabledPayloads() {
+		payloads = append(payloads, payloadType.String())
+	}
+
+	return fmt.Sprintf(`SOL Payload Enabled : %s
+Enabled Payloads    : %s`,
+		formatBool(res.Enabled(PayloadTypeSOL), "true", "false"),
+		strings.Join(payloads, ", "),
+	)
+}
+
+// GetUserPayloadAccess returns the payload access settings for the user on the channel.
+func (c *Client) GetUserPayloadAccess(channelNumber uint8, userID uint8) (response *GetUserPayloadAccessResponse, err error) {
+	request := &GetUserPayloadAccessRequest{
+		ChannelNumber: channelNumber,
+		UserID:        userID,
+	}
+	response = &GetUserPayloadAccessResponse{}
+	err = c.Exchange(request, response)
+	return
+}
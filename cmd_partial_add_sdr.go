@@ -0,0 +1,73 @@
+package ipmi
+
+import "fmt"
+
+// 33.14 Partial Add SDR Command
+type PartialAddSDRRequest struct {
+	ReservationID uint16
+
+	// Record ID of the record being added. 0000h for the first partial add,
+	// use the Record ID returned by the first partial add for the subsequent partial adds.
+	RecordID uint16
+
+	// Offset into record
+	Offset uint8
+
+	// [3:0] In progress.
+	// 0h = partial add in progress.
+	// 1h = last record data being transferred with this request
+	LastData bool
+
+	// SDR data, the first partial add must include the record header.
+	RecordData []byte
+}
+
+type PartialAddSDRResponse struct {
+	RecordID uint16
+}
+
+func (req *PartialAddSDRRequest) Command() Command {
+	return CommandPartialAddSDR
+}
+
+func (req *PartialAddSDRRequest) Pack() []byte {
+	out := make([]byte, 6+len(req.RecordData))
+	packUint16L(req.ReservationID, out, 0)
+	packUint16L(req.RecordID, out, 2)
+	packUint8(req.Offset, out, 4)
+	if req.LastData {
+		packUint8(0x01, out, 5)
+	} else {
+		packUint8(0x00, out, 5)
+	}
+	packBytes(req.RecordData, out, 6)
+	return out
+}
+
+func (res *PartialAddSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *PartialAddSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "record rejected due to length mismatch",
+		0x81: "cannot execute command, SDR Repository in update mode",
+	}
+}
+
+func (res *PartialAddSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %#04x", res.RecordID)
+}
+
+// PartialAddSDR adds a part of a sensor record to the SDR Repository.
+// The record is not available until the last part is added.
+func (c *Client) PartialAddSDR(request *PartialAddSDRRequest) (response *PartialAddSDRResponse, err error) {
+	response = &PartialAddSDRResponse{}
+	err = c.Exchange(request, response)
+	return
+}
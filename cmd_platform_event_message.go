@@ -20,29 +20,44 @@ type PlatformEventMessageRequest struct {
 	EventDir     EventDir
 	EventType    EventReadingType
 	EventData    EventData
+
+	// whether GeneratorID is carried in the request data, it is set by Client.PlatformEventMessage for the system interface.
+	withGeneratorID bool
 }
 
+// SystemInterfaceEventSoftwareID is the System Software ID sent as the Generator ID of Platform Event Messages
+// over the system interface if the Generator ID is not a Software ID, same as ipmitool.
+const SystemInterfaceEventSoftwareID SoftwareID = 0x41
+
 type PlatformEventMessageResponse struct {
 }
 
 func (req *PlatformEventMessageRequest) Pack() []byte {
-	out := make([]byte, 8)
-	out[0] = req.GeneratorID
-	out[1] = req.EvMRev
-	out[2] = req.SensorType
-	out[3] = req.SensorNumber
+	out := make([]byte, 7)
+	out[0] = req.EvMRev
+	out[1] = req.SensorType
+	out[2] = req.SensorNumber
 
-	var b4 = uint8(req.EventType)
+	var b3 = uint8(req.EventType)
 	if req.EventDir {
-		b4 |= 0x80
+		b3 |= 0x80
 	}
-	out[4] = b4
+	out[3] = b3
 
-	out[5] = req.EventData.EventData1
-	out[6] = req.EventData.EventData2
-	out[7] = req.EventData.EventData3
+	out[4] = req.EventData.EventData1
+	out[5] = req.EventData.EventData2
+	out[6] = req.EventData.EventData3
 
-	return []byte{}
+	if req.withGeneratorID {
+		// The Generator ID carried in the request data is a System Software ID (bit 0 is 1b),
+		// a Slave Address (like the default GeneratorBMC) is replaced by the software ID used by ipmitool.
+		generatorID := req.GeneratorID
+		if generatorID&0x01 == 0 {
+			generatorID = uint8(SystemInterfaceEventSoftwareID)
+		}
+		return append([]byte{generatorID}, out...)
+	}
+	return out
 }
 
 func (req *PlatformEventMessageRequest) Command() Command {
@@ -62,7 +77,9 @@ func (res *PlatformEventMessageResponse) Format() string {
 }
 
 func (c *Client) PlatformEventMessage(request *PlatformEventMessageRequest) (response *PlatformEventMessageResponse, err error) {
-	// Todo, consider GeneratorID
+	// The Generator ID is equated to the requester's address for IPMB (LAN) messages,
+	// and carried in the request data for the system interface.
+	request.withGeneratorID = c.Interface == "" || c.Interface == InterfaceOpen
 	response = &PlatformEventMessageResponse{}
 	err = c.Exchange(request, response)
 	return
@@ -0,0 +1,76 @@
+package ipmi
+
+// 35.12 Re-arm Sensor Events Command
+type RearmSensorEventsRequest struct {
+	SensorNumber uint8
+
+	// true to re-arm all event status from this sensor, the SensorEventFlag is ignored.
+	RearmAllEvents bool
+
+	// The events selected to be re-armed.
+	SensorEventFlag
+}
+
+type RearmSensorEventsResponse struct {
+	// empty
+}
+
+func (req *RearmSensorEventsRequest) Command() Command {
+	return CommandRearmSensorEvents
+}
+
+func (req *RearmSensorEventsRequest) Pack() []byte {
+	out := make([]byte, 2)
+	packUint8(req.SensorNumber, out, 0)
+
+	var b uint8
+	if !req.RearmAllEvents {
+		// 1b = re-arm selected events
+		b = setBit7(b)
+	}
+	packUint8(b, out, 1)
+
+	if req.RearmAllEvents {
+		return out
+	}
+	return append(out, req.SensorEventFlag.pack()...)
+}
+
+func (res *RearmSensorEventsResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (r *RearmSensorEventsResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *RearmSensorEventsResponse) Format() string {
+	return ""
+}
+
+// RearmSensorEvents re-arms the specified events of the sensor, so the events can be generated again
+// if the event conditions still exist. If no events are specified, all events of the sensor are re-armed.
+func (c *Client) RearmSensorEvents(sensorNumber uint8, events ...SensorEvent) (response *RearmSensorEventsResponse, err error) {
+	request := &RearmSensorEventsRequest{
+		SensorNumber:    sensorNumber,
+		RearmAllEvents:  len(events) == 0,
+		SensorEventFlag: NewSensorEventFlag(events...),
+	}
+	response = &RearmSensorEventsResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// RearmSensorEventsByName re-arms both the assertion and deassertion events of the sensor specified by names,
+// all events of the sensor are re-armed if no names are specified.
+// See ParseSensorEvents for the event names.
+func (c *Client) RearmSensorEventsByName(sensor *Sensor, names ...string) error {
+	events, err := ParseSensorEvents(sensor, names...)
+	if err != nil {
+		return err
+	}
+	return c.withSensorOwner(sensor.GeneratorID, func() error {
+		_, err := c.RearmSensorEvents(sensor.Number, events...)
+		return err
+	})
+}
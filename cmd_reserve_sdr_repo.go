@@ -0,0 +1,44 @@
+package ipmi
+
+// 33.11 Reserve SDR Repository Command
+type ReserveSDRRepoRequest struct {
+	// empty
+}
+
+type ReserveSDRRepoResponse struct {
+	ReservationID uint16
+}
+
+func (req *ReserveSDRRepoRequest) Command() Command {
+	return CommandReserveSDRRepo
+}
+
+func (req *ReserveSDRRepoRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *ReserveSDRRepoResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShort
+	}
+
+	res.ReservationID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (r *ReserveSDRRepoResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *ReserveSDRRepoResponse) Format() string {
+	return ""
+}
+
+// This command is used to set the present 'owner' of the repository, as identified by the Software ID or the Requester Slave Address from the command.
+// The reservation is required for partial reads (Get SDR with a non-zero offset) and the SDR write commands.
+func (c *Client) ReserveSDRRepo() (response *ReserveSDRRepoResponse, err error) {
+	request := &ReserveSDRRepoRequest{}
+	response = &ReserveSDRRepoResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,57 @@
+package ipmi
+
+import "fmt"
+
+// 33.21 Run Initialization Agent Command
+type RunInitializationAgentRequest struct {
+	// [0] 1b = run initialization agent
+	//     0b = get last execution status
+	GetStatusOnly bool
+}
+
+type RunInitializationAgentResponse struct {
+	// [0] 0b = initialization agent in progress
+	//     1b = initialization agent completed
+	Completed bool
+}
+
+func (req *RunInitializationAgentRequest) Command() Command {
+	return CommandRunInitializationAgent
+}
+
+func (req *RunInitializationAgentRequest) Pack() []byte {
+	if req.GetStatusOnly {
+		return []byte{0x00}
+	}
+	return []byte{0x01}
+}
+
+func (res *RunInitializationAgentResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShort
+	}
+
+	b, _, _ := unpackUint8(msg, 0)
+	res.Completed = isBit0Set(b)
+	return nil
+}
+
+func (res *RunInitializationAgentResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *RunInitializationAgentResponse) Format() string {
+	return fmt.Sprintf("Initialization Agent : %s", formatBool(res.Completed, "completed", "in progress"))
+}
+
+// RunInitializationAgent runs the initialization agent, which sets the sensors
+// to the initial values (thresholds, hysteresis, event enables) given in their SDRs.
+// If getStatusOnly is true, it only returns the status of the last execution.
+func (c *Client) RunInitializationAgent(getStatusOnly bool) (response *RunInitializationAgentResponse, err error) {
+	request := &RunInitializationAgentRequest{
+		GetStatusOnly: getStatusOnly,
+	}
+	response = &RunInitializationAgentResponse{}
+	err = c.Exchange(request, response)
+	return
+}
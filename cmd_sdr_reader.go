@@ -0,0 +1,186 @@
+package ipmi
+
+import "fmt"
+
+const (
+	sdrRecordHeaderSize uint8 = 5
+
+	// max times to restart reading a record when the reservation is canceled.
+	sdrReadMaxRestarts int = 8
+)
+
+// sdrReader reads SDR records by partial reads, it works for both the SDR Repository (Get SDR)
+// and the Device SDR Repository (Get Device SDR).
+//
+// The record header is read first, then the record body is fetched in chunks.
+// The chunk size is halved when the BMC responds with 0xCA (cannot return requested number of bytes),
+// and the shrunk size is kept for the following records.
+// When the reservation is canceled (0xC5), a new reservation is obtained and the record is read again.
+type sdrReader struct {
+	client *Client
+
+	reserve func() (reservationID uint16, err error)
+	read    func(reservationID uint16, recordID uint16, offset uint8, length uint8) (nextRecordID uint16, data []byte, err error)
+
+	reserved      bool
+	reservationID uint16
+
+	// max number of bytes to read in one request
+	maxReadBytes uint8
+}
+
+// newSDRRepoReader returns a sdrReader for the SDR Repository.
+func (c *Client) newSDRRepoReader() *sdrReader {
+	return &sdrReader{
+		client: c,
+		reserve: func() (uint16, error) {
+			res, err := c.ReserveSDRRepo()
+			if err != nil {
+				return 0, err
+			}
+			return res.ReservationID, nil
+		},
+		read: func(reservationID uint16, recordID uint16, offset uint8, length uint8) (uint16, []byte, error) {
+			request := &GetSDRRequest{
+				ReservationID: reservationID,
+				RecordID:      recordID,
+				Offset:        offset,
+				Read:          length,
+			}
+			response := &GetSDRResponse{}
+			if err := c.Exchange(request, response); err != nil {
+				return 0, nil, err
+			}
+			return response.NextRecordID, response.RecordData, nil
+		},
+		maxReadBytes: 0xff,
+	}
+}
+
+// newDeviceSDRReader returns a sdrReader for the Device SDR Repository.
+func (c *Client) newDeviceSDRReader() *sdrReader {
+	return &sdrReader{
+		client: c,
+		reserve: func() (uint16, error) {
+			res, err := c.ReserveDeviceSDRRepo()
+			if err != nil {
+				return 0, err
+			}
+			return res.ReservationID, nil
+		},
+		read: func(reservationID uint16, recordID uint16, offset uint8, length uint8) (uint16, []byte, error) {
+			request := &GetDeviceSDRRequest{
+				ReservationID: reservationID,
+				RecordID:      recordID,
+				ReadOffset:    offset,
+				ReadBytes:     length,
+			}
+			response := &GetDeviceSDRResponse{}
+			if err := c.Exchange(request, response); err != nil {
+				return 0, nil, err
+			}
+			return response.NextRecordID, response.RecordData, nil
+		},
+		maxReadBytes: 0xff,
+	}
+}
+
+// doReserve obtains a new reservation. If the BMC does not support reservation,
+// reservation ID 0 is used, then only reads with zero offset are guaranteed to work.
+func (r *sdrReader) doReserve() {
+	reservationID, err := r.reserve()
+	if err != nil {
+		r.client.Debugf("reserve sdr repository failed, use reservation id 0, err: %s\n", err)
+		reservationID = 0
+	}
+	r.reservationID = reservationID
+	r.reserved = true
+}
+
+// ReadRecord reads the whole raw data (including the header) of the SDR record.
+func (r *sdrReader) ReadRecord(recordID uint16) (data []byte, nextRecordID uint16, err error) {
+	if !r.reserved {
+		r.doReserve()
+	}
+
+	for i := 0; i <= sdrReadMaxRestarts; i++ {
+		data, nextRecordID, err = r.readRecord(recordID)
+		if err == nil {
+			return data, nextRecordID, nil
+		}
+
+		if resErr, ok := err.(*ResponseError); ok && resErr.CompletionCode() == CompletionCodeReservationCanceled {
+			r.client.Debugf("reservation canceled when reading SDR record (%#04x), reserve again and restart\n", recordID)
+			r.doReserve()
+			continue
+		}
+		return nil, 0, err
+	}
+
+	return nil, 0, fmt.Errorf("reservation canceled %d times when reading SDR record (%#04x)", sdrReadMaxRestarts+1, recordID)
+}
+
+func (r *sdrReader) readRecord(recordID uint16) ([]byte, uint16, error) {
+	nextRecordID, header, err := r.readChunk(recordID, 0, sdrRecordHeaderSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(header) < int(sdrRecordHeaderSize) {
+		return nil, 0, fmt.Errorf("sdr record header too short, got %d bytes", len(header))
+	}
+
+	recordLength := header[4]
+	total := int(sdrRecordHeaderSize) + int(recordLength)
+
+	data := make([]byte, 0, total)
+	data = append(data, header[:sdrRecordHeaderSize]...)
+
+	for len(data) < total {
+		// the offset into record of Get SDR command is one byte
+		if len(data) > 0xff {
+			return nil, 0, fmt.Errorf("read SDR record (%#04x) at offset %d exceeds the max offset %#02x", recordID, len(data), 0xff)
+		}
+
+		remaining := total - len(data)
+		length := r.maxReadBytes
+		if remaining < int(length) {
+			length = uint8(remaining)
+		}
+
+		_, chunk, err := r.readChunk(recordID, uint8(len(data)), length)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(chunk) == 0 {
+			return nil, 0, fmt.Errorf("read SDR record (%#04x) at offset %d returned no data", recordID, len(data))
+		}
+		if len(chunk) > remaining {
+			chunk = chunk[:remaining]
+		}
+		data = append(data, chunk...)
+	}
+
+	return data, nextRecordID, nil
+}
+
+// readChunk reads length bytes at offset of the record, the length is shrunk if the BMC
+// can not return the requested number of bytes.
+func (r *sdrReader) readChunk(recordID uint16, offset uint8, length uint8) (uint16, []byte, error) {
+	for {
+		nextRecordID, data, err := r.read(r.reservationID, recordID, offset, length)
+		if err == nil {
+			return nextRecordID, data, nil
+		}
+
+		resErr, ok := err.(*ResponseError)
+		if !ok || resErr.CompletionCode() != CompletionCodeCannotReturnRequestedDataBytes || length <= 1 {
+			return 0, nil, err
+		}
+
+		r.client.Debugf("cannot return %d bytes of SDR record (%#04x), shrink read length to %d\n", length, recordID, length/2)
+		length = length / 2
+		if length < r.maxReadBytes && length >= sdrRecordHeaderSize {
+			r.maxReadBytes = length
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func Test_sdrReader(t *testing.T) {
+	record := make([]byte, 5+48)
+	record[0] = 0x01 // record id
+	record[2] = 0x51 // sdr version
+	record[3] = 0x01 // full sensor
+	record[4] = 48   // record length
+	for i := 5; i < len(record); i++ {
+		record[i] = uint8(i)
+	}
+
+	tests := []struct {
+		name string
+		// max bytes the fake BMC can return in one read
+		maxRead int
+		// cancel the reservation at the Nth read
+		cancelAt int
+	}{
+		{"whole record", 0xff, 0},
+		{"small buffer", 16, 0},
+		{"tiny buffer", 6, 0},
+		{"reservation canceled", 16, 3},
+	}
+
+	for _, test := range tests {
+		reads := 0
+		reservations := 0
+
+		r := &sdrReader{
+			client: &Client{},
+			reserve: func() (uint16, error) {
+				reservations++
+				return uint16(reservations), nil
+			},
+			read: func(reservationID uint16, recordID uint16, offset uint8, length uint8) (uint16, []byte, error) {
+				reads++
+				if test.cancelAt > 0 && reads == test.cancelAt {
+					return 0, nil, &ResponseError{completionCode: CompletionCodeReservationCanceled}
+				}
+				if int(length) > test.maxRead {
+					return 0, nil, &ResponseError{completionCode: CompletionCodeCannotReturnRequestedDataBytes}
+				}
+				end := int(offset) + int(length)
+				if end > len(record) {
+					end = len(record)
+				}
+				return 0xffff, record[offset:end], nil
+			},
+			maxReadBytes: 0xff,
+		}
+
+		data, nextRecordID, err := r.ReadRecord(0)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+		if nextRecordID != 0xffff {
+			t.Errorf("test %s next record id not matched, got: %#04x", test.name, nextRecordID)
+		}
+		if !isByteSliceEqual(data, record) {
+			t.Errorf("test %s record not matched, got: %v, expected: %v", test.name, data, record)
+		}
+		if test.cancelAt > 0 && reservations != 2 {
+			t.Errorf("test %s expected to reserve again, got %d reservations", test.name, reservations)
+		}
+	}
+}
+
+func Test_sdrReader_MaxOffset(t *testing.T) {
+	// the record is longer than the max offset of Get SDR command
+	record := make([]byte, 5+0xff)
+	record[4] = 0xff
+
+	r := &sdrReader{
+		client: &Client{},
+		reserve: func() (uint16, error) {
+			return 1, nil
+		},
+		read: func(reservationID uint16, recordID uint16, offset uint8, length uint8) (uint16, []byte, error) {
+			if length > 8 {
+				return 0, nil, &ResponseError{completionCode: CompletionCodeCannotReturnRequestedDataBytes}
+			}
+			end := int(offset) + int(length)
+			if end > len(record) {
+				end = len(record)
+			}
+			return 0xffff, record[offset:end], nil
+		},
+		maxReadBytes: 0xff,
+	}
+
+	if _, _, err := r.ReadRecord(0); err == nil {
+		t.Errorf("test read record over the max offset expected error")
+	}
+}
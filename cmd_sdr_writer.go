@@ -0,0 +1,338 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// default max number of record bytes to write in one Partial Add SDR request,
+	// it keeps the request within the 32 bytes IPMB message limit.
+	sdrWriteDefaultBytes uint8 = 16
+
+	// max times to restart adding a record when the reservation is canceled.
+	sdrWriteMaxRestarts int = 8
+
+	// max times to shrink the write length when the Partial Add SDR request is rejected as too long.
+	sdrWriteMaxShrinks int = 8
+)
+
+// sdrRepoUpdater is the commands used by SDRRepoWriter, it is implemented by Client.
+type sdrRepoUpdater interface {
+	ReserveSDRRepo() (*ReserveSDRRepoResponse, error)
+	ClearSDRRepo(reservationID uint16) (*ClearSDRRepoResponse, error)
+	GetSDRRepoErasureStatus(reservationID uint16) (*ClearSDRRepoResponse, error)
+	DeleteSDR(reservationID uint16, recordID uint16) (*DeleteSDRResponse, error)
+	AddSDR(recordData []byte) (*AddSDRResponse, error)
+	PartialAddSDR(request *PartialAddSDRRequest) (*PartialAddSDRResponse, error)
+	EnterSDRRepoUpdateMode() (*EnterSDRRepoUpdateModeResponse, error)
+	ExitSDRRepoUpdateMode() (*ExitSDRRepoUpdateModeResponse, error)
+	Debugf(format string, object ...interface{})
+}
+
+// SDRRepoWriter writes SDR records into the SDR Repository.
+//
+// The records are added by Partial Add SDR commands with reservation (falls back to Add SDR if
+// Partial Add SDR is not supported). If the reservation is canceled (0xC5), a new reservation is
+// obtained and the record is added again from the beginning.
+//
+// If the SDR Repository only supports modal update, the writer must be opened by Begin before
+// writing, and closed by End after writing.
+type SDRRepoWriter struct {
+	client sdrRepoUpdater
+
+	repoInfo *GetSDRRepoInfoResponse
+
+	reserved      bool
+	reservationID uint16
+
+	inUpdateMode bool
+
+	// max number of record bytes to write in one Partial Add SDR request
+	maxWriteBytes uint8
+}
+
+func (c *Client) NewSDRRepoWriter() (*SDRRepoWriter, error) {
+	repoInfo, err := c.GetSDRRepoInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRRepoInfo failed, err: %s", err)
+	}
+
+	return newSDRRepoWriter(c, repoInfo), nil
+}
+
+func newSDRRepoWriter(updater sdrRepoUpdater, repoInfo *GetSDRRepoInfoResponse) *SDRRepoWriter {
+	return &SDRRepoWriter{
+		client:        updater,
+		repoInfo:      repoInfo,
+		maxWriteBytes: sdrWriteDefaultBytes,
+	}
+}
+
+// Begin enters the SDR Repository update mode if the SDR Repository supports modal update.
+func (w *SDRRepoWriter) Begin() error {
+	if !w.repoInfo.SDROperationSupport.SupportModalSDRRepoUpdate {
+		return nil
+	}
+
+	if _, err := w.client.EnterSDRRepoUpdateMode(); err != nil {
+		return fmt.Errorf("EnterSDRRepoUpdateMode failed, err: %s", err)
+	}
+	w.inUpdateMode = true
+	return nil
+}
+
+// End exits the SDR Repository update mode if it was entered by Begin.
+func (w *SDRRepoWriter) End() error {
+	if !w.inUpdateMode {
+		return nil
+	}
+
+	if _, err := w.client.ExitSDRRepoUpdateMode(); err != nil {
+		return fmt.Errorf("ExitSDRRepoUpdateMode failed, err: %s", err)
+	}
+	w.inUpdateMode = false
+	return nil
+}
+
+func (w *SDRRepoWriter) reserve() error {
+	res, err := w.client.ReserveSDRRepo()
+	if err != nil {
+		if !w.repoInfo.SDROperationSupport.SupportReserveSDRRepo {
+			w.reservationID = 0
+			w.reserved = true
+			return nil
+		}
+		return fmt.Errorf("ReserveSDRRepo failed, err: %s", err)
+	}
+	w.reservationID = res.ReservationID
+	w.reserved = true
+	return nil
+}
+
+// retryOnReservationCanceled runs f, and runs it again with a new reservation if the reservation is canceled.
+func (w *SDRRepoWriter) retryOnReservationCanceled(f func() error) error {
+	if !w.reserved {
+		if err := w.reserve(); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i <= sdrWriteMaxRestarts; i++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		if resErr, ok := err.(*ResponseError); ok && resErr.CompletionCode() == CompletionCodeReservationCanceled {
+			w.client.Debugf("reservation canceled, reserve again and restart\n")
+			if err := w.reserve(); err != nil {
+				return err
+			}
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("reservation canceled %d times", sdrWriteMaxRestarts+1)
+}
+
+// Clear erases all the records of the SDR Repository, and waits for the erasure to complete.
+func (w *SDRRepoWriter) Clear() error {
+	err := w.retryOnReservationCanceled(func() error {
+		res, err := w.client.ClearSDRRepo(w.reservationID)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; !res.Completed(); i++ {
+			if i > 60 {
+				return fmt.Errorf("wait for SDR Repository erasure to complete timeout")
+			}
+			time.Sleep(500 * time.Millisecond)
+
+			res, err = w.client.GetSDRRepoErasureStatus(w.reservationID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ClearSDRRepo failed, err: %s", err)
+	}
+	return nil
+}
+
+// Delete deletes the record specified by recordID.
+func (w *SDRRepoWriter) Delete(recordID uint16) error {
+	err := w.retryOnReservationCanceled(func() error {
+		_, err := w.client.DeleteSDR(w.reservationID, recordID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteSDR for recordID (%#04x) failed, err: %s", recordID, err)
+	}
+	return nil
+}
+
+// Add adds the raw record (including the record header) to the SDR Repository,
+// and returns the Record ID assigned by the BMC.
+func (w *SDRRepoWriter) Add(data []byte) (uint16, error) {
+	if len(data) < int(sdrRecordHeaderSize) {
+		return 0, fmt.Errorf("sdr record data must be longer than %d", sdrRecordHeaderSize)
+	}
+
+	if !w.repoInfo.SDROperationSupport.SupportParitialAddSDR {
+		res, err := w.client.AddSDR(data)
+		if err != nil {
+			return 0, fmt.Errorf("AddSDR failed, err: %s", err)
+		}
+		return res.RecordID, nil
+	}
+
+	var recordID uint16
+	err := w.retryOnReservationCanceled(func() error {
+		var err error
+		recordID, err = w.partialAdd(data)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("PartialAddSDR failed, err: %s", err)
+	}
+	return recordID, nil
+}
+
+// partialAdd adds the record by Partial Add SDR commands. If the request is rejected as too long,
+// the write length is halved and the request is retried, but the first request must contain
+// the whole record header, so the write length of the first request can not be less than the header size.
+func (w *SDRRepoWriter) partialAdd(data []byte) (uint16, error) {
+	var recordID uint16 = 0x0000
+	offset := 0
+	shrinks := 0
+
+	for offset < len(data) {
+		length := int(w.maxWriteBytes)
+		// the first partial add must contain the whole record header
+		if offset == 0 && length < int(sdrRecordHeaderSize) {
+			length = int(sdrRecordHeaderSize)
+		}
+		if offset+length > len(data) {
+			length = len(data) - offset
+		}
+
+		request := &PartialAddSDRRequest{
+			ReservationID: w.reservationID,
+			RecordID:      recordID,
+			Offset:        uint8(offset),
+			LastData:      offset+length == len(data),
+			RecordData:    data[offset : offset+length],
+		}
+		res, err := w.client.PartialAddSDR(request)
+		if err != nil {
+			if isRequestDataTooLong(err) && w.canShrink(offset, length) && shrinks < sdrWriteMaxShrinks {
+				shrinks++
+				w.maxWriteBytes = uint8(length / 2)
+				w.client.Debugf("partial add %d bytes rejected, shrink write length to %d\n", length, w.maxWriteBytes)
+				continue
+			}
+			return 0, err
+		}
+
+		if offset == 0 {
+			recordID = res.RecordID
+		}
+		offset += length
+	}
+
+	return recordID, nil
+}
+
+// canShrink reports whether the write length of the request at offset can be less than length.
+func (w *SDRRepoWriter) canShrink(offset int, length int) bool {
+	if offset == 0 {
+		return length > int(sdrRecordHeaderSize)
+	}
+	return length > 1
+}
+
+// isRequestDataTooLong reports whether the error is the completion code of request data length invalid or limit exceeded.
+func isRequestDataTooLong(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	if !ok {
+		return false
+	}
+	cc := resErr.CompletionCode()
+	return cc == CompletionCodeRequestDataLengthInvalid || cc == CompletionCodeRequestDataLengthLimitExceeded
+}
+
+// FillSDRRepo replaces all the records of the SDR Repository with records (raw data including the record header),
+// like "ipmitool sdr fill file".
+func (c *Client) FillSDRRepo(records [][]byte) error {
+	w, err := c.NewSDRRepoWriter()
+	if err != nil {
+		return err
+	}
+
+	if err := w.Begin(); err != nil {
+		return err
+	}
+
+	if err := w.Clear(); err != nil {
+		w.End()
+		return err
+	}
+
+	for i, record := range records {
+		if _, err := w.Add(record); err != nil {
+			w.End()
+			return fmt.Errorf("add record #%d failed, err: %s", i, err)
+		}
+	}
+
+	return w.End()
+}
+
+// ReplaceSDRs writes records (raw data including the record header) into the SDR Repository,
+// the existing record with the same Record ID is deleted before the record is added.
+// Note, the BMC assigns new Record IDs to the added records.
+func (c *Client) ReplaceSDRs(records [][]byte) error {
+	existing := make(map[uint16]bool)
+	sdrs, err := c.GetSDRs()
+	if err != nil {
+		return fmt.Errorf("GetSDRs failed, err: %s", err)
+	}
+	for _, sdr := range sdrs {
+		existing[sdr.RecordHeader.RecordID] = true
+	}
+
+	w, err := c.NewSDRRepoWriter()
+	if err != nil {
+		return err
+	}
+
+	if err := w.Begin(); err != nil {
+		return err
+	}
+
+	for i, record := range records {
+		if len(record) < int(sdrRecordHeaderSize) {
+			w.End()
+			return fmt.Errorf("record #%d too short", i)
+		}
+
+		recordID, _, _ := unpackUint16L(record, 0)
+		if existing[recordID] {
+			if err := w.Delete(recordID); err != nil {
+				w.End()
+				return err
+			}
+		}
+
+		if _, err := w.Add(record); err != nil {
+			w.End()
+			return fmt.Errorf("add record #%d failed, err: %s", i, err)
+		}
+	}
+
+	return w.End()
+}
@@ -0,0 +1,137 @@
+package ipmi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// fakeSDRRepo is a SDR Repository implementing sdrRepoUpdater,
+// it rejects the Partial Add SDR requests of more than maxWriteBytes record bytes.
+type fakeSDRRepo struct {
+	maxWriteBytes int
+	// cancel the reservation before the nth partial add request, 0 for never
+	cancelAt int
+
+	reservationID uint16
+	partialAdds   int
+	pending       []byte
+	records       map[uint16][]byte
+	nextRecordID  uint16
+}
+
+func newFakeSDRRepo(maxWriteBytes int) *fakeSDRRepo {
+	return &fakeSDRRepo{maxWriteBytes: maxWriteBytes, records: make(map[uint16][]byte), nextRecordID: 1}
+}
+
+func (r *fakeSDRRepo) ReserveSDRRepo() (*ReserveSDRRepoResponse, error) {
+	r.reservationID++
+	return &ReserveSDRRepoResponse{ReservationID: r.reservationID}, nil
+}
+
+func (r *fakeSDRRepo) ClearSDRRepo(reservationID uint16) (*ClearSDRRepoResponse, error) {
+	r.records = make(map[uint16][]byte)
+	return &ClearSDRRepoResponse{ErasureProgressStatus: 0x01}, nil
+}
+
+func (r *fakeSDRRepo) GetSDRRepoErasureStatus(reservationID uint16) (*ClearSDRRepoResponse, error) {
+	return &ClearSDRRepoResponse{ErasureProgressStatus: 0x01}, nil
+}
+
+func (r *fakeSDRRepo) DeleteSDR(reservationID uint16, recordID uint16) (*DeleteSDRResponse, error) {
+	delete(r.records, recordID)
+	return &DeleteSDRResponse{RecordID: recordID}, nil
+}
+
+func (r *fakeSDRRepo) AddSDR(recordData []byte) (*AddSDRResponse, error) {
+	recordID := r.nextRecordID
+	r.nextRecordID++
+	r.records[recordID] = recordData
+	return &AddSDRResponse{RecordID: recordID}, nil
+}
+
+func (r *fakeSDRRepo) PartialAddSDR(request *PartialAddSDRRequest) (*PartialAddSDRResponse, error) {
+	r.partialAdds++
+	if r.cancelAt > 0 && r.partialAdds == r.cancelAt {
+		r.reservationID++
+	}
+	if request.ReservationID != r.reservationID {
+		return nil, &ResponseError{completionCode: CompletionCodeReservationCanceled}
+	}
+	if len(request.RecordData) > r.maxWriteBytes {
+		return nil, &ResponseError{completionCode: CompletionCodeRequestDataLengthInvalid}
+	}
+
+	if request.Offset == 0 {
+		r.pending = nil
+	}
+	if int(request.Offset) != len(r.pending) {
+		return nil, &ResponseError{completionCode: CompletionCodeParameterOutOfRange}
+	}
+	r.pending = append(r.pending, request.RecordData...)
+
+	if !request.LastData {
+		return &PartialAddSDRResponse{RecordID: r.nextRecordID}, nil
+	}
+	recordID := r.nextRecordID
+	r.nextRecordID++
+	r.records[recordID] = r.pending
+	r.pending = nil
+	return &PartialAddSDRResponse{RecordID: recordID}, nil
+}
+
+func (r *fakeSDRRepo) EnterSDRRepoUpdateMode() (*EnterSDRRepoUpdateModeResponse, error) {
+	return &EnterSDRRepoUpdateModeResponse{}, nil
+}
+
+func (r *fakeSDRRepo) ExitSDRRepoUpdateMode() (*ExitSDRRepoUpdateModeResponse, error) {
+	return &ExitSDRRepoUpdateModeResponse{}, nil
+}
+
+func (r *fakeSDRRepo) Debugf(format string, object ...interface{}) {
+}
+
+func Test_SDRRepoWriter_Add(t *testing.T) {
+	record, _ := hex.DecodeString(sdrTestRecords[0].raw)
+
+	partialAddSupported := &GetSDRRepoInfoResponse{SDROperationSupport: SDROperationSupport{SupportParitialAddSDR: true, SupportReserveSDRRepo: true}}
+
+	tests := []struct {
+		name          string
+		repoInfo      *GetSDRRepoInfoResponse
+		maxWriteBytes int
+		cancelAt      int
+		fails         bool
+	}{
+		{"partial add", partialAddSupported, 16, 0, false},
+		{"shrink write length", partialAddSupported, 6, 0, false},
+		{"shrink to record header size", partialAddSupported, 5, 0, false},
+		{"reservation canceled", partialAddSupported, 16, 2, false},
+		{"record header rejected", partialAddSupported, 4, 0, true},
+		{"add without partial add", &GetSDRRepoInfoResponse{}, 0, 0, false},
+	}
+
+	for _, test := range tests {
+		repo := newFakeSDRRepo(test.maxWriteBytes)
+		repo.cancelAt = test.cancelAt
+		w := newSDRRepoWriter(repo, test.repoInfo)
+
+		recordID, err := w.Add(record)
+		if test.fails {
+			if err == nil {
+				t.Errorf("test %s expected error", test.name)
+			}
+			if repo.partialAdds > 10 {
+				t.Errorf("test %s too many partial adds, got: %d", test.name, repo.partialAdds)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+		if !bytes.Equal(repo.records[recordID], record) {
+			t.Errorf("test %s record not matched, got: %x, expected: %x", test.name, repo.records[recordID], record)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// 31.15 Set Auxiliary Log Status Command
+type SetAuxLogStatusRequest struct {
+	LogType AuxLogType
+
+	// Timestamp when the log was last updated, zero for unspecified.
+	// The log status of all log types starts with the timestamp, same as GetAuxLogStatusResponse.
+	Timestamp time.Time
+	// Number of entries in the MCA log, only for AuxLogTypeMCA.
+	MCAEntries uint32
+
+	// OEM-defined log status, only for OEM log types.
+	OEMData []byte
+}
+
+type SetAuxLogStatusResponse struct {
+}
+
+func (req *SetAuxLogStatusRequest) Pack() []byte {
+	var ts uint32 = timestampUnspecified
+	if !req.Timestamp.IsZero() {
+		ts = uint32(req.Timestamp.Unix())
+	}
+
+	if req.LogType != AuxLogTypeMCA {
+		out := make([]byte, 5+len(req.OEMData))
+		out[0] = uint8(req.LogType) & 0x0f
+		packUint32L(ts, out, 1)
+		packBytes(req.OEMData, out, 5)
+		return out
+	}
+
+	out := make([]byte, 9)
+	out[0] = uint8(req.LogType) & 0x0f
+	packUint32L(ts, out, 1)
+	packUint32L(req.MCAEntries, out, 5)
+	return out
+}
+
+func (req *SetAuxLogStatusRequest) Command() Command {
+	return CommandSetAuxLogStatus
+}
+
+func (res *SetAuxLogStatusResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *SetAuxLogStatusResponse) CompletionCodes() map[uint8]string {
+	// no command-specific cc
+	return map[uint8]string{}
+}
+
+func (res *SetAuxLogStatusResponse) Format() string {
+	return fmt.Sprintf("%v", res)
+}
+
+// SetAuxLogStatus sets the status of the auxiliary log, it is normally used by the
+// system software which maintains the log.
+func (c *Client) SetAuxLogStatus(request *SetAuxLogStatusRequest) (response *SetAuxLogStatusResponse, err error) {
+	response = &SetAuxLogStatusResponse{}
+	err = c.Exchange(request, response)
+	return
+}
@@ -0,0 +1,44 @@
+package ipmi
+
+import (
+	"time"
+)
+
+// 33.18 Set SDR Repository Time Command
+type SetSDRRepoTimeRequest struct {
+	Time time.Time
+}
+
+type SetSDRRepoTimeResponse struct {
+}
+
+func (req *SetSDRRepoTimeRequest) Command() Command {
+	return CommandSetSDRRepoTime
+}
+
+func (req *SetSDRRepoTimeRequest) Pack() []byte {
+	var out = make([]byte, 4)
+	packUint32L(uint32(req.Time.Unix()), out, 0)
+	return out
+}
+
+func (res *SetSDRRepoTimeResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *SetSDRRepoTimeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *SetSDRRepoTimeResponse) Format() string {
+	return ""
+}
+
+func (c *Client) SetSDRRepoTime(t time.Time) (response *SetSDRRepoTimeResponse, err error) {
+	request := &SetSDRRepoTimeRequest{
+		Time: t,
+	}
+	response = &SetSDRRepoTimeResponse{}
+	err = c.Exchange(request, response)
+	return
+}
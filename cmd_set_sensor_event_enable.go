@@ -0,0 +1,172 @@
+package ipmi
+
+import "fmt"
+
+// 35.10 Set Sensor Event Enable Command
+type SetSensorEventEnableRequest struct {
+	SensorNumber uint8
+
+	EventMessagesDisabled  bool
+	SensorScanningDisabled bool
+
+	EventEnableAction SensorEventEnableAction
+
+	// The events selected to be enabled or disabled by EventEnableAction.
+	SensorEventFlag
+}
+
+type SetSensorEventEnableResponse struct {
+	// empty
+}
+
+// SensorEventEnableAction indicates how the selected events in Set Sensor Event Enable command are handled.
+type SensorEventEnableAction uint8
+
+const (
+	// do not change individual enables
+	SensorEventEnableActionNoChange SensorEventEnableAction = 0x00
+	// enable selected event messages
+	SensorEventEnableActionEnable SensorEventEnableAction = 0x01
+	// disable selected event messages
+	SensorEventEnableActionDisable SensorEventEnableAction = 0x02
+)
+
+func (req *SetSensorEventEnableRequest) Command() Command {
+	return CommandSetSensorEventEnable
+}
+
+func (req *SetSensorEventEnableRequest) Pack() []byte {
+	out := make([]byte, 2)
+	packUint8(req.SensorNumber, out, 0)
+
+	var b uint8
+	if !req.EventMessagesDisabled {
+		b = setBit7(b)
+	}
+	if !req.SensorScanningDisabled {
+		b = setBit6(b)
+	}
+	b |= (uint8(req.EventEnableAction) & 0x03) << 4
+	packUint8(b, out, 1)
+
+	if req.EventEnableAction == SensorEventEnableActionNoChange {
+		return out
+	}
+	return append(out, req.SensorEventFlag.pack()...)
+}
+
+func (res *SetSensorEventEnableResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (r *SetSensorEventEnableResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *SetSensorEventEnableResponse) Format() string {
+	return ""
+}
+
+// SetSensorEventEnable is used to enable or disable event message generation and scanning of the sensor,
+// and to enable or disable the individual events of the sensor.
+func (c *Client) SetSensorEventEnable(request *SetSensorEventEnableRequest) (response *SetSensorEventEnableResponse, err error) {
+	response = &SetSensorEventEnableResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// EnableSensorEvents enables the generation of the specified events of the sensor.
+// The event messages and scanning states of the sensor are kept unchanged.
+func (c *Client) EnableSensorEvents(sensorNumber uint8, events ...SensorEvent) error {
+	return c.setSensorEvents(sensorNumber, SensorEventEnableActionEnable, events...)
+}
+
+// DisableSensorEvents disables the generation of the specified events of the sensor.
+// The event messages and scanning states of the sensor are kept unchanged.
+func (c *Client) DisableSensorEvents(sensorNumber uint8, events ...SensorEvent) error {
+	return c.setSensorEvents(sensorNumber, SensorEventEnableActionDisable, events...)
+}
+
+func (c *Client) setSensorEvents(sensorNumber uint8, action SensorEventEnableAction, events ...SensorEvent) error {
+	enableRes, err := c.GetSensorEventEnable(sensorNumber)
+	if err != nil {
+		return fmt.Errorf("GetSensorEventEnable for sensor %#02x failed, err: %s", sensorNumber, err)
+	}
+
+	request := &SetSensorEventEnableRequest{
+		SensorNumber:           sensorNumber,
+		EventMessagesDisabled:  enableRes.EventMessagesDisabled,
+		SensorScanningDisabled: enableRes.SensorScanningDisabled,
+		EventEnableAction:      action,
+		SensorEventFlag:        NewSensorEventFlag(events...),
+	}
+	if _, err := c.SetSensorEventEnable(request); err != nil {
+		return fmt.Errorf("SetSensorEventEnable for sensor %#02x failed, err: %s", sensorNumber, err)
+	}
+	return nil
+}
+
+// SetSensorEventMessages enables or disables all event messages from the sensor,
+// the individual event enables and scanning state of the sensor are kept unchanged.
+// It is used to silence a noisy sensor and re-enable it later.
+func (c *Client) SetSensorEventMessages(sensor *Sensor, enabled bool) error {
+	return c.withSensorOwner(sensor.GeneratorID, func() error {
+		return c.setSensorEventMessages(sensor.Number, enabled)
+	})
+}
+
+func (c *Client) setSensorEventMessages(sensorNumber uint8, enabled bool) error {
+	enableRes, err := c.GetSensorEventEnable(sensorNumber)
+	if err != nil {
+		return fmt.Errorf("GetSensorEventEnable for sensor %#02x failed, err: %s", sensorNumber, err)
+	}
+
+	request := &SetSensorEventEnableRequest{
+		SensorNumber:           sensorNumber,
+		EventMessagesDisabled:  !enabled,
+		SensorScanningDisabled: enableRes.SensorScanningDisabled,
+		EventEnableAction:      SensorEventEnableActionNoChange,
+	}
+	if _, err := c.SetSensorEventEnable(request); err != nil {
+		return fmt.Errorf("SetSensorEventEnable for sensor %#02x failed, err: %s", sensorNumber, err)
+	}
+	return nil
+}
+
+// EnableSensorEventsByName enables both the assertion and deassertion events of the sensor specified by names.
+// See ParseSensorEvents for the event names.
+func (c *Client) EnableSensorEventsByName(sensor *Sensor, names ...string) error {
+	events, err := ParseSensorEvents(sensor, names...)
+	if err != nil {
+		return err
+	}
+	return c.withSensorOwner(sensor.GeneratorID, func() error {
+		return c.EnableSensorEvents(sensor.Number, events...)
+	})
+}
+
+// DisableSensorEventsByName disables both the assertion and deassertion events of the sensor specified by names.
+// See ParseSensorEvents for the event names.
+func (c *Client) DisableSensorEventsByName(sensor *Sensor, names ...string) error {
+	events, err := ParseSensorEvents(sensor, names...)
+	if err != nil {
+		return err
+	}
+	return c.withSensorOwner(sensor.GeneratorID, func() error {
+		return c.DisableSensorEvents(sensor.Number, events...)
+	})
+}
+
+// ParseSensorEvents parses the event names of the sensor by ParseSensorEvent,
+// and returns both the assertion and deassertion events.
+func ParseSensorEvents(sensor *Sensor, names ...string) ([]SensorEvent, error) {
+	events := make([]SensorEvent, 0)
+	for _, name := range names {
+		event, err := ParseSensorEvent(name, sensor.SensorType, sensor.EventReadingType)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event, event.Deassertion())
+	}
+	return events, nil
+}
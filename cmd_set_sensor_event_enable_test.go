@@ -0,0 +1,83 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_SetSensorEventEnableRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *SetSensorEventEnableRequest
+		expected []byte
+	}{
+		{
+			name: "disable event messages",
+			request: &SetSensorEventEnableRequest{
+				SensorNumber:          0x30,
+				EventMessagesDisabled: true,
+			},
+			expected: []byte{0x30, 0x40},
+		},
+		{
+			name: "enable threshold events",
+			request: &SetSensorEventEnableRequest{
+				SensorNumber:      0x30,
+				EventEnableAction: SensorEventEnableActionEnable,
+				SensorEventFlag:   NewSensorEventFlag(SensorEvent_UCR_High_Assert, SensorEvent_LNC_Low_Assert, SensorEvent_UCR_High_Deassert),
+			},
+			expected: []byte{0x30, 0xd0, 0x01, 0x02, 0x00, 0x02},
+		},
+		{
+			name: "disable discrete events",
+			request: &SetSensorEventEnableRequest{
+				SensorNumber:      0x50,
+				EventEnableAction: SensorEventEnableActionDisable,
+				SensorEventFlag:   NewSensorEventFlag(SensorEvent_State_1_Assert, SensorEvent_State_14_Deassert),
+			},
+			expected: []byte{0x50, 0xe0, 0x02, 0x00, 0x00, 0x40},
+		},
+	}
+
+	for _, test := range tests {
+		got := test.request.Pack()
+		if !bytes.Equal(got, test.expected) {
+			t.Errorf("test %s not matched, got: %x, expected: %x", test.name, got, test.expected)
+		}
+	}
+
+	rearm := &RearmSensorEventsRequest{SensorNumber: 0x30, SensorEventFlag: NewSensorEventFlag(SensorEvent_UNR_High_Assert)}
+	if got, expected := rearm.Pack(), []byte{0x30, 0x80, 0x00, 0x08, 0x00, 0x00}; !bytes.Equal(got, expected) {
+		t.Errorf("test rearm not matched, got: %x, expected: %x", got, expected)
+	}
+}
+
+func Test_ParseSensorEvent(t *testing.T) {
+	tests := []struct {
+		name             string
+		sensorType       SensorType
+		eventReadingType EventReadingType
+		expected         SensorEvent
+	}{
+		{"ucr+", SensorTypeTemperature, EventReadingTypeThreshold, SensorEvent_UCR_High_Assert},
+		{"LNC", SensorTypeTemperature, EventReadingTypeThreshold, SensorEvent_LNC_Low_Assert},
+		{"unc-", SensorTypeTemperature, EventReadingTypeThreshold, SensorEvent_UNC_Low_Assert},
+		{"state3", SensorTypePowserSupply, EventReadingTypeSensorSpecific, SensorEvent_State_3_Assert},
+		{"presence detected", SensorTypePowserSupply, EventReadingTypeSensorSpecific, SensorEvent_State_0_Assert},
+	}
+
+	for _, test := range tests {
+		got, err := ParseSensorEvent(test.name, test.sensorType, test.eventReadingType)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+	}
+
+	if _, err := ParseSensorEvent("ucr", SensorTypePowserSupply, EventReadingTypeSensorSpecific); err == nil {
+		t.Errorf("test threshold event name for discrete sensor expected error")
+	}
+}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "fmt"
+
 // 35.6 Set Sensor Hysteresis Command
 type SetSensorHysteresisRequest struct {
 	SensorNumber       uint8
@@ -48,3 +50,37 @@ func (c *Client) SetSensorHysteresis(sensorNumber uint8, positiveHysteresis uint
 	err = c.Exchange(request, response)
 	return
 }
+
+// SetSensorHysteresisValues sets the positive-going and negative-going hysteresis of the threshold sensor
+// by the values in the desired units for the sensor.
+//
+// The hysteresis of the sensor must be settable, see SensorCapabilitites.HysteresisAccess.
+func (c *Client) SetSensorHysteresisValues(sensor *Sensor, positiveHysteresis float64, negativeHysteresis float64) (response *SetSensorHysteresisResponse, err error) {
+	err = c.withSensorOwner(sensor.GeneratorID, func() error {
+		response, err = c.setSensorHysteresisValues(sensor, positiveHysteresis, negativeHysteresis)
+		return err
+	})
+	return
+}
+
+func (c *Client) setSensorHysteresisValues(sensor *Sensor, positiveHysteresis float64, negativeHysteresis float64) (*SetSensorHysteresisResponse, error) {
+	if !sensor.IsThreshold() {
+		return nil, fmt.Errorf("sensor %#02x is not threshold based", sensor.Number)
+	}
+
+	if sensor.SensorCapabilitites.HysteresisAccess != SensorHysteresisAccess_ReadableSettable {
+		return nil, fmt.Errorf("hysteresis of sensor %#02x is not settable, hysteresis access: %s", sensor.Number, sensor.SensorCapabilitites.HysteresisAccess)
+	}
+
+	positiveRaw, err := ConvertSensorHysteresisToRaw(positiveHysteresis, sensor.Threshold.ReadingFactors)
+	if err != nil {
+		return nil, fmt.Errorf("convert positive hysteresis failed, err: %s", err)
+	}
+
+	negativeRaw, err := ConvertSensorHysteresisToRaw(negativeHysteresis, sensor.Threshold.ReadingFactors)
+	if err != nil {
+		return nil, fmt.Errorf("convert negative hysteresis failed, err: %s", err)
+	}
+
+	return c.SetSensorHysteresis(sensor.Number, positiveRaw, negativeRaw)
+}
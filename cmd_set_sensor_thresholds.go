@@ -1,5 +1,7 @@
 package ipmi
 
+import "fmt"
+
 // 35.8 Set Sensor Thresholds Command
 type SetSensorThresholdsRequest struct {
 	SensorNumber uint8
@@ -82,3 +84,102 @@ func (c *Client) SetSensorThresholds(request *SetSensorThresholdsRequest) (respo
 	err = c.Exchange(request, response)
 	return
 }
+
+// SetSensorThresholdValues sets the thresholds of the threshold sensor by the values in the desired units for the sensor.
+// The values are converted to raw values by the reading factors of the sensor, for non-linear sensors,
+// the reading factors are retrieved by Get Sensor Reading Factors command for the converted raw values.
+//
+// Only the thresholds in the settable threshold mask of the sensor's SDR can be set.
+func (c *Client) SetSensorThresholdValues(sensor *Sensor, values map[SensorThresholdType]float64) (response *SetSensorThresholdsResponse, err error) {
+	err = c.withSensorOwner(sensor.GeneratorID, func() error {
+		response, err = c.setSensorThresholdValues(sensor, values)
+		return err
+	})
+	return
+}
+
+func (c *Client) setSensorThresholdValues(sensor *Sensor, values map[SensorThresholdType]float64) (*SetSensorThresholdsResponse, error) {
+	if !sensor.IsThreshold() {
+		return nil, fmt.Errorf("sensor %#02x is not threshold based", sensor.Number)
+	}
+
+	settable := sensor.SettableThresholds()
+
+	request := &SetSensorThresholdsRequest{
+		SensorNumber: sensor.Number,
+	}
+
+	for thresholdType, value := range values {
+		if !settable.Contains(thresholdType) {
+			return nil, fmt.Errorf("threshold %s of sensor %#02x is not settable, settable thresholds: %v", thresholdType, sensor.Number, settable.Strings())
+		}
+
+		raw, err := c.convertReadingToRaw(sensor, value)
+		if err != nil {
+			return nil, fmt.Errorf("convert threshold %s value %v failed, err: %s", thresholdType, value, err)
+		}
+
+		switch thresholdType {
+		case SensorThresholdType_LNC:
+			request.SetLNC = true
+			request.LNC_Raw = raw
+		case SensorThresholdType_LCR:
+			request.SetLCR = true
+			request.LCR_Raw = raw
+		case SensorThresholdType_LNR:
+			request.SetLNR = true
+			request.LNR_Raw = raw
+		case SensorThresholdType_UNC:
+			request.SetUNC = true
+			request.UNC_Raw = raw
+		case SensorThresholdType_UCR:
+			request.SetUCR = true
+			request.UCR_Raw = raw
+		case SensorThresholdType_UNR:
+			request.SetUNR = true
+			request.UNR_Raw = raw
+		}
+	}
+
+	return c.SetSensorThresholds(request)
+}
+
+// sensorReadingFactorsGetter is the command used by convertReadingToRaw, it is implemented by Client.
+type sensorReadingFactorsGetter interface {
+	GetSensorReadingFactors(sensorNumber uint8, reading uint8) (*GetSensorReadingFactorsResponse, error)
+}
+
+// convertReadingToRawMaxIterations is the max times the conversion is repeated for non-linear sensors.
+const convertReadingToRawMaxIterations = 5
+
+func (c *Client) convertReadingToRaw(sensor *Sensor, value float64) (uint8, error) {
+	return convertReadingToRaw(c, sensor, value)
+}
+
+// convertReadingToRaw converts the value to raw value for the sensor.
+// For non-linear sensors, the reading factors depend on the raw value, so the conversion is repeated
+// by the reading factors of the last converted raw value, until the raw value is stable.
+// An error is returned if the raw value is not stable after convertReadingToRawMaxIterations conversions.
+func convertReadingToRaw(getter sensorReadingFactorsGetter, sensor *Sensor, value float64) (uint8, error) {
+	if !sensor.Threshold.LinearizationFunc.IsNonLinear() {
+		return sensor.ConvertReadingToRaw(value)
+	}
+
+	raw := sensor.Raw
+	for i := 0; i < convertReadingToRawMaxIterations; i++ {
+		factorsRes, err := getter.GetSensorReadingFactors(sensor.Number, raw)
+		if err != nil {
+			return 0, fmt.Errorf("GetSensorReadingFactors for sensor %#02x failed, err: %s", sensor.Number, err)
+		}
+
+		newRaw, err := ConvertReadingToRaw(value, sensor.SensorUnit.AnalogDataFormat, factorsRes.ReadingFactors, sensor.Threshold.LinearizationFunc)
+		if err != nil {
+			return 0, err
+		}
+		if newRaw == raw {
+			return raw, nil
+		}
+		raw = newRaw
+	}
+	return 0, fmt.Errorf("value %v of sensor %#02x does not converge to a raw value in %d conversions, last raw %d", value, sensor.Number, convertReadingToRawMaxIterations, raw)
+}
@@ -0,0 +1,77 @@
+package ipmi
+
+import "fmt"
+
+// 24.6 Set User Payload Access Command
+type SetUserPayloadAccessRequest struct {
+	ChannelNumber uint8
+
+	// [7:6] Operation
+	// 00b = Enable payloads listed in following fields.
+	// 01b = Disable payloads listed in following fields.
+	Disable bool
+
+	// [5:0] User ID
+	UserID uint8
+
+	// The payloads to be enabled or disabled, payloads not set are left unchanged.
+	Payloads PayloadAccess
+}
+
+type SetUserPayloadAccessResponse struct {
+}
+
+func (req *SetUserPayloadAccessRequest) Command() Command {
+	return CommandSetUserPayloadAccess
+}
+
+func (req *SetUserPayloadAccessRequest) Pack() []byte {
+	out := make([]byte, 6)
+	packUint8(req.ChannelNumber&0x0f, out, 0)
+
+	b := req.UserID & 0x3f
+	if req.Disable {
+		b |= 0x40
+	}
+	packUint8(b, out, 1)
+	packBytes(req.Payloads.Pack(), out, 2)
+	return out
+}
+
+func (res *SetUserPayloadAccessResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *SetUserPayloadAccessResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *SetUserPayloadAccessResponse) Format() string {
+	return ""
+}
+
+// SetUserPayloadAccess is used to enable or disable payloads for a given user ID.
+func (c *Client) SetUserPayloadAccess(request *SetUserPayloadAccessRequest) (response *SetUserPayloadAccessResponse, err error) {
+	response = &SetUserPayloadAccessResponse{}
+	err = c.Exchange(request, response)
+	return
+}
+
+// SetUserPayloadEnabled enables or disables the payload types for the user on the channel.
+func (c *Client) SetUserPayloadEnabled(channelNumber uint8, userID uint8, enabled bool, payloadTypes ...PayloadType) error {
+	request := &SetUserPayloadAccessRequest{
+		ChannelNumber: channelNumber,
+		Disable:       !enabled,
+		UserID:        userID,
+	}
+	for _, payloadType := range payloadTypes {
+		if err := request.Payloads.SetEnabled(payloadType, true); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.SetUserPayloadAccess(request); err != nil {
+		return fmt.Errorf("SetUserPayloadAccess failed, err: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package ipmi
+
+import "fmt"
+
+// SetSOLConfigParam sets a single SOL configuration parameter selected by paramSelector,
+// the parameter data is packed from the corresponding field of solConfigParam.
+func (c *Client) SetSOLConfigParam(channelNumber uint8, paramSelector SOLConfigParamSelector, solConfigParam *SOLConfigParam) error {
+	return setSOLConfigParam(c, channelNumber, paramSelector, solConfigParam)
+}
+
+// solConfigurer is the commands used by SetSOLConfig, it is implemented by Client.
+type solConfigurer interface {
+	GetSOLConfigParams(channelNumber uint8, paramSelector SOLConfigParamSelector) (*GetSOLConfigParamsResponse, error)
+	SetSOLConfigurationParameters(channelNumber uint8, paramSelector uint8, paramData []byte) (*SetSOLConfigurationParametersResponse, error)
+	Debugf(format string, object ...interface{})
+}
+
+// SOLConfigParamUnsupportedError is returned when the BMC does not support setting the SOL configuration parameter,
+// that is the parameter is not supported or read-only.
+type SOLConfigParamUnsupportedError struct {
+	ParamSelector SOLConfigParamSelector
+	err           error
+}
+
+func (e *SOLConfigParamUnsupportedError) Error() string {
+	return fmt.Sprintf("SOL configuration parameter %s is not supported, err: %s", e.ParamSelector, e.err)
+}
+
+// isSOLConfigParamUnsupported reports whether the error is the "parameter not supported" completion code.
+func isSOLConfigParamUnsupported(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	return ok && resErr.CompletionCode() == CompletionCode(0x80)
+}
+
+// isSOLConfigParamReadOnly reports whether the error is the "attempt to write read-only parameter" completion code.
+func isSOLConfigParamReadOnly(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	return ok && resErr.CompletionCode() == CompletionCode(0x82)
+}
+
+func setSOLConfigParam(configurer solConfigurer, channelNumber uint8, paramSelector SOLConfigParamSelector, solConfigParam *SOLConfigParam) error {
+	paramData, err := PackSOLParamData(paramSelector, solConfigParam)
+	if err != nil {
+		return fmt.Errorf("PackSOLParamData failed, err: %s", err)
+	}
+
+	if _, err := configurer.SetSOLConfigurationParameters(channelNumber, uint8(paramSelector), paramData); err != nil {
+		if isSOLConfigParamUnsupported(err) || isSOLConfigParamReadOnly(err) {
+			return &SOLConfigParamUnsupportedError{paramSelector, err}
+		}
+		return fmt.Errorf("SetSOLConfigurationParameters for %s failed, err: %s", paramSelector, err)
+	}
+	return nil
+}
+
+// setSOLSetInProgress returns the original error of SetSOLConfigurationParameters,
+// so the completion code can be checked by the caller.
+func setSOLSetInProgress(configurer solConfigurer, channelNumber uint8, v SOLConfigParam_SetInProgress) error {
+	_, err := configurer.SetSOLConfigurationParameters(channelNumber, uint8(SOLConfigParamSelector_SetInProgress), v.Pack())
+	return err
+}
+
+// SetSOLConfig sets all the non-nil parameters of solConfigParam (except SetInProgress).
+//
+// The changes are wrapped in the "set in progress" lock, "commit write" and "set complete" sequence.
+// If any parameter fails to be set, the parameters already set are restored to their original values.
+//
+// The optional parameters not supported (or read-only) by the BMC are skipped, and returned as unsupported.
+// If the BMC does not support the "set in progress" parameter, the parameters are set without lock.
+func (c *Client) SetSOLConfig(channelNumber uint8, solConfigParam *SOLConfigParam) (unsupported []SOLConfigParamSelector, err error) {
+	return setSOLConfig(c, channelNumber, solConfigParam)
+}
+
+func setSOLConfig(configurer solConfigurer, channelNumber uint8, solConfigParam *SOLConfigParam) ([]SOLConfigParamSelector, error) {
+	unsupported := make([]SOLConfigParamSelector, 0)
+
+	// save the original values for rollback, the parameters can not be read are not supported
+	selectors := make([]SOLConfigParamSelector, 0)
+	originals := make(map[SOLConfigParamSelector][]byte)
+	for _, paramSelector := range solConfigParam.Selectors() {
+		res, err := configurer.GetSOLConfigParams(channelNumber, paramSelector)
+		if err != nil {
+			if isSOLConfigParamUnsupported(err) {
+				unsupported = append(unsupported, paramSelector)
+				continue
+			}
+			return nil, fmt.Errorf("GetSOLConfigParams for %s failed, err: %s", paramSelector, err)
+		}
+		selectors = append(selectors, paramSelector)
+		originals[paramSelector] = res.ParameterData
+	}
+	if len(selectors) == 0 {
+		return unsupported, nil
+	}
+
+	locked := true
+	if err := setSOLSetInProgress(configurer, channelNumber, SOLSetInProgress); err != nil {
+		resErr, ok := err.(*ResponseError)
+		if !ok {
+			return nil, fmt.Errorf("set in progress failed, err: %s", err)
+		}
+		switch resErr.CompletionCode() {
+		case CompletionCode(0x80):
+			// "set in progress" parameter not supported
+			configurer.Debugf("set in progress not supported, continue without lock\n")
+			locked = false
+		case CompletionCode(0x81):
+			return nil, fmt.Errorf("set in progress failed, another party is setting the SOL configuration parameters, err: %s", err)
+		default:
+			return nil, fmt.Errorf("set in progress failed, err: %s", err)
+		}
+	}
+
+	release := func() {
+		if !locked {
+			return
+		}
+		if err := setSOLSetInProgress(configurer, channelNumber, SOLSetComplete); err != nil {
+			configurer.Debugf("set complete failed, err: %s\n", err)
+		}
+	}
+
+	rollback := func(applied []SOLConfigParamSelector) {
+		for i := len(applied) - 1; i >= 0; i-- {
+			paramSelector := applied[i]
+			if _, err := configurer.SetSOLConfigurationParameters(channelNumber, uint8(paramSelector), originals[paramSelector]); err != nil {
+				configurer.Debugf("rollback %s failed, err: %s\n", paramSelector, err)
+			}
+		}
+	}
+
+	applied := make([]SOLConfigParamSelector, 0)
+	for _, paramSelector := range selectors {
+		if err := setSOLConfigParam(configurer, channelNumber, paramSelector, solConfigParam); err != nil {
+			if _, ok := err.(*SOLConfigParamUnsupportedError); ok {
+				// readable but not writable on this BMC
+				unsupported = append(unsupported, paramSelector)
+				continue
+			}
+			rollback(applied)
+			release()
+			return nil, err
+		}
+		applied = append(applied, paramSelector)
+	}
+
+	if locked && len(applied) > 0 {
+		if err := setSOLSetInProgress(configurer, channelNumber, SOLCommitWrite); err != nil {
+			// commit write is optional, the BMC may reject it as invalid data
+			if resErr, ok := err.(*ResponseError); ok && resErr.CompletionCode() == CompletionCodeRequestDataFieldInvalid {
+				configurer.Debugf("commit write not supported, err: %s\n", err)
+			} else {
+				rollback(applied)
+				release()
+				return nil, fmt.Errorf("commit write failed, err: %s", err)
+			}
+		}
+	}
+
+	release()
+	return unsupported, nil
+}
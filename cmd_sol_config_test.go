@@ -0,0 +1,209 @@
+package ipmi
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_PackSOLParamData(t *testing.T) {
+	enable := SOLConfigParam_SOLEnable(true)
+	nonVolatileBitRate := SOLConfigParam_NonVolatileBitRate(0x0a)
+	volatileBitRate := SOLConfigParam_VolatileBitRate(0x06)
+	payloadChannel := SOLConfigParam_PayloadChannel(0x01)
+	payloadPort := SOLConfigParam_PayloadPort(623)
+	commitWrite := SOLCommitWrite
+
+	param := &SOLConfigParam{
+		SetInProgress:      &commitWrite,
+		SOLEnable:          &enable,
+		SOLAuthentication:  &SOLConfigParam_SOLAuthentication{ForceEncryption: true, PrivilegeLevel: uint8(PrivilegeLevelAdministrator)},
+		Character:          &SOLConfigParam_Character{AccumulateInterval5Millis: 12, SendThreshold: 96},
+		SOLRetry:           &SOLConfigParam_SOLRetry{RetryCount: 7, RetryInterval10Millis: 50},
+		NonVolatileBitRate: &nonVolatileBitRate,
+		VolatileBitRate:    &volatileBitRate,
+		PayloadChannel:     &payloadChannel,
+		PayloadPort:        &payloadPort,
+	}
+
+	tests := []struct {
+		paramSelector SOLConfigParamSelector
+		expected      []byte
+	}{
+		{SOLConfigParamSelector_SetInProgress, []byte{0x02}},
+		{SOLConfigParamSelector_SOLEnable, []byte{0x01}},
+		{SOLConfigParamSelector_SOLAuthentication, []byte{0x84}},
+		{SOLConfigParamSelector_Character, []byte{0x0c, 0x60}},
+		{SOLConfigParamSelector_SOLRetry, []byte{0x07, 0x32}},
+		{SOLConfigParamSelector_NonVolatileBitRate, []byte{0x0a}},
+		{SOLConfigParamSelector_VolatileBitRate, []byte{0x06}},
+		{SOLConfigParamSelector_PayloadChannel, []byte{0x01}},
+		{SOLConfigParamSelector_PayloadPort, []byte{0x6f, 0x02}},
+	}
+
+	for _, test := range tests {
+		got, err := PackSOLParamData(test.paramSelector, param)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.paramSelector, err)
+			continue
+		}
+		if !bytes.Equal(got, test.expected) {
+			t.Errorf("test %s not matched, got: %x, expected: %x", test.paramSelector, got, test.expected)
+		}
+
+		// round trip
+		parsed := &SOLConfigParam{}
+		if err := ParseSOLParamData(test.paramSelector, got, parsed); err != nil {
+			t.Errorf("test %s parse failed, err: %s", test.paramSelector, err)
+			continue
+		}
+		if again, _ := PackSOLParamData(test.paramSelector, parsed); !bytes.Equal(again, got) {
+			t.Errorf("test %s round trip not matched, got: %x, expected: %x", test.paramSelector, again, got)
+		}
+	}
+
+	if _, err := PackSOLParamData(SOLConfigParamSelector_SOLEnable, &SOLConfigParam{}); err == nil {
+		t.Errorf("test unset param expected error")
+	}
+
+	// the privilege level must not overwrite the force flags
+	authentication := &SOLConfigParam_SOLAuthentication{PrivilegeLevel: 0xf4}
+	if got, expected := authentication.Pack(), []byte{0x04}; !bytes.Equal(got, expected) {
+		t.Errorf("test SOLAuthentication privilege level not masked, got: %x, expected: %x", got, expected)
+	}
+
+	request := &SetSOLConfigParamsRequest{ChannelNumber: 0x0e, ParameterSelector: uint8(SOLConfigParamSelector_SOLRetry), ParameterData: []byte{0x07, 0x32}}
+	if got, expected := request.Pack(), []byte{0x0e, 0x04, 0x07, 0x32}; !bytes.Equal(got, expected) {
+		t.Errorf("test SetSOLConfigParamsRequest not matched, got: %x, expected: %x", got, expected)
+	}
+}
+
+// fakeSOLConfig is the SOL configuration parameters of a BMC implementing solConfigurer.
+type fakeSOLConfig struct {
+	params map[SOLConfigParamSelector][]byte
+	// the parameters rejected by the set command with the completion code
+	rejected map[SOLConfigParamSelector]CompletionCode
+	// the parameters set, in order
+	sets []SOLConfigParamSelector
+}
+
+func (f *fakeSOLConfig) GetSOLConfigParams(channelNumber uint8, paramSelector SOLConfigParamSelector) (*GetSOLConfigParamsResponse, error) {
+	data, ok := f.params[paramSelector]
+	if !ok {
+		return nil, &ResponseError{completionCode: CompletionCode(0x80)}
+	}
+	return &GetSOLConfigParamsResponse{ParameterData: data}, nil
+}
+
+func (f *fakeSOLConfig) SetSOLConfigurationParameters(channelNumber uint8, paramSelector uint8, paramData []byte) (*SetSOLConfigurationParametersResponse, error) {
+	selector := SOLConfigParamSelector(paramSelector)
+	if _, ok := f.params[selector]; !ok {
+		return nil, &ResponseError{completionCode: CompletionCode(0x80)}
+	}
+	if cc, ok := f.rejected[selector]; ok {
+		return nil, &ResponseError{completionCode: cc}
+	}
+	if selector != SOLConfigParamSelector_SetInProgress {
+		f.sets = append(f.sets, selector)
+	}
+	f.params[selector] = paramData
+	return &SetSOLConfigurationParametersResponse{}, nil
+}
+
+func (f *fakeSOLConfig) Debugf(format string, object ...interface{}) {
+}
+
+func Test_SOLConfigParam_SOLRetry(t *testing.T) {
+	// the retry count is only 3 bits, the reserved bits are not sent
+	retry := &SOLConfigParam_SOLRetry{RetryCount: 0xff, RetryInterval10Millis: 50}
+	if got := retry.Pack(); !bytes.Equal(got, []byte{0x07, 0x32}) {
+		t.Errorf("test pack not matched, got: %x, expected: 0732", got)
+	}
+
+	parsed := &SOLConfigParam_SOLRetry{}
+	if err := parsed.Unpack([]byte{0xfb, 0x32}); err != nil || parsed.RetryCount != 3 {
+		t.Errorf("test unpack not matched, got: %d, err: %v", parsed.RetryCount, err)
+	}
+}
+
+func Test_setSOLConfig(t *testing.T) {
+	enable := SOLConfigParam_SOLEnable(false)
+	bitRate := SOLConfigParam_VolatileBitRate(0x0a)
+	param := &SOLConfigParam{
+		SOLEnable:       &enable,
+		SOLRetry:        &SOLConfigParam_SOLRetry{RetryCount: 3, RetryInterval10Millis: 10},
+		VolatileBitRate: &bitRate,
+	}
+
+	tests := []struct {
+		name        string
+		bmc         *fakeSOLConfig
+		unsupported []SOLConfigParamSelector
+		sets        []SOLConfigParamSelector
+		fails       bool
+	}{
+		{
+			name: "all supported",
+			bmc: &fakeSOLConfig{params: map[SOLConfigParamSelector][]byte{
+				SOLConfigParamSelector_SetInProgress: {0x00}, SOLConfigParamSelector_SOLEnable: {0x01},
+				SOLConfigParamSelector_SOLRetry: {0x07, 0x32}, SOLConfigParamSelector_VolatileBitRate: {0x06},
+			}},
+			unsupported: []SOLConfigParamSelector{},
+			sets:        []SOLConfigParamSelector{SOLConfigParamSelector_SOLEnable, SOLConfigParamSelector_SOLRetry, SOLConfigParamSelector_VolatileBitRate},
+		},
+		{
+			name: "optional parameter and set in progress not supported",
+			bmc: &fakeSOLConfig{params: map[SOLConfigParamSelector][]byte{
+				SOLConfigParamSelector_SOLEnable: {0x01}, SOLConfigParamSelector_VolatileBitRate: {0x06},
+			}},
+			unsupported: []SOLConfigParamSelector{SOLConfigParamSelector_SOLRetry},
+			sets:        []SOLConfigParamSelector{SOLConfigParamSelector_SOLEnable, SOLConfigParamSelector_VolatileBitRate},
+		},
+		{
+			name: "read-only parameter",
+			bmc: &fakeSOLConfig{
+				params: map[SOLConfigParamSelector][]byte{
+					SOLConfigParamSelector_SOLEnable: {0x01}, SOLConfigParamSelector_SOLRetry: {0x07, 0x32}, SOLConfigParamSelector_VolatileBitRate: {0x06},
+				},
+				rejected: map[SOLConfigParamSelector]CompletionCode{SOLConfigParamSelector_VolatileBitRate: 0x82},
+			},
+			unsupported: []SOLConfigParamSelector{SOLConfigParamSelector_VolatileBitRate},
+			sets:        []SOLConfigParamSelector{SOLConfigParamSelector_SOLEnable, SOLConfigParamSelector_SOLRetry},
+		},
+		{
+			name: "failure rolls back",
+			bmc: &fakeSOLConfig{
+				params: map[SOLConfigParamSelector][]byte{
+					SOLConfigParamSelector_SOLEnable: {0x01}, SOLConfigParamSelector_SOLRetry: {0x07, 0x32}, SOLConfigParamSelector_VolatileBitRate: {0x06},
+				},
+				rejected: map[SOLConfigParamSelector]CompletionCode{SOLConfigParamSelector_SOLRetry: CompletionCodeParameterOutOfRange},
+			},
+			// SOLEnable is set, and restored
+			sets:  []SOLConfigParamSelector{SOLConfigParamSelector_SOLEnable, SOLConfigParamSelector_SOLEnable},
+			fails: true,
+		},
+	}
+
+	for _, test := range tests {
+		unsupported, err := setSOLConfig(test.bmc, 0x0e, param)
+		if test.fails {
+			if err == nil {
+				t.Errorf("test %s expected error", test.name)
+			}
+			if !bytes.Equal(test.bmc.params[SOLConfigParamSelector_SOLEnable], []byte{0x01}) {
+				t.Errorf("test %s not rolled back, got: %x", test.name, test.bmc.params[SOLConfigParamSelector_SOLEnable])
+			}
+		} else {
+			if err != nil {
+				t.Errorf("test %s failed, err: %s", test.name, err)
+				continue
+			}
+			if !reflect.DeepEqual(unsupported, test.unsupported) {
+				t.Errorf("test %s unsupported not matched, got: %v, expected: %v", test.name, unsupported, test.unsupported)
+			}
+		}
+		if !reflect.DeepEqual(test.bmc.sets, test.sets) {
+			t.Errorf("test %s sets not matched, got: %v, expected: %v", test.name, test.bmc.sets, test.sets)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Package collector collects IPMI metrics by go-ipmi for Prometheus.
+package collector
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+const namespace = "ipmi"
+
+// Collector collects a group of metrics from a BMC.
+type Collector interface {
+	// Name returns the name of the collector, used in the collectors list of Module
+	// and the collector label of the collector metrics.
+	Name() string
+
+	// Collect collects metrics by client and add them to metrics.
+	Collect(client *ipmi.Client, metrics *Metrics) error
+}
+
+var collectors = map[string]Collector{}
+
+// Register registers a collector, the collector can then be enabled by its name in Module.
+// A collector with the same name will be replaced.
+func Register(c Collector) {
+	collectors[c.Name()] = c
+}
+
+// Collectors returns the names of all registered collectors.
+func Collectors() []string {
+	out := make([]string, 0, len(collectors))
+	for name := range collectors {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func init() {
+	Register(&SensorCollector{})
+	Register(&ChassisCollector{})
+	Register(&SELCollector{})
+	Register(&BMCCollector{})
+	Register(&POHCollector{})
+}
+
+// Scrape runs the named collectors by client one by one.
+// The error of a collector does not stop the scrape, but is reported
+// by the ipmi_collector_up metric, and returned for logging.
+func Scrape(client *ipmi.Client, names []string, metrics *Metrics) []error {
+	errs := make([]error, 0)
+
+	for _, name := range names {
+		labels := []Label{{"collector", name}}
+
+		c, ok := collectors[name]
+		if !ok {
+			metrics.Gauge(namespace+"_collector_up", "Whether the collector succeeded.", 0, labels...)
+			errs = append(errs, fmt.Errorf("unknown collector (%s)", name))
+			continue
+		}
+
+		start := time.Now()
+		err := c.Collect(client, metrics)
+		duration := time.Since(start)
+
+		var up float64 = 1
+		if err != nil {
+			up = 0
+			errs = append(errs, fmt.Errorf("collector %s failed, err: %s", name, err))
+		}
+		metrics.Gauge(namespace+"_collector_up", "Whether the collector succeeded.", up, labels...)
+		metrics.Gauge(namespace+"_collector_duration_seconds", "Duration of the collector.", duration.Seconds(), labels...)
+	}
+
+	return errs
+}
+
+// SensorCollector collects the readings and status of Full and Compact SDR sensors.
+type SensorCollector struct{}
+
+func (c *SensorCollector) Name() string {
+	return "sensor"
+}
+
+// Collect exports the sensors could be read even if some sensors failed,
+// the failed sensors are reported by the returned error.
+func (c *SensorCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	sensors, err := client.GetSensors()
+	if err != nil {
+		if _, ok := err.(*ipmi.SensorsError); !ok {
+			return fmt.Errorf("GetSensors failed, err: %s", err)
+		}
+	}
+
+	for _, sensor := range sensors {
+		addSensorMetrics(sensor, metrics)
+	}
+
+	if err != nil {
+		return fmt.Errorf("GetSensors failed, err: %s", err)
+	}
+	return nil
+}
+
+// sensorMetricName returns the metric name and the value converted to the Prometheus base unit
+// for well-known sensor units. The ok is false for the other units.
+func sensorMetricName(unit ipmi.SensorUnit, value float64) (name string, v float64, ok bool) {
+	if unit.RateUnit != ipmi.SensorRateUnit_None || unit.ModifierRelation != ipmi.SensorModifierRelation_None {
+		return "", 0, false
+	}
+
+	if unit.Percentage {
+		if unit.BaseUnit == ipmi.SensorUnitType_Unspecified {
+			return namespace + "_sensor_ratio", value / 100, true
+		}
+		return "", 0, false
+	}
+
+	switch unit.BaseUnit {
+	case ipmi.SensorUnitType_DegressC:
+		return namespace + "_temperature_celsius", value, true
+	case ipmi.SensorUnitType_DegreesF:
+		return namespace + "_temperature_celsius", (value - 32) * 5 / 9, true
+	case ipmi.SensorUnitType_DegreesK:
+		return namespace + "_temperature_celsius", value - 273.15, true
+	case ipmi.SensorUnitType_Volts:
+		return namespace + "_voltage_volts", value, true
+	case ipmi.SensorUnitType_Amps:
+		return namespace + "_current_amperes", value, true
+	case ipmi.SensorUnitType_Watts:
+		return namespace + "_power_watts", value, true
+	case ipmi.SensorUnitType_Joules:
+		return namespace + "_energy_joules", value, true
+	case ipmi.SensorUnitType_RPM:
+		return namespace + "_fan_speed_rpm", value, true
+	case ipmi.SensorUnitType_CFM:
+		return namespace + "_airflow_cfm", value, true
+	}
+	return "", 0, false
+}
+
+// sensorState returns 0 for ok, 1 for non-critical, 2 for critical and non-recoverable threshold status.
+func sensorState(status ipmi.SensorThresholdStatus) float64 {
+	switch status {
+	case ipmi.SensorThresholdStatus_LNC, ipmi.SensorThresholdStatus_UNC:
+		return 1
+	case ipmi.SensorThresholdStatus_LCR, ipmi.SensorThresholdStatus_UCR,
+		ipmi.SensorThresholdStatus_LNR, ipmi.SensorThresholdStatus_UNR:
+		return 2
+	}
+	return 0
+}
+
+func addSensorMetrics(sensor *ipmi.Sensor, metrics *Metrics) {
+	// the sensor number is only unique within the owner and the LUN
+	labels := []Label{
+		{"id", fmt.Sprintf("%d", sensor.Number)},
+		{"owner", fmt.Sprintf("%#02x", sensor.GeneratorID.OwnerID())},
+		{"lun", fmt.Sprintf("%d", sensor.GeneratorID.LUN())},
+		{"name", sensor.Name},
+		{"type", sensor.SensorType.String()},
+	}
+
+	if sensor.Err != nil || !sensor.IsThreshold() || !sensor.IsReadingValid() {
+		return
+	}
+
+	metrics.Gauge(namespace+"_sensor_state", "Threshold status of the sensor (0=ok, 1=non-critical, 2=critical).", sensorState(sensor.Threshold.ThresholdStatus), labels...)
+
+	if name, value, ok := sensorMetricName(sensor.SensorUnit, sensor.Value); ok {
+		metrics.Gauge(name, "Reading of the sensor in the base unit.", value, labels...)
+		return
+	}
+
+	labels = append(labels, Label{"unit", sensor.SensorUnit.String()})
+	metrics.Gauge(namespace+"_sensor_value", "Reading of the sensor with other units.", sensor.Value, labels...)
+}
+
+// ChassisCollector collects chassis status.
+type ChassisCollector struct{}
+
+func (c *ChassisCollector) Name() string {
+	return "chassis"
+}
+
+func (c *ChassisCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	res, err := client.GetChassisStatus()
+	if err != nil {
+		return fmt.Errorf("GetChassisStatus failed, err: %s", err)
+	}
+
+	metrics.Gauge(namespace+"_chassis_power_state", "Whether the system power is on.", boolToFloat(res.PowerIsOn))
+	metrics.Gauge(namespace+"_chassis_power_fault_state", "Whether a fault is detected in main power subsystem.", boolToFloat(res.PowerFault))
+	metrics.Gauge(namespace+"_chassis_drive_fault_state", "Whether a drive fault is detected.", boolToFloat(res.DriveFault))
+	metrics.Gauge(namespace+"_chassis_cooling_fault_state", "Whether a cooling or fan fault is detected.", boolToFloat(res.CollingFanFault))
+	metrics.Gauge(namespace+"_chassis_intrusion_state", "Whether the chassis intrusion is active.", boolToFloat(res.ChassisIntrusionActive))
+	return nil
+}
+
+// SELCollector collects SEL information.
+type SELCollector struct {
+	mu sync.Mutex
+	// the aux log types not supported by the BMCs keyed by host:port, they are not requested again
+	auxLogUnsupported map[string]map[ipmi.AuxLogType]bool
+}
+
+func (c *SELCollector) Name() string {
+	return "sel"
+}
+
+// Collect exports the SEL Info, and the statuses of the auxiliary logs.
+// The failures of the auxiliary logs are reported by the ipmi_sel_aux_log_up metric,
+// they don't fail the collector.
+func (c *SELCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	res, err := client.GetSELInfo()
+	if err != nil {
+		return fmt.Errorf("GetSELInfo failed, err: %s", err)
+	}
+
+	metrics.Gauge(namespace+"_sel_logs_count", "Number of SEL entries.", float64(res.Entries))
+	metrics.Gauge(namespace+"_sel_free_space_bytes", "Free space of SEL in bytes.", float64(res.FreeBytes))
+	metrics.Gauge(namespace+"_sel_overflow_state", "Whether events have been dropped due to lack of space in the SEL.", boolToFloat(res.OperationSupport.Overflow))
+	if !res.RecentAdditionTime.IsZero() {
+		metrics.Gauge(namespace+"_sel_last_addition_timestamp_seconds", "Timestamp of the most recent SEL addition.", float64(res.RecentAdditionTime.Unix()))
+	}
+
+	c.collectAuxLogs(net.JoinHostPort(client.Host, strconv.Itoa(client.Port)), client, metrics)
+	return nil
+}
+
+// collectAuxLogs exports the statuses of the auxiliary logs of the BMC by key,
+// the log types not supported by the BMC are remembered and skipped in the later scrapes.
+func (c *SELCollector) collectAuxLogs(key string, source ipmi.AuxLogSource, metrics *Metrics) {
+	c.mu.Lock()
+	if c.auxLogUnsupported == nil {
+		c.auxLogUnsupported = make(map[string]map[ipmi.AuxLogType]bool)
+	}
+	if c.auxLogUnsupported[key] == nil {
+		c.auxLogUnsupported[key] = make(map[ipmi.AuxLogType]bool)
+	}
+	unsupported := make(map[ipmi.AuxLogType]bool)
+	for logType := range c.auxLogUnsupported[key] {
+		unsupported[logType] = true
+	}
+	c.mu.Unlock()
+
+	for _, logType := range ipmi.AuxLogTypes {
+		if unsupported[logType] {
+			continue
+		}
+		labels := []Label{{"log_type", logType.String()}}
+
+		status, err := source.GetAuxLogStatus(logType)
+		if err != nil {
+			if ipmi.IsAuxLogUnsupported(err) {
+				c.mu.Lock()
+				c.auxLogUnsupported[key][logType] = true
+				c.mu.Unlock()
+				continue
+			}
+			metrics.Gauge(namespace+"_sel_aux_log_up", "Whether the status of the auxiliary log was read.", 0, labels...)
+			continue
+		}
+
+		metrics.Gauge(namespace+"_sel_aux_log_up", "Whether the status of the auxiliary log was read.", 1, labels...)
+		if status.Timestamp.IsZero() {
+			continue
+		}
+		metrics.Gauge(namespace+"_sel_aux_log_last_update_timestamp_seconds", "Timestamp of the most recent update of the auxiliary log, like the MCA log.",
+			float64(status.Timestamp.Unix()), labels...)
+	}
+}
+
+// BMCCollector collects BMC device information.
+type BMCCollector struct{}
+
+func (c *BMCCollector) Name() string {
+	return "bmc"
+}
+
+func (c *BMCCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	res, err := client.GetDeviceID()
+	if err != nil {
+		return fmt.Errorf("GetDeviceID failed, err: %s", err)
+	}
+
+	metrics.Gauge(namespace+"_bmc_info", "Constant metric with BMC device information.", 1,
+		Label{"firmware_revision", res.FirmwareVersionStr()},
+		Label{"ipmi_version", fmt.Sprintf("%d.%d", res.MajorIPMIVersion, res.MinorIPMIVersion)},
+		Label{"manufacturer_id", fmt.Sprintf("%d", res.ManufacturerID)},
+		Label{"product_id", fmt.Sprintf("%d", res.ProductID)},
+	)
+	return nil
+}
+
+// POHCollector collects the Power-On Hours counter.
+type POHCollector struct{}
+
+func (c *POHCollector) Name() string {
+	return "poh"
+}
+
+func (c *POHCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	res, err := client.GetPOHCounter()
+	if err != nil {
+		return fmt.Errorf("GetPOHCounter failed, err: %s", err)
+	}
+
+	metrics.Counter(namespace+"_power_on_seconds_total", "Power-on time counted by the POH counter.", float64(res.Minutes())*60)
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
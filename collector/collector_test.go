@@ -0,0 +1,185 @@
+package collector
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+type fakeCollector struct {
+	name string
+	err  error
+}
+
+func (c *fakeCollector) Name() string {
+	return c.name
+}
+
+func (c *fakeCollector) Collect(client *ipmi.Client, metrics *Metrics) error {
+	if c.err != nil {
+		return c.err
+	}
+	metrics.Gauge("ipmi_fake_value", "Fake value.", 1.5, Label{"name", `CPU "1"`})
+	return nil
+}
+
+func Test_Scrape(t *testing.T) {
+	Register(&fakeCollector{name: "fake_ok"})
+	Register(&fakeCollector{name: "fake_err", err: fmt.Errorf("timeout")})
+	defer delete(collectors, "fake_ok")
+	defer delete(collectors, "fake_err")
+
+	metrics := NewMetrics()
+	errs := Scrape(nil, []string{"fake_ok", "fake_err", "nosuch"}, metrics)
+	if len(errs) != 2 {
+		t.Errorf("test scrape errors not matched, got: %v", errs)
+	}
+
+	out := metrics.String()
+	expected := []string{
+		"# HELP ipmi_collector_up Whether the collector succeeded.\n# TYPE ipmi_collector_up gauge\n",
+		`ipmi_collector_up{collector="fake_ok"} 1` + "\n",
+		`ipmi_collector_up{collector="fake_err"} 0` + "\n",
+		`ipmi_collector_up{collector="nosuch"} 0` + "\n",
+		"# TYPE ipmi_fake_value gauge\n" + `ipmi_fake_value{name="CPU \"1\""} 1.5` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("test scrape output not contains %q, got:\n%s", e, out)
+		}
+	}
+	if strings.Index(out, "ipmi_collector_up") > strings.Index(out, "ipmi_fake_value") {
+		t.Errorf("test scrape output metric families not sorted, got:\n%s", out)
+	}
+}
+
+func Test_sensorMetricName(t *testing.T) {
+	tests := []struct {
+		name         string
+		unit         ipmi.SensorUnit
+		value        float64
+		expectedName string
+		expected     float64
+	}{
+		{"celsius", ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_DegressC}, 45, "ipmi_temperature_celsius", 45},
+		{"fahrenheit", ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_DegreesF}, 212, "ipmi_temperature_celsius", 100},
+		{"rpm", ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_RPM}, 5400, "ipmi_fan_speed_rpm", 5400},
+		{"percent", ipmi.SensorUnit{Percentage: true}, 50, "ipmi_sensor_ratio", 0.5},
+		{"rate", ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_CFM, RateUnit: ipmi.SensorRateUnit_PerMin}, 1, "", 0},
+		{"other", ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_Hz}, 1, "", 0},
+	}
+
+	for _, test := range tests {
+		name, value, ok := sensorMetricName(test.unit, test.value)
+		if ok != (test.expectedName != "") || name != test.expectedName || value != test.expected {
+			t.Errorf("test %s not matched, got: %s %v, expected: %s %v", test.name, name, value, test.expectedName, test.expected)
+		}
+	}
+}
+
+func Test_addSensorMetrics(t *testing.T) {
+	sensor := &ipmi.Sensor{
+		Number:           0x30,
+		Name:             "CPU1 Temp",
+		SensorType:       ipmi.SensorTypeTemperature,
+		EventReadingType: ipmi.EventReadingTypeThreshold,
+		GeneratorID:      ipmi.GeneratorID(0x0162),
+		SensorUnit:       ipmi.SensorUnit{BaseUnit: ipmi.SensorUnitType_DegressC},
+		Value:            45,
+	}
+
+	metrics := NewMetrics()
+	addSensorMetrics(sensor, metrics)
+
+	expected := `ipmi_temperature_celsius{id="48",owner="0x62",lun="1",name="CPU1 Temp",type="Temperature"} 45`
+	if out := metrics.String(); !strings.Contains(out, expected) {
+		t.Errorf("test sensor metrics not contains %q, got:\n%s", expected, out)
+	}
+
+	// the failed reading of the sensor is not exported
+	sensor.Err = &ipmi.SensorError{}
+	metrics = NewMetrics()
+	addSensorMetrics(sensor, metrics)
+	if out := metrics.String(); out != "" {
+		t.Errorf("test failed sensor expected no metrics, got:\n%s", out)
+	}
+}
+
+func Test_Exporter_metrics(t *testing.T) {
+	var logs bytes.Buffer
+	config := &Config{Modules: map[string]*Module{
+		DefaultModuleName: {Interface: string(ipmi.InterfaceLan), Target: "127.0.0.1:1", TimeoutSeconds: 1, Collectors: []string{"chassis"}},
+	}}
+	server := httptest.NewServer(NewExporter(config, log.New(&logs, "", 0)).Handler())
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("test get metrics failed, err: %s", err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+
+	if !strings.Contains(string(body), "ipmi_up 0\n") {
+		t.Errorf("test metrics not contains ipmi_up, got:\n%s", body)
+	}
+	// the module target is scraped, nothing listens on it
+	if !strings.Contains(logs.String(), "connect target (127.0.0.1:1) failed") {
+		t.Errorf("test module target not scraped, got logs: %s", logs.String())
+	}
+}
+
+type fakeAuxLogSource struct {
+	errs  map[ipmi.AuxLogType]error
+	calls int
+}
+
+func (s *fakeAuxLogSource) GetAuxLogStatus(logType ipmi.AuxLogType) (*ipmi.GetAuxLogStatusResponse, error) {
+	s.calls++
+	if err, ok := s.errs[logType]; ok {
+		return nil, err
+	}
+	res := &ipmi.GetAuxLogStatusResponse{LogType: logType}
+	if logType == ipmi.AuxLogTypeMCA {
+		res.Timestamp = time.Unix(1700000000, 0)
+	}
+	return res, nil
+}
+
+func Test_SELCollector_collectAuxLogs(t *testing.T) {
+	source := &fakeAuxLogSource{errs: map[ipmi.AuxLogType]error{ipmi.AuxLogTypeOEM1: fmt.Errorf("timeout")}}
+	c := &SELCollector{}
+
+	metrics := NewMetrics()
+	c.collectAuxLogs("bmc1:623", source, metrics)
+
+	out := metrics.String()
+	expected := []string{
+		fmt.Sprintf(`ipmi_sel_aux_log_up{log_type="%s"} 1`, ipmi.AuxLogTypeMCA) + "\n",
+		fmt.Sprintf(`ipmi_sel_aux_log_up{log_type="%s"} 0`, ipmi.AuxLogTypeOEM1) + "\n",
+		fmt.Sprintf(`ipmi_sel_aux_log_up{log_type="%s"} 1`, ipmi.AuxLogTypeOEM2) + "\n",
+		fmt.Sprintf(`ipmi_sel_aux_log_last_update_timestamp_seconds{log_type="%s"} 1.7e+09`, ipmi.AuxLogTypeMCA) + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("test aux log output not contains %q, got:\n%s", e, out)
+		}
+	}
+	if strings.Contains(out, fmt.Sprintf(`ipmi_sel_aux_log_last_update_timestamp_seconds{log_type="%s"}`, ipmi.AuxLogTypeOEM2)) {
+		t.Errorf("test aux log output should not contain the zero timestamp, got:\n%s", out)
+	}
+
+	// the failed log type is not remembered as unsupported, it is requested again
+	c.collectAuxLogs("bmc1:623", source, NewMetrics())
+	if source.calls != 2*len(ipmi.AuxLogTypes) {
+		t.Errorf("test aux log calls not matched, got: %d, expected: %d", source.calls, 2*len(ipmi.AuxLogTypes))
+	}
+}
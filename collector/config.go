@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+const DefaultModuleName = "default"
+
+// Config holds the modules of the exporter, it is loaded from a JSON file like:
+//
+//	{
+//	  "modules": {
+//	    "default": {
+//	      "user": "admin",
+//	      "pass": "secret",
+//	      "interface": "lanplus",
+//	      "collectors": ["sensor", "chassis", "sel", "bmc", "poh"]
+//	    }
+//	  }
+//	}
+type Config struct {
+	Modules map[string]*Module `json:"modules"`
+}
+
+// Module holds the credentials and options to scrape a target.
+// The module of a scrape is specified by the module parameter, defaults to "default".
+type Module struct {
+	User      string `json:"user"`
+	Pass      string `json:"pass"`
+	Interface string `json:"interface"`
+	// Target is scraped when the scrape does not specify the target parameter, like the /metrics endpoint.
+	Target string `json:"target"`
+	// Port is used when the target does not contain a port, defaults to 623.
+	Port int `json:"port"`
+	// Timeout of each IPMI request
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// Collectors to run, defaults to all registered collectors.
+	Collectors []string `json:"collectors"`
+	// SDRCacheDir enables the SDR Repository cache, so the SDR Repository is not walked on every scrape.
+	SDRCacheDir string `json:"sdr_cache_dir"`
+	// SkipThresholds and SkipHysteresis skip reading the thresholds and hysteresis of threshold sensors,
+	// which are not exported, to speed up the scrape.
+	SkipThresholds bool `json:"skip_thresholds"`
+	SkipHysteresis bool `json:"skip_hysteresis"`
+}
+
+// DefaultConfig returns a Config with only the default module, which scrapes the local BMC by open interface.
+func DefaultConfig() *Config {
+	return &Config{
+		Modules: map[string]*Module{
+			DefaultModuleName: {
+				Interface: string(ipmi.InterfaceOpen),
+			},
+		},
+	}
+}
+
+// LoadConfig loads Config from the JSON file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read config file failed, err: %s", err)
+	}
+
+	config := &Config{}
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("unmarshal config file failed, err: %s", err)
+	}
+
+	for name, module := range config.Modules {
+		if err := module.validate(); err != nil {
+			return nil, fmt.Errorf("invalid module (%s), err: %s", name, err)
+		}
+	}
+
+	return config, nil
+}
+
+func (module *Module) validate() error {
+	switch ipmi.Interface(module.Interface) {
+	case "", ipmi.InterfaceOpen, ipmi.InterfaceLan, ipmi.InterfaceLanplus:
+	default:
+		return fmt.Errorf("not supported interface (%s), supported: lan,lanplus,open", module.Interface)
+	}
+
+	for _, name := range module.Collectors {
+		if _, ok := collectors[name]; !ok {
+			return fmt.Errorf("unknown collector (%s), supported: %v", name, Collectors())
+		}
+	}
+	return nil
+}
+
+func (module *Module) collectors() []string {
+	if len(module.Collectors) == 0 {
+		return Collectors()
+	}
+	return module.Collectors
+}
+
+// NewClient creates and connects a client to target by the module.
+// The target is "host" or "host:port", it is ignored for open interface.
+func (module *Module) NewClient(target string) (*ipmi.Client, error) {
+	client, err := ipmi.NewClientForTarget(&ipmi.ClientTarget{
+		Interface:   ipmi.Interface(module.Interface),
+		Host:        target,
+		Port:        module.Port,
+		Username:    module.User,
+		Password:    module.Pass,
+		Timeout:     time.Duration(module.TimeoutSeconds) * time.Second,
+		SDRCacheDir: module.SDRCacheDir,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client.WithSensorErrorTolerance(true).
+		WithSkipSensorThresholds(module.SkipThresholds).
+		WithSkipSensorHysteresis(module.SkipHysteresis)
+	return client, nil
+}
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Exporter serves the metrics of IPMI targets over HTTP.
+//
+//	/metrics                               scrape the target of the default module (the local BMC by default)
+//	/ipmi?target=<host[:port]>&module=<m>  scrape the target by the module
+type Exporter struct {
+	config *Config
+	logger *log.Logger
+}
+
+func NewExporter(config *Config, logger *log.Logger) *Exporter {
+	return &Exporter{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Handler returns the http.Handler of the exporter.
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", e.handleScrape)
+	mux.HandleFunc("/ipmi", e.handleScrape)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<html>
+<head><title>IPMI Exporter</title></head>
+<body>
+<h1>IPMI Exporter</h1>
+<p><a href="/metrics">Local metrics</a></p>
+<p><a href="/ipmi?target=127.0.0.1">Remote metrics of target</a></p>
+</body>
+</html>
+`)
+	})
+	return mux
+}
+
+func (e *Exporter) handleScrape(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("target")
+	moduleName := r.URL.Query().Get("module")
+	if moduleName == "" {
+		moduleName = DefaultModuleName
+	}
+
+	module, ok := e.config.Modules[moduleName]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown module (%s)", moduleName), http.StatusBadRequest)
+		return
+	}
+	if target == "" {
+		target = module.Target
+	}
+
+	metrics := e.Scrape(target, module)
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := metrics.WriteTo(w); err != nil {
+		e.logf("write metrics for target (%s) failed, err: %s", target, err)
+	}
+}
+
+// Scrape collects the metrics of target by module.
+// Errors are not returned but reported by the ipmi_up and ipmi_collector_up metrics.
+func (e *Exporter) Scrape(target string, module *Module) *Metrics {
+	metrics := NewMetrics()
+	start := time.Now()
+
+	client, err := module.NewClient(target)
+	if err != nil {
+		e.logf("connect target (%s) failed, err: %s", target, err)
+		metrics.Gauge(namespace+"_up", "Whether the BMC is reachable.", 0)
+	} else {
+		metrics.Gauge(namespace+"_up", "Whether the BMC is reachable.", 1)
+
+		for _, err := range Scrape(client, module.collectors(), metrics) {
+			e.logf("scrape target (%s) failed, err: %s", target, err)
+		}
+
+		if err := client.Close(); err != nil {
+			e.logf("close client for target (%s) failed, err: %s", target, err)
+		}
+	}
+
+	metrics.Gauge(namespace+"_scrape_duration_seconds", "Duration of the scrape.", time.Since(start).Seconds())
+	return metrics
+}
+
+func (e *Exporter) logf(format string, v ...interface{}) {
+	if e.logger != nil {
+		e.logger.Printf(format, v...)
+	}
+}
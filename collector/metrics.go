@@ -0,0 +1,159 @@
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type MetricType string
+
+const (
+	MetricTypeGauge   MetricType = "gauge"
+	MetricTypeCounter MetricType = "counter"
+)
+
+// Label is a name/value pair of a metric sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Metrics holds the metric families collected by a scrape,
+// and writes them in the Prometheus text exposition format.
+//
+// see: https://prometheus.io/docs/instrumenting/exposition_formats/
+type Metrics struct {
+	families []*metricFamily
+	index    map[string]*metricFamily
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	typ     MetricType
+	samples []*sample
+}
+
+type sample struct {
+	labels []Label
+	value  float64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		families: make([]*metricFamily, 0),
+		index:    make(map[string]*metricFamily),
+	}
+}
+
+// Add adds a sample to the metric family of name.
+// The help and typ are only used when the metric family is firstly added.
+func (m *Metrics) Add(name string, help string, typ MetricType, value float64, labels ...Label) {
+	family, ok := m.index[name]
+	if !ok {
+		family = &metricFamily{
+			name:    name,
+			help:    help,
+			typ:     typ,
+			samples: make([]*sample, 0),
+		}
+		m.families = append(m.families, family)
+		m.index[name] = family
+	}
+
+	family.samples = append(family.samples, &sample{
+		labels: labels,
+		value:  value,
+	})
+}
+
+// Gauge adds a gauge sample.
+func (m *Metrics) Gauge(name string, help string, value float64, labels ...Label) {
+	m.Add(name, help, MetricTypeGauge, value, labels...)
+}
+
+// Counter adds a counter sample.
+func (m *Metrics) Counter(name string, help string, value float64, labels ...Label) {
+	m.Add(name, help, MetricTypeCounter, value, labels...)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+// The metric families are sorted by name, samples are kept in the added order.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	families := make([]*metricFamily, len(m.families))
+	copy(families, m.families)
+	sort.SliceStable(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, family := range families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", family.name, escapeHelp(family.help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", family.name, family.typ)
+		for _, sample := range family.samples {
+			bw.WriteString(family.name)
+			if len(sample.labels) > 0 {
+				bw.WriteByte('{')
+				for i, label := range sample.labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					fmt.Fprintf(bw, "%s=\"%s\"", label.Name, escapeLabelValue(label.Value))
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(formatFloat(sample.value))
+			bw.WriteByte('\n')
+		}
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+func (m *Metrics) String() string {
+	var sb strings.Builder
+	m.WriteTo(&sb)
+	return sb.String()
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,209 @@
+package forwarder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// Config holds the BMCs to follow, the targets and the routing rules of the forwarder,
+// it is loaded from a JSON file like:
+//
+//	{
+//	  "bmcs": [
+//	    {"host": "10.0.0.1", "user": "admin", "pass": "secret", "interface": "lanplus"}
+//	  ],
+//	  "interval_seconds": 5,
+//	  "cursor_dir": "/var/lib/goipmi/forward",
+//	  "delivery_attempts": 3,
+//	  "dead_letter_file": "/var/lib/goipmi/forward/dead-letter.ndjson",
+//	  "targets": {
+//	    "syslog": {"type": "syslog", "network": "udp", "address": "127.0.0.1:514"},
+//	    "alerts": {"type": "webhook", "url": "https://alerts.example.com/ipmi", "max_retries": 3}
+//	  },
+//	  "rules": [
+//	    {"severities": ["Critical", "Non-fatal"], "targets": ["alerts"]},
+//	    {"targets": ["syslog"]}
+//	  ]
+//	}
+type Config struct {
+	BMCs []*BMC `json:"bmcs"`
+
+	// Poll interval of SEL, defaults to 5 seconds.
+	IntervalSeconds int `json:"interval_seconds"`
+
+	// CursorDir saves the SEL cursor of each BMC, so the forwarder resumes after restart.
+	// If empty, the cursors are kept in memory and only the records added after start are forwarded.
+	CursorDir string `json:"cursor_dir"`
+
+	// A record failed to be forwarded to a target is sent again to the failed target after the poll interval,
+	// until it is delivered or the attempts are reached, defaults to 3 attempts. The permanent errors
+	// (like the 4xx responses of webhooks) are not retried. The record is then given up for the target.
+	DeliveryAttempts int `json:"delivery_attempts"`
+
+	// DeadLetterFile appends the records given up for the targets as JSON lines of DeadLetter.
+	// If empty, the records given up are only logged.
+	DeadLetterFile string `json:"dead_letter_file"`
+
+	Targets map[string]*TargetConfig `json:"targets"`
+
+	// Each record is forwarded to the targets of all the matched rules, at most once for each target.
+	// If no rules, the records are forwarded to all targets.
+	Rules []*Rule `json:"rules"`
+}
+
+// BMC holds the address and credentials of a BMC to follow.
+type BMC struct {
+	// Host is also used as the hostname of the forwarded records, empty for the local BMC by open interface.
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	User      string `json:"user"`
+	Pass      string `json:"pass"`
+	Interface string `json:"interface"`
+	// Timeout of each IPMI request
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// SDRCacheDir enables the SDR Repository cache.
+	SDRCacheDir string `json:"sdr_cache_dir"`
+}
+
+type TargetType string
+
+const (
+	TargetTypeSyslog  TargetType = "syslog"
+	TargetTypeWebhook TargetType = "webhook"
+)
+
+// TargetConfig holds the options of a target, the fields are used by the target type.
+type TargetConfig struct {
+	Type TargetType `json:"type"`
+
+	// syslog: network is one of udp, tcp, unix (stream) and unixgram, the address is "host:port" or the socket path.
+	Network string `json:"network"`
+	Address string `json:"address"`
+	// Facility name like "daemon", "local0", defaults to "local0".
+	Facility string `json:"facility"`
+
+	// webhook
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	// Failed requests are retried with exponential backoff starting from the retry interval.
+	// Defaults to 3 retries, 1 second retry interval and 10 seconds timeout.
+	MaxRetries           *int `json:"max_retries"`
+	RetryIntervalSeconds int  `json:"retry_interval_seconds"`
+	TimeoutSeconds       int  `json:"timeout_seconds"`
+}
+
+// Rule routes the records to the targets. All the non-empty conditions of the rule must match.
+type Rule struct {
+	// Event severities like "Critical", "Non-fatal", see ipmi.EventSeverity.
+	// OEM records have no severity, they never match a rule with severities.
+	Severities []ipmi.EventSeverity `json:"severities"`
+
+	// Sensor type names like "Power Supply", case insensitive, see ipmi.SensorType.
+	// OEM records have no sensor type, they never match a rule with sensor types.
+	SensorTypes []string `json:"sensor_types"`
+
+	// Regular expression matched against the text of the record, see Event.Text.
+	Pattern string `json:"pattern"`
+
+	Targets []string `json:"targets"`
+
+	pattern *regexp.Regexp
+}
+
+// LoadConfig loads Config from the JSON file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read config file failed, err: %s", err)
+	}
+
+	config := &Config{}
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("unmarshal config file failed, err: %s", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (config *Config) validate() error {
+	if len(config.BMCs) == 0 {
+		return fmt.Errorf("no bmcs")
+	}
+	for _, bmc := range config.BMCs {
+		switch ipmi.Interface(bmc.Interface) {
+		case "", ipmi.InterfaceOpen, ipmi.InterfaceLan, ipmi.InterfaceLanplus:
+		default:
+			return fmt.Errorf("invalid bmc (%s), not supported interface (%s), supported: lan,lanplus,open", bmc.Host, bmc.Interface)
+		}
+	}
+
+	if len(config.Targets) == 0 {
+		return fmt.Errorf("no targets")
+	}
+	for name, target := range config.Targets {
+		if err := target.validate(); err != nil {
+			return fmt.Errorf("invalid target (%s), err: %s", name, err)
+		}
+	}
+
+	for i, rule := range config.Rules {
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("invalid rule (%d), err: %s", i, err)
+		}
+		if len(rule.Targets) == 0 {
+			return fmt.Errorf("invalid rule (%d), no targets", i)
+		}
+		for _, name := range rule.Targets {
+			if _, ok := config.Targets[name]; !ok {
+				return fmt.Errorf("invalid rule (%d), unknown target (%s)", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (config *Config) interval() time.Duration {
+	if config.IntervalSeconds <= 0 {
+		return ipmi.DefaultSELFollowInterval
+	}
+	return time.Duration(config.IntervalSeconds) * time.Second
+}
+
+func (config *Config) deliveryAttempts() int {
+	if config.DeliveryAttempts <= 0 {
+		return 3
+	}
+	return config.DeliveryAttempts
+}
+
+func (target *TargetConfig) validate() error {
+	switch target.Type {
+	case TargetTypeSyslog:
+		switch target.Network {
+		case "udp", "tcp", "unix", "unixgram":
+		default:
+			return fmt.Errorf("not supported syslog network (%s), supported: udp,tcp,unix,unixgram", target.Network)
+		}
+		if target.Address == "" {
+			return fmt.Errorf("empty syslog address")
+		}
+		if _, ok := syslogFacilities[target.Facility]; !ok && target.Facility != "" {
+			return fmt.Errorf("not supported syslog facility (%s)", target.Facility)
+		}
+	case TargetTypeWebhook:
+		if target.URL == "" {
+			return fmt.Errorf("empty webhook url")
+		}
+	default:
+		return fmt.Errorf("not supported target type (%s), supported: syslog,webhook", target.Type)
+	}
+	return nil
+}
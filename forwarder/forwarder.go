@@ -0,0 +1,429 @@
+package forwarder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// Event is a SEL record to forward, it is also the JSON body of the webhook:
+//
+//	{"host": "10.0.0.1", "sel": {...}}
+//
+// The schema of "sel" is ipmi.SELOutput.
+type Event struct {
+	Host   string          `json:"host"`
+	Record *ipmi.SELOutput `json:"sel"`
+}
+
+// DeadLetter is the line of the dead letter file, it is the event given up for the target.
+type DeadLetter struct {
+	Target string `json:"target"`
+	Error  string `json:"error"`
+	*Event
+}
+
+// Text returns the text matched by the pattern of rules:
+//
+//	standard: <sensor type> <sensor name> <event> <event direction>, <event detail>
+//	OEM:      OEM record <record type>, <description>
+func (e *Event) Text() string {
+	text := e.Record.SyslogMessage().Message
+	if s := e.Record.Standard; s != nil {
+		text = strings.TrimSpace(fmt.Sprintf("%s %s", s.SensorType, s.SensorName)) + " " + text
+	}
+	return text
+}
+
+func (rule *Rule) compile() error {
+	if rule.Pattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern (%s), err: %s", rule.Pattern, err)
+	}
+	rule.pattern = pattern
+	return nil
+}
+
+// Match reports whether the event matches all the non-empty conditions of the rule.
+func (rule *Rule) Match(event *Event) bool {
+	s := event.Record.Standard
+
+	if len(rule.Severities) > 0 {
+		if s == nil || !containsFold(severitiesToStrings(rule.Severities), s.EventSeverity) {
+			return false
+		}
+	}
+
+	if len(rule.SensorTypes) > 0 {
+		if s == nil || !containsFold(rule.SensorTypes, s.SensorType) {
+			return false
+		}
+	}
+
+	if rule.pattern != nil && !rule.pattern.MatchString(event.Text()) {
+		return false
+	}
+
+	return true
+}
+
+func severitiesToStrings(severities []ipmi.EventSeverity) []string {
+	out := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		out = append(out, string(severity))
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Forwarder follows the SEL of the BMCs and forwards the new records to the targets by the rules.
+type Forwarder struct {
+	config  *Config
+	targets map[string]Target
+	logger  *log.Logger
+
+	// the interval to send the failed records again
+	retryInterval time.Duration
+
+	deadLetterMutex sync.Mutex
+}
+
+// NewForwarder creates the targets of the config.
+func NewForwarder(config *Config, logger *log.Logger) (*Forwarder, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	f := &Forwarder{
+		config:        config,
+		targets:       make(map[string]Target),
+		logger:        logger,
+		retryInterval: config.interval(),
+	}
+	for name, targetConfig := range config.Targets {
+		target, err := NewTarget(targetConfig)
+		if err != nil {
+			return nil, fmt.Errorf("create target (%s) failed, err: %s", name, err)
+		}
+		f.targets[name] = target
+	}
+	return f, nil
+}
+
+// WithTarget adds or replaces the target, it is used by the rules by name.
+func (f *Forwarder) WithTarget(name string, target Target) *Forwarder {
+	f.targets[name] = target
+	return f
+}
+
+// Forward sends the event to the targets of the matched rules, or all targets if no rules.
+// Each target is sent at most once, the errors of all failed targets are returned.
+func (f *Forwarder) Forward(ctx context.Context, event *Event) error {
+	names := f.route(event)
+	failed := f.send(ctx, event, names)
+
+	errs := []string{}
+	for _, name := range names {
+		if err, ok := failed[name]; ok {
+			errs = append(errs, fmt.Sprintf("target (%s): %s", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("forward record (%#04x) of (%s) failed, err: %s", event.Record.RecordID, event.Host, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// route returns the names of the targets of the matched rules, or all targets if no rules.
+func (f *Forwarder) route(event *Event) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if len(f.config.Rules) == 0 {
+		for name := range f.targets {
+			add(name)
+		}
+	}
+	for _, rule := range f.config.Rules {
+		if rule.Match(event) {
+			for _, name := range rule.Targets {
+				add(name)
+			}
+		}
+	}
+	return names
+}
+
+// send sends the event to the named targets, it returns the errors of the failed targets by name.
+func (f *Forwarder) send(ctx context.Context, event *Event, names []string) map[string]error {
+	failed := make(map[string]error)
+	for _, name := range names {
+		target, ok := f.targets[name]
+		if !ok {
+			failed[name] = &PermanentError{Err: fmt.Errorf("unknown target (%s)", name)}
+			continue
+		}
+		if err := target.Send(ctx, event); err != nil {
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// deliver forwards the event until all the routed targets accepted it, only the failed targets are sent again.
+// A target is given up at once if the error is permanent, or after the delivery attempts, and the event
+// is dead-lettered for it. It returns false if ctx is done before the event is delivered or given up.
+func (f *Forwarder) deliver(ctx context.Context, event *Event) bool {
+	pending := f.route(event)
+	for attempt := 1; ; attempt++ {
+		failed := f.send(ctx, event, pending)
+
+		retries := []string{}
+		for _, name := range pending {
+			err, ok := failed[name]
+			if !ok {
+				continue
+			}
+			if ctx.Err() != nil {
+				return false
+			}
+			if IsPermanent(err) || attempt >= f.config.deliveryAttempts() {
+				f.deadLetter(name, event, err)
+				continue
+			}
+			retries = append(retries, name)
+		}
+		if len(retries) == 0 {
+			return true
+		}
+		pending = retries
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(f.retryInterval):
+		}
+	}
+}
+
+// deadLetter records the event which is given up for the target, it is appended to the dead letter file
+// if configured, and is always logged.
+func (f *Forwarder) deadLetter(name string, event *Event, err error) {
+	f.logf("give up forwarding record (%#04x) of (%s) to target (%s), err: %s", event.Record.RecordID, event.Host, name, err)
+	if f.config.DeadLetterFile == "" {
+		return
+	}
+
+	line, e := json.Marshal(&DeadLetter{Target: name, Error: err.Error(), Event: event})
+	if e != nil {
+		f.logf("marshal dead letter failed, err: %s", e)
+		return
+	}
+
+	f.deadLetterMutex.Lock()
+	defer f.deadLetterMutex.Unlock()
+
+	file, e := os.OpenFile(f.config.DeadLetterFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if e != nil {
+		f.logf("open dead letter file failed, err: %s", e)
+		return
+	}
+	defer file.Close()
+	if _, e := file.Write(append(line, '\n')); e != nil {
+		f.logf("write dead letter file failed, err: %s", e)
+	}
+}
+
+// Run follows all the BMCs until ctx is done, then closes the targets.
+// Each record is acked after it is delivered to all its targets or given up for the failed targets,
+// see Config.DeliveryAttempts and Config.DeadLetterFile.
+func (f *Forwarder) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, bmc := range f.config.BMCs {
+		wg.Add(1)
+		go func(bmc *BMC) {
+			defer wg.Done()
+			f.follow(ctx, bmc)
+		}(bmc)
+	}
+	wg.Wait()
+
+	for name, target := range f.targets {
+		if err := target.Close(); err != nil {
+			f.logf("close target (%s) failed, err: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// bmcFollow is the state of following a BMC, it is kept across the reconnections.
+type bmcFollow struct {
+	bmc   *BMC
+	store ipmi.SELCursorStore
+
+	// the SDRs and the OEM decoder are read once,
+	// they are read again after reconnecting if the reading failed
+	sdrsLoaded    bool
+	sdrsMap       ipmi.SDRMapBySensorNumber
+	decoderLoaded bool
+	decoder       ipmi.SELOEMDecoder
+}
+
+// follow follows the SEL of the BMC, it reconnects the BMC after the interval if it failed.
+// The cursor store is kept across the reconnections, so no records are missed.
+func (f *Forwarder) follow(ctx context.Context, bmc *BMC) {
+	state := &bmcFollow{bmc: bmc, store: &ipmi.MemorySELCursorStore{}}
+	if f.config.CursorDir != "" {
+		state.store = ipmi.NewFileSELCursorStore(filepath.Join(f.config.CursorDir, bmc.cursorFileName()))
+	}
+
+	for {
+		if err := f.followOnce(ctx, state); err != nil {
+			f.logf("follow bmc (%s) failed, err: %s", bmc.Host, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.config.interval()):
+		}
+	}
+}
+
+// followOnce follows the SEL of the BMC by a new session, until ctx is done or the SEL poll failed.
+func (f *Forwarder) followOnce(ctx context.Context, state *bmcFollow) error {
+	bmc := state.bmc
+	client, err := bmc.NewClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if !state.sdrsLoaded {
+		sdrsMap, err := client.GetSDRsMap()
+		if err != nil {
+			f.logf("GetSDRsMap of bmc (%s) failed, the sensor names are not forwarded, err: %s", bmc.Host, err)
+		} else {
+			state.sdrsMap, state.sdrsLoaded = sdrsMap, true
+		}
+	}
+	if !state.decoderLoaded {
+		decoder, err := client.GetSELOEMDecoder()
+		if err != nil {
+			f.logf("GetSELOEMDecoder of bmc (%s) failed, OEM SEL records are not decoded, err: %s", bmc.Host, err)
+		} else {
+			state.decoder, state.decoderLoaded = decoder, true
+		}
+	}
+
+	followCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var pollErr error
+	follower := client.NewSELFollower(f.config.interval(), state.store).
+		WithErrorHandler(func(err error) {
+			// the session may be broken, stop and reconnect
+			pollErr = err
+			cancel()
+		})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- follower.Run(followCtx)
+	}()
+
+	for sel := range follower.Records() {
+		var sdr *ipmi.SDR
+		if sel.Standard != nil {
+			sdr = state.sdrsMap[sel.Standard.GeneratorID][sel.Standard.SensorNumber]
+		}
+		event := &Event{
+			Host:   bmc.Host,
+			Record: ipmi.NewSELOutput(sel, sdr, state.decoder),
+		}
+		if !f.deliver(followCtx, event) {
+			// not acked, the record is delivered again after the reconnection
+			continue
+		}
+		follower.Ack(sel)
+	}
+
+	if err := <-errCh; err != nil {
+		return err
+	}
+	return pollErr
+}
+
+func (f *Forwarder) logf(format string, v ...interface{}) {
+	if f.logger != nil {
+		f.logger.Printf(format, v...)
+	}
+}
+
+// cursorFileName returns the file name of the SEL cursor of the BMC,
+// which is unique for the interface, host and port of the BMC.
+func (bmc *BMC) cursorFileName() string {
+	var name string
+	switch ipmi.Interface(bmc.Interface) {
+	case "", ipmi.InterfaceOpen:
+		name = bmc.Host
+		if name == "" {
+			name = "localhost"
+		}
+		name = string(ipmi.InterfaceOpen) + "_" + name
+
+	default:
+		host, port := bmc.Host, strconv.Itoa(bmc.Port)
+		if bmc.Port == 0 {
+			port = "623"
+		}
+		if h, p, err := net.SplitHostPort(bmc.Host); err == nil {
+			host, port = h, p
+		}
+		name = bmc.Interface + "_" + net.JoinHostPort(host, port)
+	}
+
+	name = strings.NewReplacer("/", "_", ":", "_", "[", "", "]", "", "%", "_").Replace(name)
+	return name + ".cursor.json"
+}
+
+// NewClient creates and connects a client to the BMC.
+func (bmc *BMC) NewClient() (*ipmi.Client, error) {
+	return ipmi.NewClientForTarget(&ipmi.ClientTarget{
+		Interface:   ipmi.Interface(bmc.Interface),
+		Host:        bmc.Host,
+		Port:        bmc.Port,
+		Username:    bmc.User,
+		Password:    bmc.Pass,
+		Timeout:     time.Duration(bmc.TimeoutSeconds) * time.Second,
+		SDRCacheDir: bmc.SDRCacheDir,
+	})
+}
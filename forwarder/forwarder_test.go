@@ -0,0 +1,408 @@
+package forwarder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+func newTestEvent(t *testing.T, msg []byte, sensorName string) *Event {
+	sel, err := ipmi.ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+
+	var sdr *ipmi.SDR
+	if sensorName != "" {
+		sdr = &ipmi.SDR{
+			RecordHeader: &ipmi.SDRHeader{RecordType: ipmi.SDRRecordTypeCompactSensor},
+			Compact:      &ipmi.SDRCompact{IDStringBytes: []byte(sensorName)},
+		}
+	}
+	return &Event{
+		Host:   "bmc-01",
+		Record: ipmi.NewSELOutput(sel, sdr, nil),
+	}
+}
+
+var (
+	// Power Supply, Power Supply Failure detected
+	testPSUFailure = []byte{0x01, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x08, 0x51, 0x6f, 0x01, 0xff, 0xff}
+	// Power Supply, Presence detected
+	testPSUPresence = []byte{0x02, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x08, 0x52, 0x6f, 0x00, 0xff, 0xff}
+	// Temperature, Upper Critical going high
+	testTemperature = []byte{0x03, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x01, 0x30, 0x01, 0x59, 0x5f, 0x5a}
+	// timestamped OEM record
+	testOEM = []byte{0x04, 0x00, 0xc1, 0x00, 0xe1, 0xf5, 0x65, 0x00, 0xff, 0x0f, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+)
+
+func Test_Rule_Match(t *testing.T) {
+	psuFailure := newTestEvent(t, testPSUFailure, "PSU1 Status")
+	psuPresence := newTestEvent(t, testPSUPresence, "PSU2 Status")
+	temperature := newTestEvent(t, testTemperature, "CPU Temp")
+	oem := newTestEvent(t, testOEM, "")
+
+	tests := []struct {
+		name     string
+		rule     *Rule
+		event    *Event
+		expected bool
+	}{
+		{"empty rule", &Rule{}, oem, true},
+		{"severity matched", &Rule{Severities: []ipmi.EventSeverity{"critical"}}, psuFailure, true},
+		{"severity not matched", &Rule{Severities: []ipmi.EventSeverity{ipmi.EventSeverityCritical}}, psuPresence, false},
+		{"severity of oem", &Rule{Severities: []ipmi.EventSeverity{ipmi.EventSeverityInfo}}, oem, false},
+		{"sensor type matched", &Rule{SensorTypes: []string{"power supply"}}, psuPresence, true},
+		{"sensor type not matched", &Rule{SensorTypes: []string{"Power Supply"}}, temperature, false},
+		{"pattern sensor name", &Rule{Pattern: `^Power Supply PSU\d `}, psuFailure, true},
+		{"pattern event", &Rule{Pattern: `going high`}, temperature, true},
+		{"pattern oem", &Rule{Pattern: `^OEM record c1`}, oem, true},
+		{"all conditions", &Rule{Severities: []ipmi.EventSeverity{ipmi.EventSeverityCritical}, Pattern: `PSU2`}, psuFailure, false},
+	}
+
+	for _, test := range tests {
+		if err := test.rule.compile(); err != nil {
+			t.Fatalf("test %s compile failed, err: %s", test.name, err)
+		}
+		if got := test.rule.Match(test.event); got != test.expected {
+			t.Errorf("test %s not matched, got: %v, expected: %v, text: %q", test.name, got, test.expected, test.event.Text())
+		}
+	}
+}
+
+// recordTarget records the events sent to it.
+type recordTarget struct {
+	mutex  sync.Mutex
+	events []*Event
+}
+
+func (t *recordTarget) Send(ctx context.Context, event *Event) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.events = append(t.events, event)
+	return nil
+}
+
+func (t *recordTarget) Close() error {
+	return nil
+}
+
+func Test_Forwarder_Forward(t *testing.T) {
+	config := &Config{
+		BMCs: []*BMC{{}},
+		Targets: map[string]*TargetConfig{
+			"alerts": {Type: TargetTypeWebhook, URL: "http://127.0.0.1"},
+			"syslog": {Type: TargetTypeSyslog, Network: "udp", Address: "127.0.0.1:514"},
+		},
+		Rules: []*Rule{
+			{Severities: []ipmi.EventSeverity{ipmi.EventSeverityCritical}, Targets: []string{"alerts", "syslog"}},
+			{Targets: []string{"syslog"}},
+		},
+	}
+	f, err := NewForwarder(config, nil)
+	if err != nil {
+		t.Fatalf("test NewForwarder failed, err: %s", err)
+	}
+	alerts, syslog := &recordTarget{}, &recordTarget{}
+	f.WithTarget("alerts", alerts).WithTarget("syslog", syslog)
+
+	for _, msg := range [][]byte{testPSUFailure, testPSUPresence} {
+		if err := f.Forward(context.Background(), newTestEvent(t, msg, "")); err != nil {
+			t.Errorf("test Forward failed, err: %s", err)
+		}
+	}
+
+	if len(alerts.events) != 1 || alerts.events[0].Record.RecordID != 1 {
+		t.Errorf("test alerts target not matched, got %d events", len(alerts.events))
+	}
+	// sent once even if matched by both rules
+	if len(syslog.events) != 2 {
+		t.Errorf("test syslog target not matched, got %d events", len(syslog.events))
+	}
+}
+
+// failTarget fails the first n events, or all events with the permanent error.
+type failTarget struct {
+	recordTarget
+	failures  int
+	permanent bool
+	sends     int
+}
+
+func (t *failTarget) Send(ctx context.Context, event *Event) error {
+	t.sends++
+	if t.permanent {
+		return &PermanentError{Err: fmt.Errorf("response status 400 Bad Request")}
+	}
+	if t.failures > 0 {
+		t.failures--
+		return fmt.Errorf("connection refused")
+	}
+	return t.recordTarget.Send(ctx, event)
+}
+
+func Test_Forwarder_deliver(t *testing.T) {
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+	config := &Config{
+		BMCs: []*BMC{{}},
+		Targets: map[string]*TargetConfig{
+			"ok":    {Type: TargetTypeSyslog, Network: "udp", Address: "127.0.0.1:514"},
+			"flaky": {Type: TargetTypeSyslog, Network: "udp", Address: "127.0.0.1:514"},
+			"down":  {Type: TargetTypeSyslog, Network: "udp", Address: "127.0.0.1:514"},
+			"bad":   {Type: TargetTypeWebhook, URL: "http://127.0.0.1"},
+		},
+		DeliveryAttempts: 3,
+		DeadLetterFile:   deadLetterFile,
+	}
+	f, err := NewForwarder(config, nil)
+	if err != nil {
+		t.Fatalf("test NewForwarder failed, err: %s", err)
+	}
+	f.retryInterval = time.Millisecond
+
+	ok := &failTarget{}
+	flaky := &failTarget{failures: 2}
+	down := &failTarget{failures: 100}
+	bad := &failTarget{permanent: true}
+	f.WithTarget("ok", ok).WithTarget("flaky", flaky).WithTarget("down", down).WithTarget("bad", bad)
+
+	if !f.deliver(context.Background(), newTestEvent(t, testPSUFailure, "")) {
+		t.Fatalf("test deliver should give up the failed targets")
+	}
+
+	// only the failed targets are sent again
+	if ok.sends != 1 || len(ok.events) != 1 {
+		t.Errorf("test ok target not matched, sends: %d, events: %d", ok.sends, len(ok.events))
+	}
+	if flaky.sends != 3 || len(flaky.events) != 1 {
+		t.Errorf("test flaky target not matched, sends: %d, events: %d", flaky.sends, len(flaky.events))
+	}
+	if down.sends != 3 {
+		t.Errorf("test down target not matched, sends: %d, expected: 3", down.sends)
+	}
+	// the permanent error is not retried
+	if bad.sends != 1 {
+		t.Errorf("test bad target not matched, sends: %d, expected: 1", bad.sends)
+	}
+
+	b, err := os.ReadFile(deadLetterFile)
+	if err != nil {
+		t.Fatalf("test read dead letter file failed, err: %s", err)
+	}
+	targets := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		deadLetter := &DeadLetter{}
+		if err := json.Unmarshal([]byte(line), deadLetter); err != nil {
+			t.Fatalf("test unmarshal dead letter failed, err: %s", err)
+		}
+		if deadLetter.Host != "bmc-01" || deadLetter.Record.RecordID != 1 {
+			t.Errorf("test dead letter not matched, got: %s", line)
+		}
+		targets = append(targets, deadLetter.Target)
+	}
+	sort.Strings(targets)
+	if !reflect.DeepEqual(targets, []string{"bad", "down"}) {
+		t.Errorf("test dead letter targets not matched, got: %v", targets)
+	}
+}
+
+func Test_BMC_cursorFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		bmc      *BMC
+		expected string
+	}{
+		{"open", &BMC{}, "open_localhost.cursor.json"},
+		{"open with host", &BMC{Interface: "open", Host: "node1"}, "open_node1.cursor.json"},
+		{"lan default port", &BMC{Interface: "lanplus", Host: "10.0.0.1"}, "lanplus_10.0.0.1_623.cursor.json"},
+		{"lan port", &BMC{Interface: "lanplus", Host: "10.0.0.1", Port: 6230}, "lanplus_10.0.0.1_6230.cursor.json"},
+		{"lan host port", &BMC{Interface: "lan", Host: "10.0.0.1:6231"}, "lan_10.0.0.1_6231.cursor.json"},
+		{"ipv6", &BMC{Interface: "lanplus", Host: "fe80::1%eth0", Port: 623}, "lanplus_fe80__1_eth0_623.cursor.json"},
+		{"ipv6 host port", &BMC{Interface: "lanplus", Host: "[fe80::1]:6230"}, "lanplus_fe80__1_6230.cursor.json"},
+	}
+
+	for _, test := range tests {
+		if got := test.bmc.cursorFileName(); got != test.expected {
+			t.Errorf("test %s not matched, got: %s, expected: %s", test.name, got, test.expected)
+		}
+	}
+}
+
+func Test_SyslogTarget(t *testing.T) {
+	event := newTestEvent(t, testPSUFailure, "PSU1 Status")
+	expected := "<162>1 "
+	expectedSuffix := ` Power Supply Failure detected Asserted`
+
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen failed, err: %s", err)
+		}
+		defer conn.Close()
+
+		target := NewSyslogTarget("udp", conn.LocalAddr().String(), ipmi.SyslogFacilityLocal4)
+		defer target.Close()
+		if err := target.Send(context.Background(), event); err != nil {
+			t.Fatalf("Send failed, err: %s", err)
+		}
+
+		buf := make([]byte, 2048)
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("read failed, err: %s", err)
+		}
+		got := string(buf[:n])
+		if !strings.HasPrefix(got, expected) || !strings.HasSuffix(got, expectedSuffix) || !strings.Contains(got, " bmc-01 goipmi - SEL ") {
+			t.Errorf("message not matched, got: %s", got)
+		}
+	})
+
+	for _, network := range []string{"tcp", "unix"} {
+		network := network
+		t.Run(network, func(t *testing.T) {
+			address := "127.0.0.1:0"
+			if network == "unix" {
+				address = filepath.Join(t.TempDir(), "syslog.sock")
+			}
+			l, err := net.Listen(network, address)
+			if err != nil {
+				t.Fatalf("listen failed, err: %s", err)
+			}
+			defer l.Close()
+
+			received := make(chan []string, 1)
+			go func() {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+				// octet counting frames
+				r := bufio.NewReader(conn)
+				messages := []string{}
+				for len(messages) < 2 {
+					length, err := r.ReadString(' ')
+					if err != nil {
+						break
+					}
+					n, _ := strconv.Atoi(strings.TrimSpace(length))
+					msg := make([]byte, n)
+					if _, err := io.ReadFull(r, msg); err != nil {
+						break
+					}
+					messages = append(messages, string(msg))
+				}
+				received <- messages
+			}()
+
+			target := NewSyslogTarget(network, l.Addr().String(), ipmi.SyslogFacilityLocal4)
+			defer target.Close()
+			for i := 0; i < 2; i++ {
+				if err := target.Send(context.Background(), event); err != nil {
+					t.Fatalf("Send failed, err: %s", err)
+				}
+			}
+
+			messages := <-received
+			if len(messages) != 2 {
+				t.Fatalf("messages not matched, got: %q", messages)
+			}
+			for _, got := range messages {
+				if !strings.HasPrefix(got, expected) || !strings.HasSuffix(got, expectedSuffix) {
+					t.Errorf("message not matched, got: %s", got)
+				}
+			}
+		})
+	}
+}
+
+func Test_WebhookTarget(t *testing.T) {
+	var mutex sync.Mutex
+	var requests int
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+
+		switch r.URL.Path {
+		case "/flaky":
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	event := newTestEvent(t, testPSUFailure, "PSU1 Status")
+
+	tests := []struct {
+		name       string
+		path       string
+		maxRetries int
+		requests   int
+		ok         bool
+	}{
+		{"retried until succeeded", "/flaky", 3, 3, true},
+		{"retries exhausted", "/flaky", 1, 2, false},
+		{"client error not retried", "/bad", 3, 1, false},
+	}
+
+	permanent := map[string]bool{"/bad": true}
+
+	for _, test := range tests {
+		requests = 0
+		body = nil
+
+		target := NewWebhookTarget(server.URL + test.path)
+		target.Headers = map[string]string{"Authorization": "Bearer token"}
+		target.MaxRetries = test.maxRetries
+		target.RetryInterval = time.Millisecond
+
+		err := target.Send(context.Background(), event)
+		if (err == nil) != test.ok || requests != test.requests {
+			t.Errorf("test %s not matched, got err: %v, requests: %d, expected requests: %d", test.name, err, requests, test.requests)
+		}
+		if err != nil && IsPermanent(err) != permanent[test.path] {
+			t.Errorf("test %s permanent error not matched, got: %v", test.name, IsPermanent(err))
+		}
+
+		if test.ok {
+			sel, _ := body["sel"].(map[string]interface{})
+			standard, _ := sel["standard"].(map[string]interface{})
+			if body["host"] != "bmc-01" || standard["sensor_name"] != "PSU1 Status" || standard["event_severity"] != "Critical" {
+				t.Errorf("test %s body not matched, got: %v", test.name, body)
+			}
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// Target is the destination of the forwarded records.
+type Target interface {
+	// Send sends the event, it returns error if the event can not be delivered,
+	// and PermanentError if sending the event again would not succeed.
+	Send(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// PermanentError is returned by Target.Send if the event is rejected by the target, sending it again would not succeed.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether the error of Target.Send is a PermanentError.
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+	return errors.As(err, &permanentErr)
+}
+
+// NewTarget creates the target by the config.
+func NewTarget(config *TargetConfig) (Target, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	switch config.Type {
+	case TargetTypeSyslog:
+		facility := ipmi.SyslogFacilityLocal0
+		if config.Facility != "" {
+			facility = syslogFacilities[config.Facility]
+		}
+		return NewSyslogTarget(config.Network, config.Address, facility), nil
+
+	case TargetTypeWebhook:
+		target := NewWebhookTarget(config.URL)
+		target.Headers = config.Headers
+		if config.MaxRetries != nil {
+			target.MaxRetries = *config.MaxRetries
+		}
+		if config.RetryIntervalSeconds > 0 {
+			target.RetryInterval = time.Duration(config.RetryIntervalSeconds) * time.Second
+		}
+		if config.TimeoutSeconds > 0 {
+			target.Client.Timeout = time.Duration(config.TimeoutSeconds) * time.Second
+		}
+		return target, nil
+	}
+
+	return nil, fmt.Errorf("not supported target type (%s)", config.Type)
+}
+
+var syslogFacilities = map[string]ipmi.SyslogFacility{
+	"kern":   ipmi.SyslogFacilityKern,
+	"user":   ipmi.SyslogFacilityUser,
+	"daemon": ipmi.SyslogFacilityDaemon,
+	"auth":   ipmi.SyslogFacilityAuth,
+	"local0": ipmi.SyslogFacilityLocal0,
+	"local1": ipmi.SyslogFacilityLocal1,
+	"local2": ipmi.SyslogFacilityLocal2,
+	"local3": ipmi.SyslogFacilityLocal3,
+	"local4": ipmi.SyslogFacilityLocal4,
+	"local5": ipmi.SyslogFacilityLocal5,
+	"local6": ipmi.SyslogFacilityLocal6,
+	"local7": ipmi.SyslogFacilityLocal7,
+}
+
+// SyslogTarget sends the records as RFC 5424 syslog messages.
+//
+// Each message is a datagram for the udp and unixgram networks, and is framed by
+// octet counting (RFC 6587) for the tcp and unix stream networks.
+// The connection is dialed on the first message, and redialed once if the write failed.
+type SyslogTarget struct {
+	Network  string
+	Address  string
+	Facility ipmi.SyslogFacility
+	AppName  string
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func NewSyslogTarget(network string, address string, facility ipmi.SyslogFacility) *SyslogTarget {
+	return &SyslogTarget{
+		Network:  network,
+		Address:  address,
+		Facility: facility,
+		AppName:  "goipmi",
+	}
+}
+
+func (t *SyslogTarget) Send(ctx context.Context, event *Event) error {
+	msg := event.Record.SyslogMessage()
+	msg.Facility = t.Facility
+	msg.Hostname = event.Host
+	msg.AppName = t.AppName
+
+	data := []byte(msg.String())
+	if t.Network == "tcp" || t.Network == "unix" {
+		data = append([]byte(fmt.Sprintf("%d ", len(data))), data...)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var err error
+	for i := 0; i < 2; i++ {
+		if t.conn == nil {
+			dialer := &net.Dialer{}
+			t.conn, err = dialer.DialContext(ctx, t.Network, t.Address)
+			if err != nil {
+				t.conn = nil
+				return fmt.Errorf("dial syslog (%s %s) failed, err: %s", t.Network, t.Address, err)
+			}
+		}
+
+		if _, err = t.conn.Write(data); err == nil {
+			return nil
+		}
+		t.conn.Close()
+		t.conn = nil
+	}
+	return fmt.Errorf("write syslog (%s %s) failed, err: %s", t.Network, t.Address, err)
+}
+
+func (t *SyslogTarget) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+// WebhookTarget posts the records as JSON to the URL, the body is the JSON of Event.
+//
+// The request is retried with exponential backoff if it failed or the response status is 429 or 5xx,
+// the other failures like the 4xx responses are returned as PermanentError.
+type WebhookTarget struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+
+	MaxRetries    int
+	RetryInterval time.Duration
+}
+
+func NewWebhookTarget(url string) *WebhookTarget {
+	return &WebhookTarget{
+		URL:           url,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+		MaxRetries:    3,
+		RetryInterval: time.Second,
+	}
+}
+
+func (t *WebhookTarget) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event failed, err: %s", err)
+	}
+
+	var retryable bool
+	interval := t.RetryInterval
+	for retry := 0; ; retry++ {
+		retryable, err = t.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || retry >= t.MaxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("post webhook (%s) canceled, err: %s", t.URL, err)
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+
+	err = fmt.Errorf("post webhook (%s) failed, err: %s", t.URL, err)
+	if !retryable && ctx.Err() == nil {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
+
+// post posts the body once, the retryable reports whether the failed request should be retried.
+func (t *WebhookTarget) post(ctx context.Context, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := t.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("response status %s", res.Status)
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500, err
+}
+
+func (t *WebhookTarget) Close() error {
+	t.Client.CloseIdleConnections()
+	return nil
+}
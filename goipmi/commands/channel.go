@@ -47,7 +47,6 @@ func NewCmdChannelInfo() *cobra.Command {
 					CheckErr(fmt.Errorf("GetChannelInfo failed, err: %s", err))
 				}
 			}
-			fmt.Println(res.Format())
 
 			res2, err := client.GetChannelAccess(channelNumber, ipmi.ChannelAccessOption_Volatile)
 			if err != nil {
@@ -55,8 +54,6 @@ func NewCmdChannelInfo() *cobra.Command {
 					CheckErr(fmt.Errorf("GetChannelAccess failed, err: %s", err))
 				}
 			}
-			fmt.Println("  Volatile(active) Settings")
-			fmt.Println(res2.Format())
 
 			res3, err := client.GetChannelAccess(channelNumber, ipmi.ChannelAccessOption_NonVolatile)
 			if err != nil {
@@ -64,8 +61,13 @@ func NewCmdChannelInfo() *cobra.Command {
 					CheckErr(fmt.Errorf("GetChannelAccess failed, err: %s", err))
 				}
 			}
-			fmt.Println("  Non-Volatile Settings")
-			fmt.Println(res3.Format())
+
+			table := fmt.Sprintf("%s\n  Volatile(active) Settings\n%s\n  Non-Volatile Settings\n%s", res.Format(), res2.Format(), res3.Format())
+			printOutput(table, struct {
+				Info              *ipmi.GetChannelInfoResponse
+				VolatileAccess    *ipmi.GetChannelAccessResponse
+				NonVolatileAccess *ipmi.GetChannelAccessResponse
+			}{res, res2, res3})
 		},
 	}
 	return cmd
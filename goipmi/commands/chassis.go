@@ -43,7 +43,7 @@ func NewCmdChassisStatus() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetChassisStatus failed, err: %s", err))
 			}
-			fmt.Println(status.Format())
+			printOutput(status.Format(), status)
 		},
 	}
 	return cmd
@@ -117,7 +117,9 @@ func NewCmdChassisPower() *cobra.Command {
 					if status.PowerIsOn {
 						powerStatus = "on"
 					}
-					fmt.Printf("Chassis Power is %s\n", powerStatus)
+					printOutput(fmt.Sprintf("Chassis Power is %s", powerStatus), struct {
+						PowerStatus string
+					}{powerStatus})
 					return
 				case "on":
 					c = ipmi.ChassisControlPowerUp
@@ -166,7 +168,7 @@ func NewCmdChassisCapabilities() *cobra.Command {
 						CheckErr(fmt.Errorf("GetChassisCapabilities failed, err: %s", err))
 						return
 					}
-					fmt.Println(cap.Format())
+					printOutput(cap.Format(), cap)
 					return
 				case "set":
 				}
@@ -185,7 +187,7 @@ func NewCmdChassisRestartCause() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSystemRestartCause failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
@@ -242,7 +244,7 @@ bootparam set bootflag <device> [options=...]
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSystemBootOptions failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			}
 
 		},
@@ -259,7 +261,7 @@ func NewCmdChassisPoh() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSystemRestartCause failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
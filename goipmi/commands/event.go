@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+	"github.com/spf13/cobra"
+)
+
+// testEvents are the predefined test events of ipmitool event <1|2|3>.
+var testEvents = map[string]*ipmi.EventBuilder{
+	"1": ipmi.NewEventBuilder("ucr+").WithSensorNumber(0x30).WithSensorType(ipmi.SensorTypeTemperature).WithEventReadingType(ipmi.EventReadingTypeThreshold),
+	"2": ipmi.NewEventBuilder("lcr-").WithSensorNumber(0x60).WithSensorType(ipmi.SensorTypeVoltage).WithEventReadingType(ipmi.EventReadingTypeThreshold),
+	"3": ipmi.NewEventBuilder("state0").WithSensorNumber(0x53).WithSensorType(ipmi.SensorTypeMemory).WithEventReadingType(ipmi.EventReadingTypeSensorSpecific),
+}
+
+func NewCmdEvent() *cobra.Command {
+	var sensorType string
+	var addSEL bool
+	var triggerReading uint8
+	var triggerThreshold uint8
+
+	usage := `event <1|2|3>
+event <sensorNumber|sensorName> <state> [assert|deassert]
+
+Send a Platform Event Message, the event is logged to SEL and processed by PEF as if it was generated by the sensor.
+
+  1  Temperature - Upper Critical - Going High
+  2  Voltage Threshold - Lower Critical - Going Low
+  3  Memory - Correctable ECC
+
+The state is the event name, like "Upper Critical going high", "Presence detected", or the threshold
+abbreviation like ucr, lnc-, or state<N> for discrete sensors. The sensor is resolved by the SDR,
+specify --sensor-type for the sensor number without SDR.`
+
+	cmd := &cobra.Command{
+		Use:   "event",
+		Short: "send platform events",
+		Long:  usage,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return initClient()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			var builder *ipmi.EventBuilder
+
+			switch len(args) {
+			case 1:
+				b, ok := testEvents[args[0]]
+				if !ok {
+					CheckErr(fmt.Errorf("unknown test event (%s), usage: %s", args[0], usage))
+				}
+				builder = b
+
+			case 2, 3:
+				dir := ""
+				if len(args) == 3 {
+					dir = args[2]
+				}
+				b, err := newEventBuilder(args[0], args[1], dir)
+				if err != nil {
+					CheckErr(fmt.Errorf("%s, usage: %s", err, usage))
+				}
+				builder = b
+
+			default:
+				CheckErr(fmt.Errorf("usage: %s", usage))
+			}
+
+			if sensorType != "" {
+				typ, err := ipmi.ParseSensorType(sensorType)
+				if err != nil {
+					CheckErr(err)
+				}
+				builder.WithSensorType(typ)
+			}
+			if cmd.Flags().Changed("trigger-reading") || cmd.Flags().Changed("trigger-threshold") {
+				builder.WithTriggerValues(triggerReading, triggerThreshold)
+			}
+
+			if addSEL {
+				res, err := client.AddSELEvent(builder)
+				if err != nil {
+					CheckErr(fmt.Errorf("AddSELEvent failed, err: %s", err))
+				}
+				printOutput(res.Format(), res)
+				return
+			}
+
+			if _, err := client.SendEvent(builder); err != nil {
+				CheckErr(fmt.Errorf("SendEvent failed, err: %s", err))
+			}
+			printStatus("Event sent")
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return closeClient()
+		},
+	}
+
+	cmd.Flags().StringVarP(&sensorType, "sensor-type", "", "", "sensor type name or number, required for the sensor number without SDR")
+	cmd.Flags().BoolVarP(&addSEL, "sel", "", false, "add the event to SEL by Add SEL Entry, instead of sending Platform Event Message")
+	cmd.Flags().Uint8VarP(&triggerReading, "trigger-reading", "", 0, "raw trigger reading, or event data 2 for discrete sensors")
+	cmd.Flags().Uint8VarP(&triggerThreshold, "trigger-threshold", "", 0, "raw trigger threshold, or event data 3 for discrete sensors")
+
+	return cmd
+}
+
+// newEventBuilder creates the event builder of the sensor (number or name), the state and the direction.
+func newEventBuilder(sensor string, state string, dir string) (*ipmi.EventBuilder, error) {
+	builder := ipmi.NewEventBuilder(state)
+
+	if id, err := parseStringToInt64(sensor); err == nil {
+		builder.WithSensorNumber(ipmi.SensorNumber(id))
+	} else {
+		builder.WithSensorName(sensor)
+	}
+
+	switch strings.ToLower(dir) {
+	case "", "assert":
+		builder.WithDirection(ipmi.EventDirAssertion)
+	case "deassert":
+		builder.WithDirection(ipmi.EventDirDeassertion)
+	default:
+		return nil, fmt.Errorf("invalid event direction (%s), should be assert or deassert", dir)
+	}
+
+	return builder, nil
+}
+
+// readSELFile reads the SEL records and events from the file, each line is one of:
+//
+//	7 hex bytes, the event message like ipmitool event file:
+//	    <EvMRev> <sensor type> <sensor number> <event dir|type> <event data 1> <event data 2> <event data 3>
+//	16 hex bytes, the raw SEL record
+//	<sensorNumber|sensorName> | <state> [| assert|deassert]
+//
+// The empty lines and the lines starting with # are ignored.
+func readSELFile(file string) ([]interface{}, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open file failed, err: %s", err)
+	}
+	defer f.Close()
+
+	out := make([]interface{}, 0)
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.Contains(line, "|") {
+			fields := strings.Split(line, "|")
+			if len(fields) < 2 || len(fields) > 3 {
+				return nil, fmt.Errorf("invalid line %d, should be <sensor> | <state> [| assert|deassert]", lineNo)
+			}
+			dir := ""
+			if len(fields) == 3 {
+				dir = strings.TrimSpace(fields[2])
+			}
+			builder, err := newEventBuilder(strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), dir)
+			if err != nil {
+				return nil, fmt.Errorf("invalid line %d, err: %s", lineNo, err)
+			}
+			out = append(out, builder)
+			continue
+		}
+
+		data := make([]byte, 0)
+		for _, field := range strings.Fields(line) {
+			b, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(field), "0x"), 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid line %d, invalid hex byte (%s)", lineNo, field)
+			}
+			data = append(data, uint8(b))
+		}
+
+		switch len(data) {
+		case 7:
+			out = append(out, &ipmi.SEL{
+				RecordType: 0x02,
+				Standard: &ipmi.SELStandard{
+					Timestamp:        time.Now(),
+					GeneratorID:      ipmi.GeneratorBMC,
+					EvMRev:           data[0],
+					SensorType:       ipmi.SensorType(data[1]),
+					SensorNumber:     ipmi.SensorNumber(data[2]),
+					EventDir:         ipmi.EventDir(data[3]&0x80 != 0),
+					EventReadingType: ipmi.EventReadingType(data[3] & 0x7f),
+					EventData:        ipmi.EventData{EventData1: data[4], EventData2: data[5], EventData3: data[6]},
+				},
+			})
+		case 16:
+			sel, err := ipmi.ParseSEL(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid line %d, err: %s", lineNo, err)
+			}
+			out = append(out, sel)
+		default:
+			return nil, fmt.Errorf("invalid line %d, should be 7 or 16 hex bytes, got %d", lineNo, len(data))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read file failed, err: %s", err)
+	}
+	return out, nil
+}
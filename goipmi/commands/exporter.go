@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/bougou/go-ipmi/collector"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdExporter() *cobra.Command {
+	var listen string
+	var configFile string
+
+	usage := `exporter [--listen <addr>] [--config <file>]
+
+Serve IPMI metrics in the Prometheus text exposition format.
+
+  /metrics                                  metrics of the local BMC or the host specified by the global flags
+  /ipmi?target=<host[:port]>&module=<name>  metrics of the target, by the credentials of the module
+
+Without --config, the "default" module is built from the global flags (--interface, --host, --user, --pass, --port).`
+
+	cmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "run as a Prometheus exporter",
+		Long:  usage,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := collector.DefaultConfig()
+			if configFile != "" {
+				c, err := collector.LoadConfig(configFile)
+				if err != nil {
+					CheckErr(fmt.Errorf("load config failed, err: %s", err))
+				}
+				config = c
+			} else {
+				module := config.Modules[collector.DefaultModuleName]
+				module.Interface = intf
+				module.Target = host
+				module.User = username
+				module.Pass = password
+				module.Port = port
+				if !noSDRCache {
+					module.SDRCacheDir = sdrCacheDir
+				}
+			}
+
+			logger := log.New(os.Stderr, "", log.LstdFlags)
+			exporter := collector.NewExporter(config, logger)
+
+			logger.Printf("listening on %s", listen)
+			if err := http.ListenAndServe(listen, exporter.Handler()); err != nil {
+				CheckErr(fmt.Errorf("exporter failed, err: %s", err))
+			}
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&listen, "listen", "l", ":9290", "address to listen on")
+	cmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "JSON config file of modules")
+
+	return cmd
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+	"github.com/bougou/go-ipmi/forwarder"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdForward() *cobra.Command {
+	var configFile string
+	var syslogURLs []string
+	var webhookURLs []string
+	var severities []string
+	var cursorDir string
+	var interval time.Duration
+
+	usage := `forward [--config <file>] [--syslog <url>] [--webhook <url>] [--severity <severity>]
+
+Follow the SEL of the BMCs and forward the new records to syslog or webhooks.
+
+Without --config, the BMC is the local BMC or the host specified by the global flags,
+and the targets are specified by the flags:
+
+  --syslog  udp://host:514, tcp://host:601, unix:///dev/log or unixgram:///dev/log, can be repeated
+  --webhook http(s) URL, the records are posted as JSON, can be repeated
+  --severity only forward the records of the severities, like Critical, can be repeated`
+
+	cmd := &cobra.Command{
+		Use:   "forward",
+		Short: "forward SEL records to syslog or webhooks",
+		Long:  usage,
+		Run: func(cmd *cobra.Command, args []string) {
+			var config *forwarder.Config
+			if configFile != "" {
+				c, err := forwarder.LoadConfig(configFile)
+				if err != nil {
+					CheckErr(fmt.Errorf("load config failed, err: %s", err))
+				}
+				config = c
+			} else {
+				c, err := forwardConfigFromFlags(syslogURLs, webhookURLs, severities)
+				if err != nil {
+					CheckErr(fmt.Errorf("%s, usage: %s", err, usage))
+				}
+				c.CursorDir = cursorDir
+				c.IntervalSeconds = int(interval.Seconds())
+				config = c
+			}
+
+			logger := log.New(os.Stderr, "", log.LstdFlags)
+			f, err := forwarder.NewForwarder(config, logger)
+			if err != nil {
+				CheckErr(fmt.Errorf("create forwarder failed, err: %s", err))
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			logger.Printf("forwarding SEL of %d bmcs to %d targets", len(config.BMCs), len(config.Targets))
+			if err := f.Run(ctx); err != nil {
+				CheckErr(fmt.Errorf("forwarder failed, err: %s", err))
+			}
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "JSON config file of bmcs, targets and rules")
+	cmd.PersistentFlags().StringArrayVarP(&syslogURLs, "syslog", "", nil, "syslog target, like udp://127.0.0.1:514")
+	cmd.PersistentFlags().StringArrayVarP(&webhookURLs, "webhook", "", nil, "webhook target URL")
+	cmd.PersistentFlags().StringArrayVarP(&severities, "severity", "", nil, "only forward the records of the severity")
+	cmd.PersistentFlags().StringVarP(&cursorDir, "cursor-dir", "", "", "directory to save the SEL cursors, the forward resumes from the saved cursors")
+	cmd.PersistentFlags().DurationVarP(&interval, "interval", "i", ipmi.DefaultSELFollowInterval, "poll interval")
+
+	return cmd
+}
+
+// forwardConfigFromFlags builds the forwarder config of the BMC specified by the global flags.
+func forwardConfigFromFlags(syslogURLs []string, webhookURLs []string, severities []string) (*forwarder.Config, error) {
+	bmc := &forwarder.BMC{
+		Host:      host,
+		Port:      port,
+		User:      username,
+		Pass:      password,
+		Interface: intf,
+	}
+	if !noSDRCache {
+		bmc.SDRCacheDir = sdrCacheDir
+	}
+
+	config := &forwarder.Config{
+		BMCs:    []*forwarder.BMC{bmc},
+		Targets: make(map[string]*forwarder.TargetConfig),
+	}
+
+	for i, s := range syslogURLs {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog url (%s), err: %s", s, err)
+		}
+		address := u.Host
+		if u.Scheme == "unix" || u.Scheme == "unixgram" {
+			address = u.Path
+		}
+		config.Targets[fmt.Sprintf("syslog-%d", i)] = &forwarder.TargetConfig{
+			Type:    forwarder.TargetTypeSyslog,
+			Network: u.Scheme,
+			Address: address,
+		}
+	}
+
+	for i, s := range webhookURLs {
+		config.Targets[fmt.Sprintf("webhook-%d", i)] = &forwarder.TargetConfig{
+			Type: forwarder.TargetTypeWebhook,
+			URL:  s,
+		}
+	}
+
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("no --syslog or --webhook targets")
+	}
+
+	if len(severities) > 0 {
+		rule := &forwarder.Rule{}
+		for _, severity := range severities {
+			rule.Severities = append(rule.Severities, ipmi.EventSeverity(severity))
+		}
+		for name := range config.Targets {
+			rule.Targets = append(rule.Targets, name)
+		}
+		config.Rules = append(config.Rules, rule)
+	}
+
+	return config, nil
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
 )
 
@@ -34,9 +36,13 @@ func NewCmdFRUList() *cobra.Command {
 				CheckErr(fmt.Errorf("GetFRUs failed, err: %s", err))
 			}
 
-			for _, fru := range frus {
-				fmt.Println(fru.String())
-			}
+			printRecords(func() string {
+				table := []string{}
+				for _, fru := range frus {
+					table = append(table, fru.String())
+				}
+				return strings.Join(table, "\n")
+			}, ipmi.NewFRUOutputs(frus))
 		},
 	}
 	return cmd
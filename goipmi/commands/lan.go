@@ -51,13 +51,13 @@ stats clear [<channel number>]
 				if err != nil {
 					CheckErr(fmt.Errorf("GetIPStatistics failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "clear":
 				res, err := client.GetIPStatistics(channelNumber, true)
 				if err != nil {
 					CheckErr(fmt.Errorf("GetIPStatistics failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
 			}
@@ -91,7 +91,7 @@ print [<channel number>]
 
 			client.Debug("Lan Config", lanConfig)
 
-			fmt.Println(lanConfig.Format())
+			printOutput(lanConfig.Format(), lanConfig)
 		},
 	}
 	return cmd
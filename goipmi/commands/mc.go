@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
 )
 
@@ -37,7 +38,7 @@ func NewCmdMCInfo() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetDeviceID failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printRecords(res.Format, []ipmi.OutputRecord{ipmi.NewDeviceIDOutput(res)})
 		},
 	}
 	return cmd
@@ -87,7 +88,7 @@ func NewCmdMC_ACPI() *cobra.Command {
 				if err != nil {
 					CheckErr(fmt.Errorf("GetACPIPowerState failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "set":
 				//
 			default:
@@ -107,7 +108,7 @@ func NewCmdMC_GUID() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSystemGUID failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
@@ -133,7 +134,7 @@ func NewCmdMC_Watchdog() *cobra.Command {
 				if err != nil {
 					CheckErr(fmt.Errorf("GetWatchdogTimer failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "reset":
 				if _, err := client.ResetWatchdogTimer(); err != nil {
 					CheckErr(fmt.Errorf("ResetWatchdogTimer failed, err: %s", err))
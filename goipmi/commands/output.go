@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// outputFormat is set by the --output flag of the root command.
+var outputFormat string
+
+func checkOutputFormat() error {
+	if _, err := ipmi.ParseOutputFormat(outputFormat); err != nil {
+		return fmt.Errorf("invalid --output, err: %s", err)
+	}
+	return nil
+}
+
+func newOutputEncoder(format ipmi.OutputFormat) *ipmi.OutputEncoder {
+	enc := ipmi.NewOutputEncoder(format)
+	enc.Hostname = host
+	return enc
+}
+
+// printRecords prints the records in the output format, the table is only called for the table format.
+func printRecords(table func() string, records []ipmi.OutputRecord) {
+	format := ipmi.OutputFormat(outputFormat)
+	if format == ipmi.OutputFormatTable {
+		fmt.Println(table())
+		return
+	}
+	if err := newOutputEncoder(format).Encode(os.Stdout, records...); err != nil {
+		CheckErr(fmt.Errorf("print output failed, err: %s", err))
+	}
+}
+
+// printRecord prints a record of a stream, like sel tail -f.
+// The json format is printed as ndjson, so that each record is printed once it is received.
+func printRecord(line string, record ipmi.OutputRecord) {
+	format := ipmi.OutputFormat(outputFormat)
+	switch format {
+	case ipmi.OutputFormatTable:
+		fmt.Println(line)
+		return
+	case ipmi.OutputFormatJSON:
+		format = ipmi.OutputFormatNDJSON
+	}
+	if err := newOutputEncoder(format).Encode(os.Stdout, record); err != nil {
+		CheckErr(fmt.Errorf("print output failed, err: %s", err))
+	}
+}
+
+// printStatus prints the status message of a command which has no response to print, like "SEL cleared".
+// It is printed to stderr unless the output format is table, so stdout is parseable by the output format.
+func printStatus(format string, a ...interface{}) {
+	if ipmi.OutputFormat(outputFormat) == ipmi.OutputFormatTable {
+		fmt.Printf(format+"\n", a...)
+		return
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+}
+
+// printOutput prints the command response v which has no dedicated OutputRecord.
+//
+// The json and ndjson formats are the JSON encoding of v. The csv format has one
+// "field,value" row for each field of v, the nested fields are named like "Parent.Field".
+// If v is a slice, the csv has one row for each element with the field values.
+// The syslog format is not supported.
+func printOutput(table string, v interface{}) {
+	printOutputFormat(ipmi.OutputFormat(outputFormat), table, v)
+}
+
+// printStreamOutput is like printOutput for an item of a stream, like sensor watch,
+// the json format is printed as ndjson.
+func printStreamOutput(line string, v interface{}) {
+	format := ipmi.OutputFormat(outputFormat)
+	if format == ipmi.OutputFormatJSON {
+		format = ipmi.OutputFormatNDJSON
+	}
+	printOutputFormat(format, line, v)
+}
+
+func printOutputFormat(format ipmi.OutputFormat, table string, v interface{}) {
+	var err error
+
+	switch format {
+	case ipmi.OutputFormatTable:
+		fmt.Println(table)
+
+	case ipmi.OutputFormatJSON, ipmi.OutputFormatNDJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		if format == ipmi.OutputFormatJSON {
+			enc.SetIndent("", "  ")
+		}
+		err = enc.Encode(v)
+
+	case ipmi.OutputFormatCSV:
+		csvWriter := csv.NewWriter(os.Stdout)
+		err = csvWriter.WriteAll(csvRows(reflect.ValueOf(v)))
+
+	default:
+		err = fmt.Errorf("output format (%s) is not supported by this command", outputFormat)
+	}
+
+	if err != nil {
+		CheckErr(fmt.Errorf("print output failed, err: %s", err))
+	}
+}
+
+func csvRows(v reflect.Value) [][]string {
+	v = reflect.Indirect(v)
+
+	rows := [][]string{}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			row := []string{}
+			for _, field := range csvFields("", v.Index(i)) {
+				row = append(row, field[1])
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	for _, field := range csvFields("", v) {
+		rows = append(rows, []string{field[0], field[1]})
+	}
+	return rows
+}
+
+// csvFields flattens the exported fields of the struct v to name and value pairs.
+func csvFields(prefix string, v reflect.Value) [][2]string {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return [][2]string{{strings.TrimSuffix(prefix, "."), csvValue(v)}}
+	}
+
+	fields := [][2]string{}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		value := v.Field(i)
+		if field.Anonymous {
+			fields = append(fields, csvFields(prefix, value)...)
+			continue
+		}
+
+		if reflect.Indirect(value).Kind() == reflect.Struct && !value.Type().Implements(stringerType) {
+			fields = append(fields, csvFields(prefix+field.Name+".", value)...)
+			continue
+		}
+		fields = append(fields, [2]string{prefix + field.Name, csvValue(value)})
+	}
+	return fields
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func csvValue(v reflect.Value) string {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return ""
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return fmt.Sprintf("%x", v.Bytes())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
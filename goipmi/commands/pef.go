@@ -34,7 +34,7 @@ func NewCmdPEFCapabilities() *cobra.Command {
 				CheckErr(fmt.Errorf("GetPEFCapabilities failed, err: %s", err))
 			}
 
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
@@ -18,12 +18,20 @@ var (
 	intf     string
 	debug    bool
 
+	sdrCacheFile string
+	sdrCacheDir  string
+	noSDRCache   bool
+
 	showVersion bool
 
 	client *ipmi.Client
 )
 
 func initClient() error {
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+
 	if debug {
 		fmt.Printf("Version: %s\n", Version)
 		fmt.Printf("Commit: %s\n", Commit)
@@ -50,6 +58,11 @@ func initClient() error {
 	client.WithDebug(debug)
 	client.WithInterface(ipmi.Interface(intf))
 
+	if !noSDRCache {
+		client.WithSDRCacheDir(sdrCacheDir)
+		client.WithSDRCacheFile(sdrCacheFile)
+	}
+
 	if err := client.Connect(); err != nil {
 		return fmt.Errorf("client connect failed, err: %s", err)
 	}
@@ -87,11 +100,16 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().StringVarP(&intf, "interface", "I", "open", "interface, supported (open,lan,lanplus)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "debug")
 	rootCmd.PersistentFlags().BoolVarP(&showVersion, "version", "V", false, "version")
+	rootCmd.PersistentFlags().StringVarP(&sdrCacheFile, "sdr-cache-file", "S", "", "use local file for SDR cache, a JSON SDR cache or an ipmitool sdr dump file")
+	rootCmd.PersistentFlags().StringVarP(&sdrCacheDir, "sdr-cache-dir", "", ipmi.DefaultSDRCacheDir(), "directory to store SDR cache files")
+	rootCmd.PersistentFlags().BoolVarP(&noSDRCache, "no-sdr-cache", "", false, "disable SDR cache")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", string(ipmi.OutputFormatTable), "output format, supported (table,json,ndjson,csv,syslog)")
 
 	rootCmd.Flags().AddGoFlagSet(flag.CommandLine)
 
 	rootCmd.AddCommand(NewCmdMC())
 	rootCmd.AddCommand(NewCmdSEL())
+	rootCmd.AddCommand(NewCmdEvent())
 	rootCmd.AddCommand(NewCmdSDR())
 	rootCmd.AddCommand(NewCmdChassis())
 	rootCmd.AddCommand(NewCmdChannel())
@@ -102,6 +120,8 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.AddCommand(NewCmdFRU())
 	rootCmd.AddCommand(NewCmdSOL())
 	rootCmd.AddCommand(NewCmdPEF())
+	rootCmd.AddCommand(NewCmdExporter())
+	rootCmd.AddCommand(NewCmdForward())
 
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
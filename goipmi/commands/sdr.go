@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -25,6 +27,10 @@ func NewCmdSDR() *cobra.Command {
 	cmd.AddCommand(NewCmdSDRInfo())
 	cmd.AddCommand(NewCmdSDRGet())
 	cmd.AddCommand(NewCmdSDRList())
+	cmd.AddCommand(NewCmdSDRDump())
+	cmd.AddCommand(NewCmdSDRFill())
+	cmd.AddCommand(NewCmdSDRLoad())
+	cmd.AddCommand(NewCmdSDREntity())
 
 	return cmd
 }
@@ -38,7 +44,7 @@ func NewCmdSDRInfo() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSDRRepoInfo failed, err: %s", err))
 			}
-			fmt.Println(sdrRepoInfo.Format())
+			printOutput(sdrRepoInfo.Format(), sdrRepoInfo)
 		},
 	}
 	return cmd
@@ -83,7 +89,7 @@ func NewCmdSDRGet() *cobra.Command {
 			}
 
 			client.Debug("SDR", sdr)
-			fmt.Println(sdr)
+			printRecords(sdr.String, []ipmi.OutputRecord{ipmi.NewSDROutput(sdr)})
 		},
 	}
 
@@ -122,7 +128,9 @@ func NewCmdSDRList() *cobra.Command {
 						CheckErr(fmt.Errorf("GetSDRs failed, err: %s", err))
 					}
 
-					fmt.Println(ipmi.FormatSDRs_FRU(sdrs))
+					printRecords(func() string {
+						return ipmi.FormatSDRs_FRU(sdrs)
+					}, ipmi.NewSDROutputs(sdrs))
 					return
 
 				case "generic":
@@ -138,9 +146,188 @@ func NewCmdSDRList() *cobra.Command {
 				CheckErr(fmt.Errorf("GetSDRs failed, err: %s", err))
 			}
 
-			fmt.Println(ipmi.FormatSDRs(sdrs))
+			printRecords(func() string {
+				return ipmi.FormatSDRs(sdrs)
+			}, ipmi.NewSDROutputs(sdrs))
 		},
 	}
 
 	return cmd
 }
+
+func NewCmdSDRDump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump <file>",
+		Short: "dump raw SDR records to file, in the same binary format as ipmitool sdr dump",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cache, err := client.ReadSDRRepo()
+			if err != nil {
+				CheckErr(fmt.Errorf("ReadSDRRepo failed, err: %s", err))
+			}
+
+			if err := os.WriteFile(args[0], cache.Dump(), 0o644); err != nil {
+				CheckErr(fmt.Errorf("write file failed, err: %s", err))
+			}
+			printStatus("Dumped %d SDR records to %s", len(cache.Records), args[0])
+		},
+	}
+	return cmd
+}
+
+// readSDRDumpFile reads raw SDR records from the file in the ipmitool sdr dump format.
+func readSDRDumpFile(file string) [][]byte {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		CheckErr(fmt.Errorf("read file failed, err: %s", err))
+	}
+
+	cache, err := ipmi.ParseSDRDump(b)
+	if err != nil {
+		CheckErr(fmt.Errorf("ParseSDRDump failed, err: %s", err))
+	}
+	return cache.RawRecords()
+}
+
+func NewCmdSDRFill() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fill <file>",
+		Short: "clear the SDR repository and fill it with the SDR records from the dump file",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			records := readSDRDumpFile(args[0])
+
+			if err := client.FillSDRRepo(records); err != nil {
+				CheckErr(fmt.Errorf("FillSDRRepo failed, err: %s", err))
+			}
+			printStatus("Filled %d SDR records from %s", len(records), args[0])
+		},
+	}
+	return cmd
+}
+
+func NewCmdSDRLoad() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load <file>",
+		Short: "write the SDR records from the dump file to the SDR repository, the records with the same record ID are replaced",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			records := readSDRDumpFile(args[0])
+
+			if err := client.ReplaceSDRs(records); err != nil {
+				CheckErr(fmt.Errorf("ReplaceSDRs failed, err: %s", err))
+			}
+			printStatus("Loaded %d SDR records from %s", len(records), args[0])
+		},
+	}
+	return cmd
+}
+
+func NewCmdSDREntity() *cobra.Command {
+	usage := `sdr entity [<id>[.<instance>]]`
+
+	cmd := &cobra.Command{
+		Use:   "entity",
+		Short: "show entities with their contained entities, sensors and devices as tree",
+		Run: func(cmd *cobra.Command, args []string) {
+			tree, err := client.GetEntityTree()
+			if err != nil {
+				CheckErr(fmt.Errorf("GetEntityTree failed, err: %s", err))
+			}
+
+			if len(args) == 0 {
+				printEntityNodes(tree.Format(), tree.Roots)
+				return
+			}
+
+			entityID, instances, err := parseEntity(args[0])
+			if err != nil {
+				CheckErr(fmt.Errorf("%s, usage: %s", err, usage))
+			}
+
+			nodes := tree.Find(entityID, instances...)
+			if len(nodes) == 0 {
+				CheckErr(fmt.Errorf("entity (%s) not found", args[0]))
+			}
+			table := ""
+			for _, node := range nodes {
+				table += node.Format()
+			}
+			printEntityNodes(table, nodes)
+		},
+	}
+
+	return cmd
+}
+
+// entityOutput is the machine-readable output of an entity of sdr entity.
+// The entities are listed flatly with the parent entity instead of nested.
+//
+// The CSV has one row for each SDR of the entity, or one row without SDR if the entity has no SDRs:
+//
+//	entity,entity_name,parent,record_type,name
+type entityOutput struct {
+	Entity     string `json:"entity"`
+	EntityName string `json:"entity_name"`
+	// empty if the entity is not contained by other entities
+	Parent string `json:"parent,omitempty"`
+	// The SDRs of the sensors, FRUs and devices of the entity
+	Records []*ipmi.SDROutput `json:"records"`
+}
+
+func newEntityOutputs(nodes []*ipmi.EntityNode) []ipmi.OutputRecord {
+	out := []ipmi.OutputRecord{}
+	for _, node := range nodes {
+		e := &entityOutput{
+			Entity:     node.Key.String(),
+			EntityName: node.Key.EntityID.String(),
+			Records:    []*ipmi.SDROutput{},
+		}
+		if node.Parent != nil {
+			e.Parent = node.Parent.Key.String()
+		}
+		for _, sdrs := range [][]*ipmi.SDR{node.Sensors, node.FRUs, node.Devices} {
+			for _, sdr := range sdrs {
+				e.Records = append(e.Records, ipmi.NewSDROutput(sdr))
+			}
+		}
+
+		out = append(out, e)
+		out = append(out, newEntityOutputs(node.Children)...)
+	}
+	return out
+}
+
+func (e *entityOutput) CSVRows() [][]string {
+	if len(e.Records) == 0 {
+		return [][]string{{e.Entity, e.EntityName, e.Parent, "", ""}}
+	}
+	rows := [][]string{}
+	for _, record := range e.Records {
+		rows = append(rows, []string{e.Entity, e.EntityName, e.Parent, record.RecordType, record.Name})
+	}
+	return rows
+}
+
+func (e *entityOutput) SyslogMessage() *ipmi.SyslogMessage {
+	msg := &ipmi.SyslogMessage{
+		Severity: ipmi.SyslogSeverityInformational,
+		MsgID:    "ENTITY",
+		Params: []ipmi.SyslogParam{
+			{Name: "entity", Value: e.Entity},
+			{Name: "parent", Value: e.Parent},
+		},
+		Message: fmt.Sprintf("%s %s", e.Entity, e.EntityName),
+	}
+	for _, record := range e.Records {
+		msg.Message += fmt.Sprintf(", %s %s", record.RecordType, record.Name)
+	}
+	return msg
+}
+
+// printEntityNodes prints the entities and all their contained entities in the output format.
+func printEntityNodes(table string, nodes []*ipmi.EntityNode) {
+	printRecords(func() string {
+		return strings.TrimSuffix(table, "\n")
+	}, newEntityOutputs(nodes))
+}
@@ -1,8 +1,15 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -25,6 +32,11 @@ func NewCmdSEL() *cobra.Command {
 	cmd.AddCommand(NewCmdSELGet())
 	cmd.AddCommand(NewCmdSELList())
 	cmd.AddCommand(NewCmdSELElist())
+	cmd.AddCommand(NewCmdSELTail())
+	cmd.AddCommand(NewCmdSELAdd())
+	cmd.AddCommand(NewCmdSELClear())
+	cmd.AddCommand(NewCmdSELDelete())
+	cmd.AddCommand(NewCmdSELTime())
 
 	return cmd
 }
@@ -38,13 +50,28 @@ func NewCmdSELInfo() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSELInfo failed, err: %s", err))
 			}
-			fmt.Println(selInfo.Format())
 
 			selAllocInfo, err := client.GetSELAllocInfo()
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSELInfo failed, err: %s", err))
 			}
-			fmt.Println(selAllocInfo.Format())
+
+			// the aux logs are optional, they are reported alongside SEL info if supported
+			auxLogs, err := client.GetAuxLogStatuses()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+
+			out := selInfo.Format() + "\n" + selAllocInfo.Format()
+			for _, auxLog := range auxLogs {
+				out += "\n" + auxLog.Format()
+			}
+
+			printOutput(out, struct {
+				Info      *ipmi.GetSELInfoResponse
+				AllocInfo *ipmi.GetSELAllocInfoResponse
+				AuxLogs   []*ipmi.GetAuxLogStatusResponse
+			}{selInfo, selAllocInfo, auxLogs})
 		},
 	}
 	return cmd
@@ -73,45 +100,584 @@ func NewCmdSELGet() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("ParseSEL failed, err: %s", err))
 			}
-			fmt.Println(ipmi.FormatSELs([]*ipmi.SEL{sel}, nil))
+			printSELs([]*ipmi.SEL{sel}, nil)
 		},
 	}
 	return cmd
 }
 
 func NewCmdSELList() *cobra.Command {
+	flags := &selQueryFlags{}
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "list",
 		Run: func(cmd *cobra.Command, args []string) {
-			selEntries, err := client.GetSELEntries(0)
+			query, err := flags.query()
 			if err != nil {
-				CheckErr(fmt.Errorf("GetSELInfo failed, err: %s", err))
+				CheckErr(err)
+			}
+
+			selEntries, err := client.QuerySEL(query)
+			if err != nil {
+				CheckErr(fmt.Errorf("QuerySEL failed, err: %s", err))
 			}
 
-			fmt.Println(ipmi.FormatSELs(selEntries, nil))
+			printSELs(selEntries, nil)
 		},
 	}
+	flags.addFlags(cmd)
+
 	return cmd
 }
 
 func NewCmdSELElist() *cobra.Command {
+	flags := &selQueryFlags{}
+
 	cmd := &cobra.Command{
 		Use:   "elist",
 		Short: "elist",
 		Run: func(cmd *cobra.Command, args []string) {
+			query, err := flags.query()
+			if err != nil {
+				CheckErr(err)
+			}
+
 			sdrsMap, err := client.GetSDRsMap()
 			if err != nil {
 				CheckErr(fmt.Errorf("GetSDRsMap failed, err: %s", err))
 			}
 
-			selEntries, err := client.GetSELEntries(0)
+			selEntries, err := client.QuerySELWithSDRs(query, sdrsMap)
 			if err != nil {
-				CheckErr(fmt.Errorf("GetSELInfo failed, err: %s", err))
+				CheckErr(fmt.Errorf("QuerySELWithSDRs failed, err: %s", err))
 			}
 
-			fmt.Println(ipmi.FormatSELs(selEntries, sdrsMap))
+			printSELs(selEntries, sdrsMap)
 		},
 	}
+	flags.addFlags(cmd)
+
 	return cmd
 }
+
+// selQueryFlags is the flags of the SEL query filters of sel list and sel elist.
+type selQueryFlags struct {
+	since       string
+	until       string
+	severities  []string
+	sensors     []string
+	sensorTypes []string
+	direction   string
+	recordTypes []string
+	limit       int
+	fullWalk    bool
+}
+
+func (f *selQueryFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.since, "since", "", "", "only the records logged since the time, like 24h (ago), \"2006-01-02 15:04:05\" or RFC 3339, SEL is still read from the oldest record")
+	cmd.Flags().StringVarP(&f.until, "until", "", "", "only the records logged until the time, in the same format as --since, SEL is read until the first record after the time")
+	cmd.Flags().StringArrayVarP(&f.severities, "severity", "", nil, "only the records of the event severity, info, ok, warning, critical, degraded or non-fatal, can be repeated")
+	cmd.Flags().StringArrayVarP(&f.sensors, "sensor", "", nil, "only the records of the sensor names matched by the pattern, like 'PSU*', can be repeated")
+	cmd.Flags().StringArrayVarP(&f.sensorTypes, "sensor-type", "", nil, "only the records of the sensor type name or number, can be repeated")
+	cmd.Flags().StringVarP(&f.direction, "direction", "", "", "only the records of the event direction, assert or deassert")
+	cmd.Flags().StringArrayVarP(&f.recordTypes, "record-type", "", nil, "only the records of the record type range, standard, oem, timestamped-oem or non-timestamped-oem, can be repeated")
+	cmd.Flags().IntVarP(&f.limit, "limit", "", 0, "max number of records, the newest ones are kept")
+	cmd.Flags().BoolVarP(&f.fullWalk, "full-walk", "", false, "read the whole SEL with --until, for the SEL whose timestamps went backwards after the SEL Time was set")
+}
+
+func (f *selQueryFlags) query() (*ipmi.SELQuery, error) {
+	query := &ipmi.SELQuery{
+		SensorNames: f.sensors,
+		Limit:       f.limit,
+		FullWalk:    f.fullWalk,
+	}
+
+	if f.since != "" {
+		t, err := parseSELQueryTime(f.since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since, err: %s", err)
+		}
+		query.Since = t
+	}
+	if f.until != "" {
+		t, err := parseSELQueryTime(f.until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until, err: %s", err)
+		}
+		query.Until = t
+	}
+
+	for _, severity := range f.severities {
+		query.Severities = append(query.Severities, ipmi.EventSeverity(severity))
+	}
+
+	for _, s := range f.sensorTypes {
+		sensorType, err := ipmi.ParseSensorType(s)
+		if err != nil {
+			return nil, err
+		}
+		query.SensorTypes = append(query.SensorTypes, sensorType)
+	}
+
+	switch strings.ToLower(f.direction) {
+	case "":
+	case "assert":
+		query.EventDirs = []ipmi.EventDir{ipmi.EventDirAssertion}
+	case "deassert":
+		query.EventDirs = []ipmi.EventDir{ipmi.EventDirDeassertion}
+	default:
+		return nil, fmt.Errorf("invalid --direction (%s), should be assert or deassert", f.direction)
+	}
+
+	for _, recordType := range f.recordTypes {
+		switch strings.ToLower(recordType) {
+		case "standard":
+			query.RecordTypeRanges = append(query.RecordTypeRanges, ipmi.SELRecordTypeRangeStandard)
+		case "oem":
+			query.RecordTypeRanges = append(query.RecordTypeRanges, ipmi.SELRecordTypeRangeTimestampedOEM, ipmi.SELRecordTypeRangeNonTimestampedOEM)
+		case "timestamped-oem":
+			query.RecordTypeRanges = append(query.RecordTypeRanges, ipmi.SELRecordTypeRangeTimestampedOEM)
+		case "non-timestamped-oem":
+			query.RecordTypeRanges = append(query.RecordTypeRanges, ipmi.SELRecordTypeRangeNonTimestampedOEM)
+		default:
+			return nil, fmt.Errorf("invalid --record-type (%s)", recordType)
+		}
+	}
+
+	return query, query.Validate()
+}
+
+// parseSELQueryTime parses the duration before now, like 24h, or the time in local time zone.
+func parseSELQueryTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02", "01/02/2006 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown time (%s), should be like 24h, \"2006-01-02 15:04:05\" or RFC 3339", s)
+}
+
+func NewCmdSELTail() *cobra.Command {
+	var follow bool
+	var lines int
+	var interval time.Duration
+	var cursorFile string
+	var auxLogs bool
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "print the last SEL entries, and the new entries with -f",
+		Run: func(cmd *cobra.Command, args []string) {
+			if lines < 0 {
+				CheckErr(fmt.Errorf("invalid lines (%d), should not be negative", lines))
+			}
+
+			decoder := getSELOEMDecoder()
+
+			if !follow {
+				selEntries, err := getSELEntries()
+				if err != nil {
+					CheckErr(err)
+				}
+				if len(selEntries) > lines {
+					selEntries = selEntries[len(selEntries)-lines:]
+				}
+				for _, sel := range selEntries {
+					printSELLine(sel, decoder)
+				}
+				return
+			}
+
+			if auxLogs && ipmi.OutputFormat(outputFormat) == ipmi.OutputFormatSyslog {
+				CheckErr(fmt.Errorf("--aux-logs is not supported by the syslog output format"))
+			}
+
+			var store ipmi.SELCursorStore = &ipmi.MemorySELCursorStore{}
+			if cursorFile != "" {
+				store = ipmi.NewFileSELCursorStore(cursorFile)
+			}
+
+			// without a saved cursor, the follow starts with the last lines of entries
+			follower := client.NewSELFollower(interval, store).
+				WithStartFromLast(lines).
+				WithErrorHandler(func(err error) {
+					fmt.Fprintln(os.Stderr, err)
+				})
+			if auxLogs {
+				follower.WithAuxLogHandler(printAuxLogLine)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- follower.Run(ctx)
+			}()
+
+			for sel := range follower.Records() {
+				printSELLine(sel, decoder)
+				follower.Ack(sel)
+			}
+
+			if err := <-errCh; err != nil {
+				CheckErr(fmt.Errorf("SELFollower failed, err: %s", err))
+			}
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(&follow, "follow", "f", false, "output new SEL entries as they are added")
+	cmd.PersistentFlags().IntVarP(&lines, "lines", "n", 10, "output the last n SEL entries")
+	cmd.PersistentFlags().DurationVarP(&interval, "interval", "i", ipmi.DefaultSELFollowInterval, "poll interval")
+	cmd.PersistentFlags().StringVarP(&cursorFile, "cursor-file", "", "", "file to save the SEL cursor, the follow resumes from the saved cursor")
+	cmd.PersistentFlags().BoolVarP(&auxLogs, "aux-logs", "", false, "also output the updates of the auxiliary logs, like the MCA log, when following")
+
+	return cmd
+}
+
+func NewCmdSELAdd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <file>",
+		Short: "add SEL records from the file",
+		Long: `add <file>
+
+Add SEL records from the file, each line is one of:
+
+  7 hex bytes, the event message like ipmitool event file:
+      <EvMRev> <sensor type> <sensor number> <event dir|type> <event data 1> <event data 2> <event data 3>
+  16 hex bytes, the raw SEL record
+  <sensorNumber|sensorName> | <state> [| assert|deassert], see goipmi event
+
+The empty lines and the lines starting with # are ignored.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(errors.New("no file supplied"))
+			}
+
+			entries, err := readSELFile(args[0])
+			if err != nil {
+				CheckErr(err)
+			}
+
+			results := make([]*ipmi.AddSELEntryResponse, 0, len(entries))
+			lines := make([]string, 0, len(entries))
+			printAdded := func() {
+				if len(results) > 0 {
+					printOutput(strings.Join(lines, "\n"), results)
+				}
+			}
+
+			for _, entry := range entries {
+				var res *ipmi.AddSELEntryResponse
+				var err error
+				switch v := entry.(type) {
+				case *ipmi.SEL:
+					res, err = client.AddSELEntry(v)
+				case *ipmi.EventBuilder:
+					res, err = client.AddSELEvent(v)
+				}
+				if err != nil {
+					// the records added before the failure are still printed
+					printAdded()
+					CheckErr(fmt.Errorf("add SEL record failed, err: %s", err))
+				}
+				results = append(results, res)
+				lines = append(lines, res.Format())
+			}
+			printAdded()
+		},
+	}
+	return cmd
+}
+
+func NewCmdSELClear() *cobra.Command {
+	var saveFile string
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "clear SEL, the records are saved to the file with --save before clearing",
+		Run: func(cmd *cobra.Command, args []string) {
+			var archiveWriter io.WriteCloser
+			if saveFile != "" {
+				f, err := os.Create(saveFile)
+				if err != nil {
+					CheckErr(fmt.Errorf("create save file failed, err: %s", err))
+				}
+				// the file is closed by ClearSELSafely before SEL is erased
+				archiveWriter = &syncOnCloseFile{f}
+			}
+
+			printStatus("Clearing SEL.  Please allow a few seconds to erase.")
+			records, err := client.ClearSELSafely(archiveWriter)
+			if err != nil {
+				CheckErr(fmt.Errorf("ClearSELSafely failed, err: %s", err))
+			}
+
+			if saveFile != "" {
+				printStatus("Saved %d SEL records to %s", len(records), saveFile)
+			}
+			printStatus("SEL cleared")
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&saveFile, "save", "", "", "JSON file to save the SEL records before clearing")
+
+	return cmd
+}
+
+// syncOnCloseFile syncs the file before closing it, so the saved records are durable before SEL is erased.
+type syncOnCloseFile struct {
+	*os.File
+}
+
+func (f *syncOnCloseFile) Close() error {
+	if err := f.File.Sync(); err != nil {
+		f.File.Close()
+		return err
+	}
+	return f.File.Close()
+}
+
+func NewCmdSELDelete() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <id>...",
+		Short: "delete SEL records",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(errors.New("no Record ID supplied"))
+			}
+
+			recordIDs := make([]uint16, 0, len(args))
+			for _, arg := range args {
+				id, err := parseStringToInt64(arg)
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid Record ID passed, err: %s", err))
+				}
+				recordIDs = append(recordIDs, uint16(id))
+			}
+
+			for _, recordID := range recordIDs {
+				if _, err := client.DeleteSELEntryReserved(recordID); err != nil {
+					CheckErr(fmt.Errorf("delete SEL record (%#04x) failed, err: %s", recordID, err))
+				}
+				printStatus("Deleted entry %d", recordID)
+			}
+		},
+	}
+	return cmd
+}
+
+func NewCmdSELTime() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time",
+		Short: "get or set SEL time, and report the drift of SEL time",
+		Run: func(cmd *cobra.Command, args []string) {
+		},
+	}
+	cmd.AddCommand(NewCmdSELTimeGet())
+	cmd.AddCommand(NewCmdSELTimeSet())
+	cmd.AddCommand(NewCmdSELTimeDrift())
+
+	return cmd
+}
+
+func NewCmdSELTimeGet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "get SEL time",
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := client.GetSELTime()
+			if err != nil {
+				CheckErr(fmt.Errorf("GetSELTime failed, err: %s", err))
+			}
+
+			out := struct {
+				Time      time.Time
+				UTCOffset *int16 `json:",omitempty"`
+			}{Time: res.Time}
+
+			table := res.Time.Format("01/02/2006 15:04:05")
+			if offsetRes, err := client.GetSELTimeUTCOffset(); err == nil {
+				out.UTCOffset = &offsetRes.MinutesOffset
+				table += "\n" + offsetRes.Format()
+			}
+			printOutput(table, out)
+		},
+	}
+	return cmd
+}
+
+func NewCmdSELTimeSet() *cobra.Command {
+	var tolerance time.Duration
+	var utcOffset int16
+	var dryRun bool
+
+	usage := `sel time set <now|"mm/dd/yyyy hh:mm:ss">
+
+With now, SEL time is synced to the local clock if the drift exceeds the tolerance, the request round-trip time
+is corrected. SEL time is UTC plus SEL time UTC offset, the offset is kept unless --utc-offset is specified.`
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "set SEL time",
+		Long:  usage,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(fmt.Errorf("no time supplied, usage: %s", usage))
+			}
+
+			if args[0] != "now" {
+				t, err := time.ParseInLocation("01/02/2006 15:04:05", strings.Join(args, " "), time.Local)
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid time, usage: %s", usage))
+				}
+				if _, err := client.SetSELTime(t); err != nil {
+					CheckErr(fmt.Errorf("SetSELTime failed, err: %s", err))
+				}
+				printOutput(t.Format("01/02/2006 15:04:05"), struct {
+					Time time.Time
+				}{Time: t})
+				return
+			}
+
+			opts := ipmi.SELTimeSyncOptions{
+				Tolerance: tolerance,
+				DryRun:    dryRun,
+			}
+			if !cmd.Flags().Changed("tolerance") {
+				// always set
+				opts.Tolerance = -1
+			}
+			if cmd.Flags().Changed("utc-offset") {
+				opts.UTCOffset = &utcOffset
+			}
+			result, err := client.SyncSELTime(opts)
+			if err != nil {
+				CheckErr(fmt.Errorf("SyncSELTime failed, err: %s", err))
+			}
+			printOutput(result.Format(), result)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&tolerance, "tolerance", "", ipmi.DefaultSELTimeTolerance, "only set SEL time if the drift exceeds the tolerance, SEL time is always set if not specified")
+	cmd.Flags().Int16VarP(&utcOffset, "utc-offset", "", 0, "set SEL time UTC offset in minutes, like 480 for UTC+8, the offset is kept if not specified")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "report the drift only, nothing is changed")
+
+	return cmd
+}
+
+func NewCmdSELTimeDrift() *cobra.Command {
+	var tolerance time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "report the drift of SEL time relative to the local clock, nothing is changed",
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := client.SyncSELTime(ipmi.SELTimeSyncOptions{
+				Tolerance: tolerance,
+				DryRun:    true,
+			})
+			if err != nil {
+				CheckErr(fmt.Errorf("SyncSELTime failed, err: %s", err))
+			}
+			printOutput(result.Format(), result)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&tolerance, "tolerance", "", ipmi.DefaultSELTimeTolerance, "the drift exceeds the tolerance is reported as time set needed")
+
+	return cmd
+}
+
+// getSELEntries returns all SEL entries, it returns empty for empty SEL.
+func getSELEntries() ([]*ipmi.SEL, error) {
+	selInfo, err := client.GetSELInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+	}
+	if selInfo.Entries == 0 {
+		return []*ipmi.SEL{}, nil
+	}
+
+	selEntries, err := client.GetSELEntries(0)
+	if err != nil {
+		return nil, fmt.Errorf("GetSELEntries failed, err: %s", err)
+	}
+	return selEntries, nil
+}
+
+// getSELOEMDecoder returns the OEM SEL decoder for the BMC, or nil if the decoder can not be determined.
+func getSELOEMDecoder() ipmi.SELOEMDecoder {
+	decoder, err := client.GetSELOEMDecoder()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GetSELOEMDecoder failed, OEM SEL records are not decoded, err: %s\n", err)
+		return nil
+	}
+	return decoder
+}
+
+// printSELs prints the SEL records in the output format, the sdrsMap is optional.
+func printSELs(records []*ipmi.SEL, sdrsMap ipmi.SDRMapBySensorNumber) {
+	decoder := getSELOEMDecoder()
+	printRecords(func() string {
+		return ipmi.FormatSELsWithDecoder(records, sdrsMap, decoder)
+	}, ipmi.NewSELOutputs(records, sdrsMap, decoder))
+}
+
+// printSELLine prints the SEL record of sel tail in the output format.
+func printSELLine(sel *ipmi.SEL, decoder ipmi.SELOEMDecoder) {
+	printRecord(formatSELLine(sel, decoder), ipmi.NewSELOutput(sel, nil, decoder))
+}
+
+// printAuxLogLine prints the update of the auxiliary log in a single line.
+func printAuxLogLine(status *ipmi.GetAuxLogStatusResponse) {
+	lastUpdate := "unspecified"
+	if !status.Timestamp.IsZero() {
+		lastUpdate = status.Timestamp.Format("2006-01-02 15:04:05")
+	}
+
+	line := fmt.Sprintf("aux log | %s | %s | updated", lastUpdate, status.LogType)
+	if status.LogType == ipmi.AuxLogTypeMCA {
+		line += fmt.Sprintf(" | %d entries", status.MCAEntries)
+	}
+	printStreamOutput(line, status)
+}
+
+// formatSELLine formats the SEL record in a single line, like ipmitool sel list.
+// The OEM information of the record is decoded by the decoder if it is not nil.
+func formatSELLine(sel *ipmi.SEL, decoder ipmi.SELOEMDecoder) string {
+	oemDesc, oemDecoded := ipmi.DecodeOEMSEL(sel, decoder)
+
+	switch sel.RecordType.Range() {
+	case ipmi.SELRecordTypeRangeStandard:
+		s := sel.Standard
+		eventDesc := s.EventString()
+		if oemDecoded {
+			eventDesc = fmt.Sprintf("%s, %s", eventDesc, oemDesc)
+		}
+		return fmt.Sprintf("%#04x | %s | %s #%#02x | %s | %s",
+			sel.RecordID, s.Timestamp.Format("2006-01-02 15:04:05"), s.SensorType, s.SensorNumber, eventDesc, s.EventDir)
+
+	case ipmi.SELRecordTypeRangeTimestampedOEM:
+		s := sel.OEMTimestamped
+		if oemDecoded {
+			return fmt.Sprintf("%#04x | %s | OEM record %#02x | %s",
+				sel.RecordID, s.Timestamp.Format("2006-01-02 15:04:05"), uint8(sel.RecordType), oemDesc)
+		}
+		return fmt.Sprintf("%#04x | %s | OEM record %#02x | manufacturer %d | % x",
+			sel.RecordID, s.Timestamp.Format("2006-01-02 15:04:05"), uint8(sel.RecordType), s.ManufacturerID, s.OEMDefined)
+
+	default:
+		if oemDecoded {
+			return fmt.Sprintf("%#04x | OEM record %#02x | %s", sel.RecordID, uint8(sel.RecordType), oemDesc)
+		}
+		return fmt.Sprintf("%#04x | OEM record %#02x | % x", sel.RecordID, uint8(sel.RecordType), sel.OEMNonTimestamped.OEM)
+	}
+}
@@ -1,7 +1,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -23,8 +31,11 @@ func NewCmdSensor() *cobra.Command {
 	cmd.AddCommand(NewCmdSensorDeviceSDRInfo())
 	cmd.AddCommand(NewCmdSensorGet())
 	cmd.AddCommand(NewCmdSensorList())
+	cmd.AddCommand(NewCmdSensorWatch())
 	cmd.AddCommand(NewCmdSensorThreshold())
+	cmd.AddCommand(NewCmdSensorThresh())
 	cmd.AddCommand(NewCmdSensorEventEnable())
+	cmd.AddCommand(NewCmdSensorRearm())
 	cmd.AddCommand(NewCmdSensorEventStatus())
 	cmd.AddCommand(NewCmdSensorReading())
 	cmd.AddCommand(NewCmdSensorReadingFactors())
@@ -42,7 +53,7 @@ func NewCmdSensorDeviceSDRInfo() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetDeviceSDRInfo failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
@@ -50,40 +61,281 @@ func NewCmdSensorDeviceSDRInfo() *cobra.Command {
 
 func NewCmdSensorList() *cobra.Command {
 	var extended bool
-	var filterThreshold bool
 	var filterReadingValid bool
+	var filterFlags sensorFilterFlags
+	var tolerateErrors bool
+	var skipThresholds bool
+	var skipHysteresis bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "list",
 		Run: func(cmd *cobra.Command, args []string) {
-
-			filterOptions := make([]ipmi.SensorFilterOption, 0)
-
-			if filterThreshold {
-				filterOptions = append(filterOptions, ipmi.SensorFilterOptionIsThreshold)
+			sdrFilterOptions, err := filterFlags.sdrFilterOptions()
+			if err != nil {
+				CheckErr(err)
 			}
 
+			filterOptions := make([]ipmi.SensorFilterOption, 0)
 			if filterReadingValid {
 				filterOptions = append(filterOptions, ipmi.SensorFilterOptionIsReadingValid)
 			}
 
-			sensors, err := client.GetSensors(filterOptions...)
+			client.WithSensorErrorTolerance(tolerateErrors).
+				WithSkipSensorThresholds(skipThresholds).
+				WithSkipSensorHysteresis(skipHysteresis)
+
+			var sensorsErr *ipmi.SensorsError
+			sensors, err := client.GetSensorsWithFilter(sdrFilterOptions, filterOptions...)
 			if err != nil {
-				CheckErr(fmt.Errorf("GetSensors failed, err: %s", err))
+				e, ok := err.(*ipmi.SensorsError)
+				if !ok {
+					CheckErr(fmt.Errorf("GetSensorsWithFilter failed, err: %s", err))
+				}
+				sensorsErr = e
 			}
 
-			fmt.Println(ipmi.FormatSensors(extended, sensors...))
+			printRecords(func() string {
+				return ipmi.FormatSensors(extended, sensors...)
+			}, ipmi.NewSensorOutputs(sensors))
+
+			if sensorsErr != nil {
+				for _, sensorErr := range sensorsErr.Errors {
+					fmt.Fprintln(os.Stderr, sensorErr)
+				}
+				fmt.Fprintln(os.Stderr, sensorsErr)
+			}
 		},
 	}
 
 	cmd.PersistentFlags().BoolVarP(&extended, "extended", "", false, "extended print")
-	cmd.PersistentFlags().BoolVarP(&filterThreshold, "threshold", "", false, "filter threshold sensor class")
 	cmd.PersistentFlags().BoolVarP(&filterReadingValid, "valid", "", false, "filter sensor that has valid reading")
+	cmd.PersistentFlags().BoolVarP(&tolerateErrors, "tolerate-errors", "", false, "list the sensors could be read, and report the failed sensors instead of aborting")
+	cmd.PersistentFlags().BoolVarP(&skipThresholds, "skip-thresholds", "", false, "do not read thresholds from sensors, use the thresholds in SDRs")
+	cmd.PersistentFlags().BoolVarP(&skipHysteresis, "skip-hysteresis", "", false, "do not read hysteresis from sensors, use the hysteresis in SDRs")
+	filterFlags.addFlags(cmd)
+
+	return cmd
+}
+
+// sensorFilterFlags holds the flags to filter sensors by their SDRs.
+type sensorFilterFlags struct {
+	threshold    bool
+	sensorTypes  []string
+	entities     []string
+	owners       []string
+	ownerLUNs    []string
+	readingTypes []string
+	nameGlob     string
+	nameRegexp   string
+}
+
+func (f *sensorFilterFlags) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVarP(&f.threshold, "threshold", "", false, "filter threshold sensor class")
+	cmd.PersistentFlags().StringSliceVarP(&f.sensorTypes, "type", "t", nil, "filter sensor type, name (like fan, temperature) or number, can be repeated")
+	cmd.PersistentFlags().StringSliceVarP(&f.entities, "entity", "e", nil, "filter entity, in the form of <id>[.<instance>], can be repeated")
+	cmd.PersistentFlags().StringSliceVarP(&f.owners, "owner", "", nil, "filter sensor owner id (slave address or software id) of any channel and lun, can be repeated")
+	cmd.PersistentFlags().StringSliceVarP(&f.ownerLUNs, "owner-lun", "", nil, "filter sensor owner lun, can be repeated")
+	cmd.PersistentFlags().StringSliceVarP(&f.readingTypes, "reading-type", "", nil, "filter event/reading type, threshold, discrete or the type code, can be repeated")
+	cmd.PersistentFlags().StringVarP(&f.nameGlob, "name", "n", "", "filter sensor name by shell pattern, like 'CPU*'")
+	cmd.PersistentFlags().StringVarP(&f.nameRegexp, "name-regex", "", "", "filter sensor name by regular expression")
+}
+
+func (f *sensorFilterFlags) sdrFilterOptions() ([]ipmi.SDRFilterOption, error) {
+	sdrFilterOptions := make([]ipmi.SDRFilterOption, 0)
+
+	if f.threshold {
+		sdrFilterOptions = append(sdrFilterOptions, ipmi.SDRFilterOptionIsThreshold)
+	}
+
+	if len(f.sensorTypes) > 0 {
+		types := make([]ipmi.SensorType, 0)
+		for _, v := range f.sensorTypes {
+			sensorType, err := ipmi.ParseSensorType(v)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, sensorType)
+		}
+		sdrFilterOptions = append(sdrFilterOptions, ipmi.SDRFilterOptionSensorType(types...))
+	}
+
+	if len(f.entities) > 0 {
+		entityFilterOptions := make([]ipmi.SDRFilterOption, 0)
+		for _, v := range f.entities {
+			entityID, instances, err := parseEntity(v)
+			if err != nil {
+				return nil, err
+			}
+			entityFilterOptions = append(entityFilterOptions, ipmi.SDRFilterOptionEntity(entityID, instances...))
+		}
+		sdrFilterOptions = append(sdrFilterOptions, anySDRFilterOption(entityFilterOptions...))
+	}
+
+	if len(f.owners) > 0 {
+		ownerIDs := make([]uint8, 0)
+		for _, v := range f.owners {
+			i, err := parseStringToInt64(v)
+			if err != nil || i < 0 || i > 0xff {
+				return nil, fmt.Errorf("invalid owner id (%s), should be in [0, 0xff]", v)
+			}
+			ownerIDs = append(ownerIDs, uint8(i))
+		}
+		sdrFilterOptions = append(sdrFilterOptions, ipmi.SDRFilterOptionOwnerID(ownerIDs...))
+	}
+
+	if len(f.ownerLUNs) > 0 {
+		luns := make([]uint8, 0)
+		for _, v := range f.ownerLUNs {
+			i, err := parseStringToInt64(v)
+			if err != nil || i < 0 || i > 3 {
+				return nil, fmt.Errorf("invalid owner lun (%s), should be in [0, 3]", v)
+			}
+			luns = append(luns, uint8(i))
+		}
+		sdrFilterOptions = append(sdrFilterOptions, ipmi.SDRFilterOptionOwnerLUN(luns...))
+	}
+
+	if len(f.readingTypes) > 0 {
+		readingTypeFilterOptions := make([]ipmi.SDRFilterOption, 0)
+		for _, v := range f.readingTypes {
+			switch v {
+			case "threshold":
+				readingTypeFilterOptions = append(readingTypeFilterOptions, ipmi.SDRFilterOptionIsThreshold)
+			case "discrete":
+				readingTypeFilterOptions = append(readingTypeFilterOptions, func(sdr *ipmi.SDR) bool {
+					return !ipmi.SDRFilterOptionIsThreshold(sdr)
+				})
+			default:
+				i, err := parseStringToInt64(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid event/reading type (%s), supported (threshold,discrete,<code>)", v)
+				}
+				readingTypeFilterOptions = append(readingTypeFilterOptions, ipmi.SDRFilterOptionEventReadingType(ipmi.EventReadingType(i)))
+			}
+		}
+		sdrFilterOptions = append(sdrFilterOptions, anySDRFilterOption(readingTypeFilterOptions...))
+	}
+
+	if f.nameGlob != "" {
+		filterOption, err := ipmi.SDRFilterOptionNameGlob(f.nameGlob)
+		if err != nil {
+			return nil, err
+		}
+		sdrFilterOptions = append(sdrFilterOptions, filterOption)
+	}
+
+	if f.nameRegexp != "" {
+		re, err := regexp.Compile(f.nameRegexp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensor name regexp (%s), err: %s", f.nameRegexp, err)
+		}
+		sdrFilterOptions = append(sdrFilterOptions, ipmi.SDRFilterOptionNameRegexp(re))
+	}
+
+	return sdrFilterOptions, nil
+}
+
+func NewCmdSensorWatch() *cobra.Command {
+	var interval time.Duration
+	var sensorIntervals []string
+	var maxBackoff time.Duration
+	var filterFlags sensorFilterFlags
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "watch sensors and print the threshold crossings and state changes",
+		Run: func(cmd *cobra.Command, args []string) {
+			sdrFilterOptions, err := filterFlags.sdrFilterOptions()
+			if err != nil {
+				CheckErr(err)
+			}
+
+			if interval <= 0 {
+				CheckErr(fmt.Errorf("invalid interval (%s), should be positive", interval))
+			}
+			if maxBackoff < interval {
+				CheckErr(fmt.Errorf("invalid max backoff (%s), should not be less than the interval (%s)", maxBackoff, interval))
+			}
+
+			watcher := client.NewSensorWatcher(interval, sdrFilterOptions...).WithMaxBackoff(maxBackoff)
+
+			for _, v := range sensorIntervals {
+				parts := strings.SplitN(v, "=", 2)
+				if len(parts) != 2 {
+					CheckErr(fmt.Errorf("invalid sensor interval (%s), should be <name pattern>=<interval>", v))
+				}
+				filterOption, err := ipmi.SDRFilterOptionNameGlob(parts[0])
+				if err != nil {
+					CheckErr(err)
+				}
+				d, err := time.ParseDuration(parts[1])
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid sensor interval (%s), err: %s", v, err))
+				}
+				if d <= 0 {
+					CheckErr(fmt.Errorf("invalid sensor interval (%s), should be positive", v))
+				}
+				watcher.WithInterval(d, filterOption)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- watcher.Run(ctx)
+			}()
+
+			for event := range watcher.Events() {
+				printStreamOutput(event.String(), event)
+			}
+
+			if err := <-errCh; err != nil {
+				CheckErr(fmt.Errorf("SensorWatcher failed, err: %s", err))
+			}
+		},
+	}
+
+	cmd.PersistentFlags().DurationVarP(&interval, "interval", "i", ipmi.DefaultSensorWatchInterval, "poll interval")
+	cmd.PersistentFlags().StringArrayVarP(&sensorIntervals, "sensor-interval", "", nil, "poll interval for sensors by name pattern, like 'FAN*=2s', can be repeated")
+	cmd.PersistentFlags().DurationVarP(&maxBackoff, "max-backoff", "", ipmi.DefaultSensorWatchMaxBackoff, "max poll interval when reading a sensor keeps failing")
+	filterFlags.addFlags(cmd)
 
 	return cmd
 }
 
+// anySDRFilterOption returns a filter option which passes if any of filterOptions passes.
+func anySDRFilterOption(filterOptions ...ipmi.SDRFilterOption) ipmi.SDRFilterOption {
+	return func(sdr *ipmi.SDR) bool {
+		for _, filterOption := range filterOptions {
+			if filterOption(sdr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// parseEntity parses entity in the form of <id>[.<instance>].
+func parseEntity(s string) (ipmi.EntityID, []ipmi.EntityInstance, error) {
+	parts := strings.SplitN(s, ".", 2)
+	id, err := parseStringToInt64(parts[0])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid entity id (%s), err: %s", parts[0], err)
+	}
+
+	instances := []ipmi.EntityInstance{}
+	if len(parts) == 2 {
+		instance, err := parseStringToInt64(parts[1])
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid entity instance (%s), err: %s", parts[1], err)
+		}
+		instances = append(instances, ipmi.EntityInstance(instance))
+	}
+	return ipmi.EntityID(id), instances, nil
+}
+
 func NewCmdSensorGet() *cobra.Command {
 	usage := `sensor get <sensorNumber> or <sensorName>, sensorName should be quoted if contains space`
 
@@ -91,37 +343,122 @@ func NewCmdSensorGet() *cobra.Command {
 		Use:   "get",
 		Short: "get",
 		Run: func(cmd *cobra.Command, args []string) {
-			var sensorNumber uint8
-
 			if len(args) < 1 {
 				CheckErr(fmt.Errorf("no Sensor ID or Sensor Name supplied, usage: %s", usage))
 			}
 
-			var sensor *ipmi.Sensor
-			var err error
-
-			id, err := parseStringToInt64(args[0])
+			sensor, err := getSensor(args[0])
 			if err != nil {
-				// suppose args is sensor name
-				sensor, err = client.GetSensorByName(args[0])
-				if err != nil {
-					CheckErr(fmt.Errorf("GetSensorByName failed, err: %s", err))
+				CheckErr(err)
+			}
+
+			client.Debug("sensor", sensor)
+			printSensor(sensor)
+		},
+	}
+	return cmd
+}
+
+// getSensor returns the sensor by sensor number or sensor name.
+func getSensor(numberOrName string) (*ipmi.Sensor, error) {
+	id, err := parseStringToInt64(numberOrName)
+	if err != nil {
+		// suppose args is sensor name
+		sensor, err := client.GetSensorByName(numberOrName)
+		if err != nil {
+			return nil, fmt.Errorf("GetSensorByName failed, err: %s", err)
+		}
+		return sensor, nil
+	}
+
+	sensor, err := client.GetSensorByID(uint8(id))
+	if err != nil {
+		return nil, fmt.Errorf("GetSensorByID failed, err: %s", err)
+	}
+	return sensor, nil
+}
+
+func NewCmdSensorThresh() *cobra.Command {
+	usage := `sensor thresh <id> <threshold> <setting>
+    id        : name or number of the sensor for which threshold is to be set
+    threshold : which threshold to set
+                  unr = upper non-recoverable
+                  ucr = upper critical
+                  unc = upper non-critical
+                  lnc = lower non-critical
+                  lcr = lower critical
+                  lnr = lower non-recoverable
+    setting   : the value to set the threshold to
+
+sensor thresh <id> lower <lnr> <lcr> <lnc>
+    Set all lower thresholds at the same time
+
+sensor thresh <id> upper <unc> <ucr> <unr>
+    Set all upper thresholds at the same time`
+
+	cmd := &cobra.Command{
+		Use:   "thresh",
+		Short: "set sensor thresholds",
+		Long:  usage,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 3 {
+				CheckErr(fmt.Errorf("usage: %s", usage))
+			}
+
+			var thresholdTypes []ipmi.SensorThresholdType
+			switch args[1] {
+			case "lower":
+				thresholdTypes = []ipmi.SensorThresholdType{ipmi.SensorThresholdType_LNR, ipmi.SensorThresholdType_LCR, ipmi.SensorThresholdType_LNC}
+			case "upper":
+				thresholdTypes = []ipmi.SensorThresholdType{ipmi.SensorThresholdType_UNC, ipmi.SensorThresholdType_UCR, ipmi.SensorThresholdType_UNR}
+			default:
+				thresholdType, ok := sensorThresholdTypes[args[1]]
+				if !ok {
+					CheckErr(fmt.Errorf("invalid threshold (%s), usage: %s", args[1], usage))
 				}
-			} else {
-				sensorNumber = uint8(id)
-				sensor, err = client.GetSensorByID(sensorNumber)
+				thresholdTypes = []ipmi.SensorThresholdType{thresholdType}
+			}
+
+			if len(args) != 2+len(thresholdTypes) {
+				CheckErr(fmt.Errorf("usage: %s", usage))
+			}
+
+			values := make(map[ipmi.SensorThresholdType]float64)
+			for i, thresholdType := range thresholdTypes {
+				v, err := strconv.ParseFloat(args[2+i], 64)
 				if err != nil {
-					CheckErr(fmt.Errorf("GetSensorByID failed, err: %s", err))
+					CheckErr(fmt.Errorf("invalid %s threshold value (%s), err: %s", thresholdType.Abbr(), args[2+i], err))
 				}
+				values[thresholdType] = v
 			}
 
-			client.Debug("sensor", sensor)
-			fmt.Println(sensor)
+			printStatus("Locating sensor record '%s'...", args[0])
+			sensor, err := getSensor(args[0])
+			if err != nil {
+				CheckErr(err)
+			}
+
+			for _, thresholdType := range thresholdTypes {
+				printStatus("Setting sensor \"%s\" %s threshold to %.3f", sensor.Name, thresholdType, values[thresholdType])
+			}
+
+			if _, err := client.SetSensorThresholdValues(sensor, values); err != nil {
+				CheckErr(fmt.Errorf("SetSensorThresholdValues failed, err: %s", err))
+			}
 		},
 	}
 	return cmd
 }
 
+var sensorThresholdTypes = map[string]ipmi.SensorThresholdType{
+	"unr": ipmi.SensorThresholdType_UNR,
+	"ucr": ipmi.SensorThresholdType_UCR,
+	"unc": ipmi.SensorThresholdType_UNC,
+	"lnc": ipmi.SensorThresholdType_LNC,
+	"lcr": ipmi.SensorThresholdType_LCR,
+	"lnr": ipmi.SensorThresholdType_LNR,
+}
+
 func NewCmdSensorThreshold() *cobra.Command {
 	usage := `
 sensor threshold get <sensor_number>
@@ -149,7 +486,7 @@ sensor threshold get <sensor_number>
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorThresholds failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "set":
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
@@ -187,7 +524,7 @@ sensor event-status get <sensor_number>
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorEventStatus failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "set":
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
@@ -198,12 +535,21 @@ sensor event-status get <sensor_number>
 }
 
 func NewCmdSensorEventEnable() *cobra.Command {
+	var enableEvents []string
+	var disableEvents []string
+	var messages string
+
 	usage := `
-sensor event-enable get <sensor_number>
+sensor event-enable get <sensor_number or sensor_name>
+sensor event-enable set <sensor_number or sensor_name> [--messages on|off] [--enable <events>] [--disable <events>]
+    events : comma separated event names, both the assertion and deassertion events are set
+             threshold sensors: unc+, ucr-, lnr, ...
+             discrete sensors : state0, state1, ..., or the event names like "Presence detected"
 	`
 	cmd := &cobra.Command{
 		Use:   "event-enable ",
 		Short: "event-enable ",
+		Long:  usage,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 2 {
 				CheckErr(fmt.Errorf("usage: %s", usage))
@@ -211,26 +557,94 @@ sensor event-enable get <sensor_number>
 
 			action := args[0]
 
-			var sensorNumber uint8
-			i, err := parseStringToInt64(args[1])
-			if err != nil {
-				CheckErr(fmt.Errorf("invalid sensor number, err: %s", err))
-			}
-			sensorNumber = uint8(i)
-
 			switch action {
 			case "get":
-				res, err := client.GetSensorEventEnable(sensorNumber)
+				sensor, err := getSensor(args[1])
+				if err != nil {
+					CheckErr(err)
+				}
+				res, err := client.GetSensorEventEnableBySensor(sensor)
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorEventEnable failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
+
 			case "set":
+				if messages == "" && len(enableEvents) == 0 && len(disableEvents) == 0 {
+					CheckErr(fmt.Errorf("nothing to set, usage: %s", usage))
+				}
+
+				sensor, err := getSensor(args[1])
+				if err != nil {
+					CheckErr(err)
+				}
+
+				switch messages {
+				case "":
+				case "on", "off":
+					if err := client.SetSensorEventMessages(sensor, messages == "on"); err != nil {
+						CheckErr(fmt.Errorf("SetSensorEventMessages failed, err: %s", err))
+					}
+				default:
+					CheckErr(fmt.Errorf("invalid --messages (%s), supported (on,off)", messages))
+				}
+
+				if len(enableEvents) > 0 {
+					if err := client.EnableSensorEventsByName(sensor, enableEvents...); err != nil {
+						CheckErr(fmt.Errorf("EnableSensorEventsByName failed, err: %s", err))
+					}
+				}
+
+				if len(disableEvents) > 0 {
+					if err := client.DisableSensorEventsByName(sensor, disableEvents...); err != nil {
+						CheckErr(fmt.Errorf("DisableSensorEventsByName failed, err: %s", err))
+					}
+				}
+
+				res, err := client.GetSensorEventEnableBySensor(sensor)
+				if err != nil {
+					CheckErr(fmt.Errorf("GetSensorEventEnable failed, err: %s", err))
+				}
+				printOutput(res.Format(), res)
+
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
 			}
 		},
 	}
+
+	cmd.PersistentFlags().StringSliceVarP(&enableEvents, "enable", "", nil, "events to enable")
+	cmd.PersistentFlags().StringSliceVarP(&disableEvents, "disable", "", nil, "events to disable")
+	cmd.PersistentFlags().StringVarP(&messages, "messages", "", "", "enable (on) or disable (off) all event messages from the sensor")
+
+	return cmd
+}
+
+func NewCmdSensorRearm() *cobra.Command {
+	usage := `
+sensor rearm <sensor_number or sensor_name> [<event> ...]
+    Re-arm the events of the sensor, all events are re-armed if no events are specified.
+    See "sensor event-enable" for the event names.
+	`
+	cmd := &cobra.Command{
+		Use:   "rearm",
+		Short: "rearm",
+		Long:  usage,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(fmt.Errorf("usage: %s", usage))
+			}
+
+			sensor, err := getSensor(args[0])
+			if err != nil {
+				CheckErr(err)
+			}
+
+			if err := client.RearmSensorEventsByName(sensor, args[1:]...); err != nil {
+				CheckErr(fmt.Errorf("RearmSensorEventsByName failed, err: %s", err))
+			}
+		},
+	}
 	return cmd
 }
 
@@ -261,7 +675,7 @@ sensor reading get <sensor_number>
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorReading failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			case "set":
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
@@ -298,13 +712,16 @@ sensor reading-factors get <sensor_number>
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorReading failed, err: %s", err))
 				}
-				fmt.Println(res0.Format())
 
 				res, err := client.GetSensorReadingFactors(sensorNumber, res0.AnalogReading)
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSensorReadingFactors failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+
+				printOutput(res0.Format()+"\n"+res.Format(), struct {
+					Reading        *ipmi.GetSensorReadingResponse
+					ReadingFactors *ipmi.GetSensorReadingFactorsResponse
+				}{res0, res})
 			case "set":
 			default:
 				CheckErr(fmt.Errorf("usage: %s", usage))
@@ -335,8 +752,15 @@ func NewCmdSensorDetail() *cobra.Command {
 					CheckErr(fmt.Errorf("GetSensorByID failed, err: %s", err))
 				}
 			}
-			fmt.Println(sensor)
+			printSensor(sensor)
 		},
 	}
 	return cmd
 }
+
+// printSensor prints the sensor in the output format.
+func printSensor(sensor *ipmi.Sensor) {
+	printRecords(func() string {
+		return fmt.Sprint(sensor)
+	}, []ipmi.OutputRecord{ipmi.NewSensorOutput(sensor)})
+}
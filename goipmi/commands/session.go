@@ -45,7 +45,7 @@ func NewCmdSessionInfo() *cobra.Command {
 				if err != nil {
 					CheckErr(fmt.Errorf("GetSessionInfo failed, err: %s", err))
 				}
-				fmt.Println(res.Format())
+				printOutput(res.Format(), res)
 			}
 		},
 	}
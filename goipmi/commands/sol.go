@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +22,8 @@ func NewCmdSOL() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(NewCmdSOLInfo())
+	cmd.AddCommand(NewCmdSOLSet())
+	cmd.AddCommand(NewCmdSOLPayload())
 
 	return cmd
 }
@@ -33,8 +37,330 @@ func NewCmdSOLInfo() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetDeviceID failed, err: %s", err))
 			}
-			fmt.Println(sol.Format())
+			printOutput(sol.Format(), sol)
 		},
 	}
 	return cmd
 }
+
+func NewCmdSOLPayload() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "payload",
+		Short: "payload",
+		Run: func(cmd *cobra.Command, args []string) {
+		},
+	}
+	cmd.AddCommand(NewCmdSOLPayloadStatus())
+	cmd.AddCommand(NewCmdSOLPayloadSet())
+	cmd.AddCommand(NewCmdSOLPayloadInfo())
+
+	return cmd
+}
+
+// parseChannelUserID parses the optional [<channel number> [<user id>]] args,
+// channel number defaults to 0x0e (current channel), user id defaults to 1.
+func parseChannelUserID(args []string) (channelNumber uint8, userID uint8) {
+	channelNumber = 0x0e
+	userID = 0x01
+
+	if len(args) > 0 {
+		id, err := parseStringToInt64(args[0])
+		if err != nil {
+			CheckErr(fmt.Errorf("invalid channel number passed, err: %s", err))
+		}
+		channelNumber = uint8(id)
+	}
+
+	if len(args) > 1 {
+		id, err := parseStringToInt64(args[1])
+		if err != nil {
+			CheckErr(fmt.Errorf("invalid user id passed, err: %s", err))
+		}
+		userID = uint8(id)
+	}
+	return
+}
+
+func NewCmdSOLPayloadStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [<channel number> [<user id>]]",
+		Short: "status [<channel number> [<user id>]]",
+		Run: func(cmd *cobra.Command, args []string) {
+			channelNumber, userID := parseChannelUserID(args)
+
+			res, err := client.GetUserPayloadAccess(channelNumber, userID)
+			if err != nil {
+				CheckErr(fmt.Errorf("GetUserPayloadAccess failed, err: %s", err))
+			}
+			status := "disabled"
+			if res.Enabled(ipmi.PayloadTypeSOL) {
+				status = "enabled"
+			}
+			instances, err := client.GetPayloadInstances(ipmi.PayloadTypeSOL)
+			if err != nil {
+				CheckErr(fmt.Errorf("GetPayloadInstances failed, err: %s", err))
+			}
+
+			table := fmt.Sprintf("User %d on channel %d is %s to use SOL\n%s", userID, channelNumber, status, ipmi.FormatPayloadInstances(instances))
+			printOutput(table, struct {
+				ChannelNumber uint8
+				UserID        uint8
+				SOLStatus     string
+				Instances     []*ipmi.PayloadInstance
+			}{channelNumber, userID, status, instances})
+		},
+	}
+	return cmd
+}
+
+func NewCmdSOLPayloadSet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <enable|disable> [<channel number> [<user id>]]",
+		Short: "set <enable|disable> [<channel number> [<user id>]]",
+		Args:  cobra.RangeArgs(1, 3),
+		Run: func(cmd *cobra.Command, args []string) {
+			var enabled bool
+			switch args[0] {
+			case "enable":
+				enabled = true
+			case "disable":
+				enabled = false
+			default:
+				CheckErr(fmt.Errorf("invalid action %s, must be enable or disable", args[0]))
+			}
+
+			channelNumber, userID := parseChannelUserID(args[1:])
+			if err := client.SetUserPayloadEnabled(channelNumber, userID, enabled, ipmi.PayloadTypeSOL); err != nil {
+				CheckErr(fmt.Errorf("SetUserPayloadEnabled failed, err: %s", err))
+			}
+		},
+	}
+	return cmd
+}
+
+func NewCmdSOLPayloadInfo() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info [<channel number>]",
+		Short: "info [<channel number>]",
+		Run: func(cmd *cobra.Command, args []string) {
+			channelNumber, _ := parseChannelUserID(args)
+
+			payloads, err := client.GetChannelPayloads(channelNumber)
+			if err != nil {
+				CheckErr(fmt.Errorf("GetChannelPayloads failed, err: %s", err))
+			}
+			printOutput(ipmi.FormatChannelPayloads(payloads), payloads)
+		},
+	}
+	return cmd
+}
+
+func NewCmdSOLSet() *cobra.Command {
+	long := `Parameters and values:
+  set-in-progress             set-complete | set-in-progress | commit-write
+  enabled                     true | false
+  force-encryption            true | false
+  force-authentication        true | false
+  privilege-level             user | operator | admin | oem
+  character-accumulate-level  <in 5 ms increments>
+  character-send-threshold    N
+  retry-count                 0-7
+  retry-interval              <in 10 ms increments>
+  non-volatile-bit-rate       serial | 9.6 | 19.2 | 38.4 | 57.6 | 115.2
+  volatile-bit-rate           serial | 9.6 | 19.2 | 38.4 | 57.6 | 115.2`
+
+	cmd := &cobra.Command{
+		Use:   "set <parameter> <value> [<channel number>]",
+		Short: "set <parameter> <value> [<channel number>]",
+		Long:  long,
+		Args:  cobra.RangeArgs(2, 3),
+		Run: func(cmd *cobra.Command, args []string) {
+			param, value := args[0], args[1]
+
+			var channelNumber uint8 = 0x0e
+			if len(args) > 2 {
+				id, err := parseStringToInt64(args[2])
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid channel number passed, err: %s", err))
+				}
+				channelNumber = uint8(id)
+			}
+
+			if param == "set-in-progress" {
+				m := map[string]ipmi.SOLConfigParam_SetInProgress{
+					"set-complete":    ipmi.SOLSetComplete,
+					"set-in-progress": ipmi.SOLSetInProgress,
+					"commit-write":    ipmi.SOLCommitWrite,
+				}
+				v, ok := m[value]
+				if !ok {
+					CheckErr(fmt.Errorf("invalid value %s for %s", value, param))
+				}
+				if err := client.SetSOLConfigParam(channelNumber, ipmi.SOLConfigParamSelector_SetInProgress, &ipmi.SOLConfigParam{SetInProgress: &v}); err != nil {
+					CheckErr(fmt.Errorf("SetSOLConfigParam failed, err: %s", err))
+				}
+				return
+			}
+
+			// some parameters share the same parameter data, so read the current value first.
+			// Only the changed parameter is read, the BMC may not support the other optional parameters.
+			paramSelector, ok := solSetParamSelectors[param]
+			if !ok {
+				CheckErr(fmt.Errorf("unknown parameter %s", param))
+			}
+			res, err := client.GetSOLConfigParams(channelNumber, paramSelector)
+			if err != nil {
+				CheckErr(fmt.Errorf("GetSOLConfigParams for %s failed, err: %s", paramSelector, err))
+			}
+			current := &ipmi.SOLConfigParam{}
+			if err := ipmi.ParseSOLParamData(paramSelector, res.ParameterData, current); err != nil {
+				CheckErr(err)
+			}
+
+			solConfigParam, err := parseSOLSetParam(current, param, value)
+			if err != nil {
+				CheckErr(err)
+			}
+
+			unsupported, err := client.SetSOLConfig(channelNumber, solConfigParam)
+			if err != nil {
+				CheckErr(fmt.Errorf("SetSOLConfig failed, err: %s", err))
+			}
+			if len(unsupported) > 0 {
+				CheckErr(fmt.Errorf("parameter %s (%s) is not supported or read-only on the BMC", param, paramSelector))
+			}
+		},
+	}
+	return cmd
+}
+
+// solSetParamSelectors maps the parameters of sol set to the SOL configuration parameter selectors.
+var solSetParamSelectors = map[string]ipmi.SOLConfigParamSelector{
+	"enabled":                    ipmi.SOLConfigParamSelector_SOLEnable,
+	"force-encryption":           ipmi.SOLConfigParamSelector_SOLAuthentication,
+	"force-authentication":       ipmi.SOLConfigParamSelector_SOLAuthentication,
+	"privilege-level":            ipmi.SOLConfigParamSelector_SOLAuthentication,
+	"character-accumulate-level": ipmi.SOLConfigParamSelector_Character,
+	"character-send-threshold":   ipmi.SOLConfigParamSelector_Character,
+	"retry-count":                ipmi.SOLConfigParamSelector_SOLRetry,
+	"retry-interval":             ipmi.SOLConfigParamSelector_SOLRetry,
+	"non-volatile-bit-rate":      ipmi.SOLConfigParamSelector_NonVolatileBitRate,
+	"volatile-bit-rate":          ipmi.SOLConfigParamSelector_VolatileBitRate,
+}
+
+// parseSOLSetParam returns a SOLConfigParam holding only the parameter to be changed,
+// the unchanged fields of the parameter data are copied from current.
+func parseSOLSetParam(current *ipmi.SOLConfigParam, param string, value string) (*ipmi.SOLConfigParam, error) {
+	parseBool := func() (bool, error) {
+		switch value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return false, fmt.Errorf("invalid value %s for %s, must be true or false", value, param)
+	}
+
+	parseUint8 := func() (uint8, error) {
+		v, err := parseStringToInt64(value)
+		if err != nil || v < 0 || v > 255 {
+			return 0, fmt.Errorf("invalid value %s for %s, must be 0-255", value, param)
+		}
+		return uint8(v), nil
+	}
+
+	parseBitRate := func() (uint8, error) {
+		if value == "serial" {
+			return ipmi.ParseSOLBitRate(0)
+		}
+		kbps, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %s for %s", value, param)
+		}
+		return ipmi.ParseSOLBitRate(kbps)
+	}
+
+	out := &ipmi.SOLConfigParam{}
+	var auth ipmi.SOLConfigParam_SOLAuthentication
+	if current.SOLAuthentication != nil {
+		auth = *current.SOLAuthentication
+	}
+	var character ipmi.SOLConfigParam_Character
+	if current.Character != nil {
+		character = *current.Character
+	}
+	var retry ipmi.SOLConfigParam_SOLRetry
+	if current.SOLRetry != nil {
+		retry = *current.SOLRetry
+	}
+
+	var err error
+	switch param {
+	case "enabled":
+		var v bool
+		v, err = parseBool()
+		enable := ipmi.SOLConfigParam_SOLEnable(v)
+		out.SOLEnable = &enable
+
+	case "force-encryption":
+		auth.ForceEncryption, err = parseBool()
+		out.SOLAuthentication = &auth
+
+	case "force-authentication":
+		auth.ForceAuthentication, err = parseBool()
+		out.SOLAuthentication = &auth
+
+	case "privilege-level":
+		m := map[string]ipmi.PrivilegeLevel{
+			"user":     ipmi.PrivilegeLevelUser,
+			"operator": ipmi.PrivilegeLevelOperator,
+			"admin":    ipmi.PrivilegeLevelAdministrator,
+			"oem":      ipmi.PrivilegeLevelOEM,
+		}
+		v, ok := m[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid value %s for %s, must be user, operator, admin or oem", value, param)
+		}
+		auth.PrivilegeLevel = uint8(v)
+		out.SOLAuthentication = &auth
+
+	case "character-accumulate-level":
+		character.AccumulateInterval5Millis, err = parseUint8()
+		out.Character = &character
+
+	case "character-send-threshold":
+		character.SendThreshold, err = parseUint8()
+		out.Character = &character
+
+	case "retry-count":
+		retry.RetryCount, err = parseUint8()
+		if err == nil && retry.RetryCount > 7 {
+			return nil, fmt.Errorf("invalid value %s for %s, must be 0-7", value, param)
+		}
+		out.SOLRetry = &retry
+
+	case "retry-interval":
+		retry.RetryInterval10Millis, err = parseUint8()
+		out.SOLRetry = &retry
+
+	case "non-volatile-bit-rate":
+		var v uint8
+		v, err = parseBitRate()
+		bitRate := ipmi.SOLConfigParam_NonVolatileBitRate(v)
+		out.NonVolatileBitRate = &bitRate
+
+	case "volatile-bit-rate":
+		var v uint8
+		v, err = parseBitRate()
+		bitRate := ipmi.SOLConfigParam_VolatileBitRate(v)
+		out.VolatileBitRate = &bitRate
+
+	default:
+		return nil, fmt.Errorf("unknown parameter %s", param)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
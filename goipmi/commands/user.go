@@ -50,7 +50,7 @@ func NewCmdUserList() *cobra.Command {
 				CheckErr(fmt.Errorf("ListUser failed, err: %s", err))
 			}
 
-			fmt.Println(ipmi.FormatUsers(users))
+			printOutput(ipmi.FormatUsers(users), users)
 		},
 	}
 	return cmd
@@ -78,7 +78,7 @@ func NewCmdUserSummary() *cobra.Command {
 			if err != nil {
 				CheckErr(fmt.Errorf("GetUserAccess failed, err: %s", err))
 			}
-			fmt.Println(res.Format())
+			printOutput(res.Format(), res)
 		},
 	}
 	return cmd
@@ -59,6 +59,14 @@ func parseTimestamp(timestamp uint32) time.Time {
 	return time.Unix(int64(timestamp), 0)
 }
 
+// timestampUnspecified is the timestamp value of invalid or unspecified time, see 37.1.
+const timestampUnspecified uint32 = 0xffffffff
+
+// isUnspecifiedTimestamp reports whether t is parsed from the unspecified timestamp value.
+func isUnspecifiedTimestamp(t time.Time) bool {
+	return t.Unix() == int64(timestampUnspecified)
+}
+
 func formatBool(b bool, trueStr string, falseStr string) string {
 	if b {
 		return trueStr
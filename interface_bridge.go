@@ -0,0 +1,163 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// responder addresses the management controller which the requests are sent to.
+// Requests are sent to the BMC (LUN 0) when Client has no responder.
+//
+// see: 6.13 BMC Message Bridging
+type responder struct {
+	// Slave Address of the management controller
+	addr uint8
+	// Channel Number of the IPMB the management controller resides on
+	channel uint8
+	lun     uint8
+}
+
+// bridged reports whether the requests to the responder must be bridged by the BMC,
+// that is the responder is a management controller other than the BMC.
+func (r *responder) bridged() bool {
+	return r != nil && r.addr != BMC_SA
+}
+
+// responderLUN returns the LUN which the requests are addressed to.
+func (r *responder) responderLUN() uint8 {
+	if r == nil {
+		return uint8(IPMB_LUN_BMC)
+	}
+	return r.lun & 0x03
+}
+
+// withResponder sends the requests made in fn to the management controller of addr
+// on channel and lun, and restores the previous responder after fn returns.
+func (c *Client) withResponder(addr uint8, channel uint8, lun uint8, fn func() error) error {
+	previous := c.responder
+	c.responder = &responder{
+		addr:    addr,
+		channel: channel,
+		lun:     lun,
+	}
+	defer func() {
+		c.responder = previous
+	}()
+
+	return fn()
+}
+
+// withSensorOwner sends the requests made in fn to the owner of the sensor,
+// which is given by the Sensor Owner ID and Sensor Owner LUN fields of the SDR.
+//
+// Sensors owned by system software (Software ID) are accessed through the BMC.
+func (c *Client) withSensorOwner(generatorID GeneratorID, fn func() error) error {
+	ownerID := generatorID.OwnerID()
+	if ownerID == 0 || ownerID&0x01 == 0x01 {
+		ownerID = BMC_SA
+	}
+
+	if ownerID == BMC_SA && generatorID.LUN() == 0 && c.responder == nil {
+		return fn()
+	}
+
+	return c.withResponder(ownerID, generatorID.Channel(), generatorID.LUN(), fn)
+}
+
+// buildBridgedRequest creates the IPMB message encapsulated in the Send Message request,
+// for the request which should be bridged to the responder.
+//
+// see: 6.13.1 Send Message Command with Response Tracking
+func (c *Client) buildBridgedRequest(request Request) *SendMessageRequest {
+	ipmbReq := &IPMIRequest{
+		ResponderAddr: c.responder.addr,
+
+		NetFn:        request.Command().NetFn,
+		ResponderLUN: c.responder.responderLUN(),
+
+		// the BMC is the requester on the IPMB
+		RequesterAddr: BMC_SA,
+
+		RequesterSequence: c.nextIPMISeq(),
+		RequesterLUN:      0x00,
+
+		Command:     request.Command().ID,
+		CommandData: request.Pack(),
+	}
+	ipmbReq.ComputeChecksum()
+	c.Debug(">>>> IPMB Request", ipmbReq)
+
+	return &SendMessageRequest{
+		ChannelNumber: c.responder.channel,
+		TrackMask:     0x01, // Track Request
+		MessageData:   ipmbReq.Pack(),
+	}
+}
+
+// exchangeLANBridged sends the request to the responder by the Send Message command of the BMC.
+//
+// The response of the responder is either carried in the data of the Send Message response,
+// or returned by the BMC as a separate message following the Send Message response.
+func (c *Client) exchangeLANBridged(request Request, response Response) error {
+	c.Debug(">> Bridged Command Request", request)
+
+	sendMessageRequest := c.buildBridgedRequest(request)
+	sendMessageResponse := &SendMessageResponse{}
+
+	r := c.responder
+	c.responder = nil
+	err := c.exchangeLAN(sendMessageRequest, sendMessageResponse)
+	c.responder = r
+	if err != nil {
+		// The *ResponseError is returned as is, so the callers can check the completion code.
+		if _, ok := err.(*ResponseError); ok {
+			c.Debug(fmt.Sprintf("SendMessage to responder (%#02x) failed", r.addr), err)
+			return err
+		}
+		return fmt.Errorf("SendMessage to responder (%#02x) failed, err: %s", r.addr, err)
+	}
+
+	if len(sendMessageResponse.Data) > 0 {
+		return c.parseBridgedResponse(sendMessageResponse.Data, response)
+	}
+
+	recv, err := c.udpClient.Receive(context.Background())
+	if err != nil {
+		return fmt.Errorf("client udp receive bridged response failed, err: %s", err)
+	}
+	c.DebugBytes("recv", recv, 16)
+
+	if err := c.ParseRmcpResponse(recv, response); err != nil {
+		return err
+	}
+
+	c.Debug("<< Bridged Command Response", response)
+	return nil
+}
+
+// parseBridgedResponse parses the IPMB response message which is carried in the data of Send Message response.
+func (c *Client) parseBridgedResponse(msg []byte, response Response) error {
+	ipmbRes := IPMIResponse{}
+	if err := ipmbRes.Unpack(msg); err != nil {
+		return fmt.Errorf("unpack ipmbRes failed, err: %s", err)
+	}
+	c.Debug("<<<< IPMB Response", ipmbRes)
+
+	ccode := ipmbRes.CompletionCode
+	if ccode != 0x00 {
+		return &ResponseError{
+			completionCode: CompletionCode(ccode),
+			description:    fmt.Sprintf("ipmbRes CompletaionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
+		}
+	}
+
+	if err := response.Unpack(ipmbRes.Data); err != nil {
+		return &ResponseError{
+			completionCode: 0x00,
+			description:    fmt.Sprintf("unpack response failed, err: %s", err),
+		}
+	}
+
+	c.Debug("<< Bridged Command Response", response)
+	return nil
+}
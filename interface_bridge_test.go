@@ -0,0 +1,235 @@
+package ipmi
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_withSensorOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		generatorID GeneratorID
+		expected    *responder
+	}{
+		{"bmc", GeneratorBMC, nil},
+		{"bmc lun 1", 0x0120, &responder{addr: BMC_SA, channel: 0, lun: 1}},
+		{"me", GeneratorIntelMEFirmware, &responder{addr: 0x2c, channel: 6, lun: 0}},
+		{"software id", GeneratorBIOSPOST, nil},
+	}
+
+	for _, test := range tests {
+		c := &Client{}
+		var got *responder
+		_ = c.withSensorOwner(test.generatorID, func() error {
+			got = c.responder
+			return nil
+		})
+
+		if test.expected == nil {
+			if got != nil {
+				t.Errorf("test %s expected no responder, got: %v", test.name, got)
+			}
+			continue
+		}
+		if got == nil || *got != *test.expected {
+			t.Errorf("test %s responder not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+		if c.responder != nil {
+			t.Errorf("test %s responder not restored", test.name)
+		}
+	}
+}
+
+func Test_buildBridgedRequest(t *testing.T) {
+	c := &Client{
+		session:   &session{ipmiSeq: 1},
+		responder: &responder{addr: 0x2c, channel: 6, lun: 0},
+	}
+
+	request := c.buildBridgedRequest(&GetSensorReadingRequest{SensorNumber: 0x10})
+
+	// channel 6 with Track Request, IPMB message: rsSA, netFn/rsLUN, cs1, rqSA, rqSeq/rqLUN, cmd, sensor number, cs2
+	expected := []byte{0x46, 0x2c, 0x10, 0xc4, 0x20, 0x04, 0x2d, 0x10, 0x9f}
+	if got := request.Pack(); !bytes.Equal(got, expected) {
+		t.Errorf("test buildBridgedRequest not matched, got: %#02x, expected: %#02x", got, expected)
+	}
+
+	if c.session.ipmiSeq != 2 {
+		t.Errorf("test buildBridgedRequest expected ipmiSeq increased, got: %d", c.session.ipmiSeq)
+	}
+}
+
+// lanResponse packs the IPMI response message in a session-less IPMI v1.5 RMCP packet.
+func lanResponse(netFn NetFn, command uint8, data []byte) []byte {
+	return lanResponseCC(netFn, command, CompletionCodeNormal, data)
+}
+
+// lanResponseCC is like lanResponse, but the response carries the completion code cc.
+func lanResponseCC(netFn NetFn, command uint8, cc CompletionCode, data []byte) []byte {
+	msg := []byte{RemoteConsole_SWID, uint8(netFn) << 2, 0x00, BMC_SA, 0x00, command, uint8(cc)}
+	msg = append(msg, data...)
+	msg = append(msg, 0x00)
+
+	session := &Session15{
+		SessionHeader15: &SessionHeader15{AuthType: AuthTypeNone, PayloadLength: uint8(len(msg))},
+		Payload:         msg,
+	}
+	return append([]byte{0x06, 0x00, 0xff, 0x07}, session.Pack()...)
+}
+
+// fakeLANPeer answers the first request on conn with the replies, and returns the request.
+func fakeLANPeer(t *testing.T, conn *net.UDPConn, replies ...[]byte) []byte {
+	buf := make([]byte, 1024)
+	n, addr, err := conn.ReadFromUDP(buf)
+	if err != nil {
+		t.Errorf("fake peer read failed, err: %s", err)
+		return nil
+	}
+	for _, reply := range replies {
+		if _, err := conn.WriteToUDP(reply, addr); err != nil {
+			t.Errorf("fake peer write failed, err: %s", err)
+			return nil
+		}
+	}
+	return buf[:n]
+}
+
+func Test_exchangeLANBridged_SeparateResponse(t *testing.T) {
+	// the Send Message response carries no data, the bridged response follows as a separate message
+	sendMessageAck := lanResponse(NetFnAppResponse, CommandSendMessage.ID, nil)
+	bridgedResponse := lanResponse(NetFnSensorEventResponse, CommandGetSensorReading.ID, []byte{0x50, 0xc0, 0x00})
+
+	sendMessageNotPresent := lanResponseCC(NetFnAppResponse, CommandSendMessage.ID, CompletionCodeRequestedDataNotPresent, nil)
+
+	tests := []struct {
+		name    string
+		replies [][]byte
+		err     string
+		cc      CompletionCode
+	}{
+		{"separate response", [][]byte{sendMessageAck, bridgedResponse}, "", 0},
+		{"separate response timeout", [][]byte{sendMessageAck}, "receive bridged response failed", 0},
+		{"send message completion code", [][]byte{sendMessageNotPresent}, "", CompletionCodeRequestedDataNotPresent},
+	}
+
+	for _, test := range tests {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			t.Fatalf("listen udp failed, err: %s", err)
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fakeLANPeer(t, conn, test.replies...)
+		}()
+
+		c, err := NewClient("127.0.0.1", conn.LocalAddr().(*net.UDPAddr).Port, "admin", "admin")
+		if err != nil {
+			t.Fatalf("test %s new client failed, err: %s", test.name, err)
+		}
+		c.v20 = false
+		c.WithTimeout(200 * time.Millisecond)
+		c.responder = &responder{addr: 0x2c, channel: 6}
+
+		response := &GetSensorReadingResponse{}
+		err = c.exchangeLAN(&GetSensorReadingRequest{SensorNumber: 0x10}, response)
+		<-done
+		c.udpClient.Close()
+		conn.Close()
+
+		if test.cc != 0 {
+			// the completion code must be kept for the callers which ignore some completion codes
+			resErr, ok := err.(*ResponseError)
+			if !ok || resErr.CompletionCode() != test.cc {
+				t.Errorf("test %s expected ResponseError (%#02x), got: %v", test.name, test.cc, err)
+			}
+			continue
+		}
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("test %s expected error (%s), got: %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+		if response.AnalogReading != 0x50 || response.ReadingUnavailable || response.EventMessagesDisabled {
+			t.Errorf("test %s response not matched, got: %+v", test.name, response)
+		}
+		if c.responder == nil || c.responder.addr != 0x2c {
+			t.Errorf("test %s responder not restored, got: %v", test.name, c.responder)
+		}
+	}
+}
+
+func Test_SensorKey(t *testing.T) {
+	sensor := &Sensor{
+		Number:      0x10,
+		GeneratorID: GeneratorIntelMEFirmware,
+	}
+
+	expected := SensorKey{OwnerID: 0x2c, OwnerLUN: 0, Number: 0x10}
+	if got := sensor.Key(); got != expected {
+		t.Errorf("test SensorKey not matched, got: %s, expected: %s", got, expected)
+	}
+
+	if sensor.Key() == GeneratorBMC.SensorKey(0x10) {
+		t.Errorf("test SensorKey expected sensors of different owners not equal")
+	}
+}
+
+func Test_RearmSensorEventsByName_Bridged(t *testing.T) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer conn.Close()
+
+	var request []byte
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		request = fakeLANPeer(t, conn,
+			lanResponse(NetFnAppResponse, CommandSendMessage.ID, nil),
+			lanResponse(NetFnSensorEventResponse, CommandRearmSensorEvents.ID, nil),
+		)
+	}()
+
+	c, err := NewClient("127.0.0.1", conn.LocalAddr().(*net.UDPAddr).Port, "admin", "admin")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	c.Interface = InterfaceLan
+	c.v20 = false
+	c.WithTimeout(200 * time.Millisecond)
+	defer c.udpClient.Close()
+
+	sensor := &Sensor{
+		Number:           0x10,
+		GeneratorID:      GeneratorIntelMEFirmware,
+		SensorType:       SensorTypeTemperature,
+		EventReadingType: EventReadingTypeThreshold,
+	}
+	err = c.RearmSensorEventsByName(sensor, "ucr")
+	<-done
+	if err != nil {
+		t.Fatalf("test RearmSensorEventsByName failed, err: %s", err)
+	}
+
+	// RMCP header (4 bytes) and IPMI v1.5 session header (10 bytes) precede the Send Message request,
+	// whose data starts with the channel 6 (Track Request) and the IPMB message to the ME (0x2c).
+	if len(request) < 23 || request[19] != CommandSendMessage.ID {
+		t.Fatalf("test expected Send Message request, got: %#02x", request)
+	}
+	if got, expected := request[20:23], []byte{0x46, 0x2c, uint8(NetFnSensorEventRequest) << 2}; !bytes.Equal(got, expected) {
+		t.Errorf("test bridged target not matched, got: %#02x, expected: %#02x", got, expected)
+	}
+	if c.responder != nil {
+		t.Errorf("test responder not restored, got: %v", c.responder)
+	}
+}
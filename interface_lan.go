@@ -695,6 +695,10 @@ func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
 }
 
 func (c *Client) exchangeLAN(request Request, response Response) error {
+	if c.responder.bridged() {
+		return c.exchangeLANBridged(request, response)
+	}
+
 	c.Debug(">> Command Request", request)
 
 	rmcp, err := c.BuildRmcpRequest(request)
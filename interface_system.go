@@ -76,8 +76,8 @@ func (c *Client) closeOpen() error {
 }
 
 func (c *Client) exchangeOpen(request Request, response Response) error {
-	if c.openipmi.targetAddr != 0 && c.openipmi.targetAddr != c.openipmi.myAddr {
-
+	if c.responder.bridged() {
+		c.Debugf("Sending request [%s] (%#02x) to IPMB addr (%#02x) on channel (%#02x)\n", request.Command().Name, request.Command().ID, c.responder.addr, c.responder.channel)
 	} else {
 		// otherwise use system interface
 		c.Debugf("Sending request [%s] (%#02x) to System Interface\n", request.Command().Name, request.Command().ID)
@@ -139,11 +139,26 @@ func (c *Client) openSendRequest(request Request) ([]byte, error) {
 	addr := &open.IPMI_SYSTEM_INTERFACE_ADDR{
 		AddrType: open.IPMI_SYSTEM_INTERFACE_ADDR_TYPE,
 		Channel:  open.IPMI_BMC_CHANNEL,
+		LUN:      c.responder.responderLUN(),
+	}
+	addrLen := unsafe.Sizeof(*addr)
+
+	if c.responder.bridged() {
+		// the driver bridges the request to the IPMB by itself,
+		// the IPMB addr is passed in place of the system interface addr.
+		ipmbAddr := &open.IPMI_IPMB_ADDR{
+			AddrType:  open.IPMI_IPMB_ADDR_TYPE,
+			Channel:   uint16(c.responder.channel),
+			SlaveAddr: c.responder.addr,
+			LUN:       c.responder.responderLUN(),
+		}
+		addr = (*open.IPMI_SYSTEM_INTERFACE_ADDR)(unsafe.Pointer(ipmbAddr))
+		addrLen = unsafe.Sizeof(*ipmbAddr)
 	}
 
 	req := &open.IPMI_REQ{
 		Addr:    addr,
-		AddrLen: int(unsafe.Sizeof(addr)),
+		AddrLen: int(addrLen),
 		MsgID:   rand.Int63(),
 		Msg:     *msg,
 	}
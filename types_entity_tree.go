@@ -0,0 +1,398 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// EntityKey identifies an entity in the system.
+//
+// Device-relative Entity Instance values (60h-7Fh) are only unique within a management controller,
+// so the controller (slave address and channel) is part of the key for them.
+// For system-relative Entity Instance values, DeviceAddress and DeviceChannel are always 0.
+type EntityKey struct {
+	EntityID       EntityID
+	EntityInstance EntityInstance
+
+	DeviceAddress uint8
+	DeviceChannel uint8
+}
+
+func newEntityKey(entityID EntityID, entityInstance EntityInstance, deviceAddress uint8, deviceChannel uint8) EntityKey {
+	key := EntityKey{
+		EntityID:       entityID,
+		EntityInstance: entityInstance & 0x7f,
+	}
+	if isEntityInstanceDeviceRelative(key.EntityInstance) {
+		key.DeviceAddress = deviceAddress & 0xfe
+		key.DeviceChannel = deviceChannel & 0x0f
+	}
+	return key
+}
+
+// String returns the entity in the form of "id.instance", like ipmitool.
+func (key EntityKey) String() string {
+	if isEntityInstanceDeviceRelative(key.EntityInstance) {
+		return fmt.Sprintf("%d.%d (controller %#02x, channel %d)", key.EntityID, key.EntityInstance, key.DeviceAddress, key.DeviceChannel)
+	}
+	return fmt.Sprintf("%d.%d", key.EntityID, key.EntityInstance)
+}
+
+func (key EntityKey) less(other EntityKey) bool {
+	if key.EntityID != other.EntityID {
+		return key.EntityID < other.EntityID
+	}
+	if key.EntityInstance != other.EntityInstance {
+		return key.EntityInstance < other.EntityInstance
+	}
+	if key.DeviceChannel != other.DeviceChannel {
+		return key.DeviceChannel < other.DeviceChannel
+	}
+	return key.DeviceAddress < other.DeviceAddress
+}
+
+// EntityNode is an entity of the EntityTree.
+type EntityNode struct {
+	Key EntityKey
+
+	// Parent is the container entity, nil if the entity is not contained by other entities.
+	Parent   *EntityNode
+	Children []*EntityNode
+
+	// Sensors holds the Full, Compact and Event-Only SDRs of sensors monitoring the entity.
+	Sensors []*SDR
+
+	// FRUs holds the FRU Device Locator SDRs of the entity.
+	FRUs []*SDR
+
+	// Devices holds the Generic Device Locator and Management Controller Device Locator SDRs of the entity.
+	Devices []*SDR
+}
+
+func (node *EntityNode) String() string {
+	return fmt.Sprintf("%s %s", node.Key, node.Key.EntityID.String())
+}
+
+// isDescendantOf reports whether node is ancestor itself or contained by ancestor.
+func (node *EntityNode) isDescendantOf(ancestor *EntityNode) bool {
+	for n := node; n != nil; n = n.Parent {
+		if n == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// EntityTree holds the entities of the system organized by containment.
+//
+// The containment is resolved from Entity Association and Device-relative Entity Association SDRs,
+// and the sensors, FRU devices and other devices are attached to the entities they are associated with.
+type EntityTree struct {
+	// Roots are the entities which are not contained by other entities.
+	Roots []*EntityNode
+
+	nodes map[EntityKey]*EntityNode
+}
+
+// NewEntityTree builds an EntityTree from the SDRs.
+func NewEntityTree(sdrs []*SDR) *EntityTree {
+	tree := &EntityTree{
+		nodes: make(map[EntityKey]*EntityNode),
+	}
+
+	for _, sdr := range sdrs {
+		if sdr == nil || sdr.RecordHeader == nil {
+			continue
+		}
+		switch sdr.RecordHeader.RecordType {
+		case SDRRecordTypeEntityAssociation:
+			tree.addEntityAssociation(sdr.EntityAssociation)
+		case SDRRecordTypeDeviceRelativeEntityAssociation:
+			tree.addDeviceRelative(sdr.DeviceRelative)
+		}
+	}
+
+	for _, sdr := range sdrs {
+		if sdr == nil || sdr.RecordHeader == nil {
+			continue
+		}
+		tree.attach(sdr)
+	}
+
+	for _, node := range tree.nodes {
+		if node.Parent == nil {
+			tree.Roots = append(tree.Roots, node)
+		}
+		sortEntityNodes(node.Children)
+	}
+	sortEntityNodes(tree.Roots)
+
+	return tree
+}
+
+func sortEntityNodes(nodes []*EntityNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Key.less(nodes[j].Key)
+	})
+}
+
+func (tree *EntityTree) node(key EntityKey) *EntityNode {
+	node, ok := tree.nodes[key]
+	if !ok {
+		node = &EntityNode{Key: key}
+		tree.nodes[key] = node
+	}
+	return node
+}
+
+func (tree *EntityTree) contain(container EntityKey, contained EntityKey) {
+	if contained.EntityID == 0 {
+		// unused contained entity field
+		return
+	}
+
+	parent := tree.node(container)
+	child := tree.node(contained)
+	if child.Parent != nil || parent.isDescendantOf(child) {
+		// an entity can only have one container, and no loop is allowed.
+		return
+	}
+	child.Parent = parent
+	parent.Children = append(parent.Children, child)
+}
+
+// containRange makes the entities in the range of [first, last] contained by container.
+// The Entity IDs of first and last must be the same.
+func (tree *EntityTree) containRange(container EntityKey, first EntityKey, last EntityKey) {
+	if first.EntityID == 0 {
+		return
+	}
+	if first.EntityID != last.EntityID || last.EntityInstance < first.EntityInstance {
+		tree.contain(container, first)
+		return
+	}
+	for instance := first.EntityInstance; instance <= last.EntityInstance; instance++ {
+		contained := first
+		contained.EntityInstance = instance
+		tree.contain(container, contained)
+	}
+}
+
+func (tree *EntityTree) addEntityAssociation(s *SDREntityAssociation) {
+	if s == nil {
+		return
+	}
+
+	// device-relative entity instances in Entity Association records are relative to the BMC.
+	key := func(id uint8, instance uint8) EntityKey {
+		return newEntityKey(EntityID(id), EntityInstance(instance), BMC_SA, 0)
+	}
+
+	container := key(s.ContainerEntityID, s.ContainerEntityInstance)
+	tree.node(container)
+
+	if s.ContainedEntitiesAsRange {
+		tree.containRange(container, key(s.ContaineredEntity1ID, s.ContaineredEntity1Instance), key(s.ContaineredEntity2ID, s.ContaineredEntity2Instance))
+		tree.containRange(container, key(s.ContaineredEntity3ID, s.ContaineredEntity3Instance), key(s.ContaineredEntity4ID, s.ContaineredEntity4Instance))
+		return
+	}
+
+	tree.contain(container, key(s.ContaineredEntity1ID, s.ContaineredEntity1Instance))
+	tree.contain(container, key(s.ContaineredEntity2ID, s.ContaineredEntity2Instance))
+	tree.contain(container, key(s.ContaineredEntity3ID, s.ContaineredEntity3Instance))
+	tree.contain(container, key(s.ContaineredEntity4ID, s.ContaineredEntity4Instance))
+}
+
+func (tree *EntityTree) addDeviceRelative(s *SDRDeviceRelative) {
+	if s == nil {
+		return
+	}
+
+	key := func(id uint8, instance uint8, address uint8, channel uint8) EntityKey {
+		return newEntityKey(EntityID(id), EntityInstance(instance), address, channel>>4)
+	}
+
+	container := key(s.ContainerEntityID, s.ContainerEntityInstance, s.ContainerEntityDeviceAddress, s.ContainerEntityDeviceChannel)
+	tree.node(container)
+
+	entity1 := key(s.ContaineredEntity1ID, s.ContaineredEntity1Instance, s.ContaineredEntity1DeviceAddress, s.ContaineredEntity1DeviceChannel)
+	entity2 := key(s.ContaineredEntity2ID, s.ContaineredEntity2Instance, s.ContaineredEntity2DeviceAddress, s.ContaineredEntity2DeviceChannel)
+	entity3 := key(s.ContaineredEntity3ID, s.ContaineredEntity3Instance, s.ContaineredEntity3DeviceAddress, s.ContaineredEntity3DeviceChannel)
+	entity4 := key(s.ContaineredEntity4ID, s.ContaineredEntity4Instance, s.ContaineredEntity4DeviceAddress, s.ContaineredEntity4DeviceChannel)
+
+	if s.ContainedEntitiesAsRange {
+		tree.containRange(container, entity1, entity2)
+		tree.containRange(container, entity3, entity4)
+		return
+	}
+
+	tree.contain(container, entity1)
+	tree.contain(container, entity2)
+	tree.contain(container, entity3)
+	tree.contain(container, entity4)
+}
+
+// attach attaches the sensor or locator SDR to the entity it is associated with.
+func (tree *EntityTree) attach(sdr *SDR) {
+	switch sdr.RecordHeader.RecordType {
+	case SDRRecordTypeFullSensor:
+		s := sdr.Full
+		if s == nil {
+			return
+		}
+		key := newEntityKey(s.SensorEntityID, s.SensorEntityInstance, uint8(s.GeneratorID), uint8(s.GeneratorID>>8)>>4)
+		node := tree.node(key)
+		node.Sensors = append(node.Sensors, sdr)
+
+	case SDRRecordTypeCompactSensor:
+		s := sdr.Compact
+		if s == nil {
+			return
+		}
+		tree.attachSharedSensor(sdr, s.GeneratorID, s.SensorEntityID, s.SensorEntityInstance, s.ShareCount, s.EntityInstanceSharing == 1)
+
+	case SDRRecordTypeEventOnly:
+		s := sdr.EventOnly
+		if s == nil {
+			return
+		}
+		tree.attachSharedSensor(sdr, s.GeneratorID, s.SensorEntityID, s.SensorEntityInstance, s.ShareCount, s.EntityInstanceSharing)
+
+	case SDRRecordTypeFRUDeviceLocator:
+		s := sdr.FRUDeviceLocator
+		if s == nil {
+			return
+		}
+		key := newEntityKey(EntityID(s.FRUEntityID), EntityInstance(s.FRUEntityInstance), s.DeviceAccessAddress, s.ChannelNumber)
+		node := tree.node(key)
+		node.FRUs = append(node.FRUs, sdr)
+
+	case SDRRecordTypeGenericLocator:
+		s := sdr.GenericDeviceLocator
+		if s == nil {
+			return
+		}
+		key := newEntityKey(EntityID(s.EntityID), EntityInstance(s.EntityInstance), s.DeviceAccessAddress, s.ChannelNumber)
+		node := tree.node(key)
+		node.Devices = append(node.Devices, sdr)
+
+	case SDRRecordTypeManagementControllerDeviceLocator:
+		s := sdr.MgmtControllerDeviceLocator
+		if s == nil {
+			return
+		}
+		key := newEntityKey(EntityID(s.EntityID), EntityInstance(s.EntityInstance), s.DeviceSlaveAddress, s.ChannelNumber)
+		node := tree.node(key)
+		node.Devices = append(node.Devices, sdr)
+	}
+}
+
+// attachSharedSensor attaches the sensor SDR which may be shared by multiple sensors.
+// If the Entity Instance increments for each shared sensor, the SDR is attached to all the entities.
+func (tree *EntityTree) attachSharedSensor(sdr *SDR, generatorID GeneratorID, entityID EntityID, entityInstance EntityInstance, shareCount uint8, instanceIncrements bool) {
+	count := 1
+	if instanceIncrements && shareCount > 1 {
+		count = int(shareCount)
+	}
+
+	for i := 0; i < count; i++ {
+		key := newEntityKey(entityID, entityInstance+EntityInstance(i), uint8(generatorID), uint8(generatorID>>8)>>4)
+		node := tree.node(key)
+		node.Sensors = append(node.Sensors, sdr)
+	}
+}
+
+// Lookup returns the entity of the key, nil if not found.
+func (tree *EntityTree) Lookup(key EntityKey) *EntityNode {
+	return tree.nodes[key]
+}
+
+// Find returns the entities of the entityID. If instances are specified,
+// only the entities of the instances are returned.
+// Device-relative entities of different controllers may share the same instance value,
+// so more than one entity could be returned even if only one instance is specified.
+func (tree *EntityTree) Find(entityID EntityID, instances ...EntityInstance) []*EntityNode {
+	out := make([]*EntityNode, 0)
+	for key, node := range tree.nodes {
+		if key.EntityID != entityID {
+			continue
+		}
+		if len(instances) == 0 {
+			out = append(out, node)
+			continue
+		}
+		for _, instance := range instances {
+			if key.EntityInstance == instance {
+				out = append(out, node)
+				break
+			}
+		}
+	}
+	sortEntityNodes(out)
+	return out
+}
+
+// Format returns the tree formatted string for print.
+func (tree *EntityTree) Format() string {
+	var buf = new(bytes.Buffer)
+	for _, root := range tree.Roots {
+		buf.WriteString(root.Format())
+	}
+	return buf.String()
+}
+
+// Format returns the tree formatted string of the entity and all its contained entities.
+//
+//	23.1 system chassis
+//	|-- 7.1 system board
+//	|   |-- 3.1 processor
+//	|   |   |-- sensor #0x01 CPU1 Temp
+//	|   |   `-- fru #1 CPU1
+//	|   `-- sensor #0x30 12V
+//	`-- fru #0 Builtin FRU
+func (node *EntityNode) Format() string {
+	var buf = new(bytes.Buffer)
+	buf.WriteString(node.String() + "\n")
+	node.format(buf, "")
+	return buf.String()
+}
+
+func (node *EntityNode) format(buf *bytes.Buffer, prefix string) {
+	items := make([]string, 0, len(node.Sensors)+len(node.FRUs)+len(node.Devices))
+	for _, sdr := range node.Sensors {
+		items = append(items, fmt.Sprintf("sensor #%#02x %s", sdr.SensorNumber(), sdr.SensorName()))
+	}
+	for _, sdr := range node.FRUs {
+		s := sdr.FRUDeviceLocator
+		items = append(items, fmt.Sprintf("fru #%d %s", s.FRUDeviceID_SlaveAddress, string(s.DeviceIDBytes)))
+	}
+	for _, sdr := range node.Devices {
+		switch sdr.RecordHeader.RecordType {
+		case SDRRecordTypeGenericLocator:
+			s := sdr.GenericDeviceLocator
+			items = append(items, fmt.Sprintf("device %#02x %s", s.DeviceSlaveAddress, string(s.DeviceIDString)))
+		case SDRRecordTypeManagementControllerDeviceLocator:
+			s := sdr.MgmtControllerDeviceLocator
+			items = append(items, fmt.Sprintf("controller %#02x %s", s.DeviceSlaveAddress, string(s.DeviceIDBytes)))
+		}
+	}
+
+	total := len(items) + len(node.Children)
+	branch := func(i int) (string, string) {
+		if i == total-1 {
+			return prefix + "`-- ", prefix + "    "
+		}
+		return prefix + "|-- ", prefix + "|   "
+	}
+
+	for i, item := range items {
+		line, _ := branch(i)
+		buf.WriteString(line + item + "\n")
+	}
+	for i, child := range node.Children {
+		line, childPrefix := branch(len(items) + i)
+		buf.WriteString(line + child.String() + "\n")
+		child.format(buf, childPrefix)
+	}
+}
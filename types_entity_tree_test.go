@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func Test_EntityTree(t *testing.T) {
+	sdrs := make([]*SDR, 0, len(syntheticSDRRecords))
+	for _, record := range syntheticSDRRecords {
+		raw, _ := hex.DecodeString(record.raw)
+		sdr, err := ParseSDR(raw, 0xffff)
+		if err != nil {
+			t.Fatalf("test %s ParseSDR failed, err: %s", record.name, err)
+		}
+		sdrs = append(sdrs, sdr)
+	}
+
+	tree := NewEntityTree(sdrs)
+
+	tests := []struct {
+		name     string
+		key      EntityKey
+		parent   string
+		children int
+		sensors  []string
+		frus     int
+		devices  int
+	}{
+		{"chassis", EntityKey{EntityID: 0x17, EntityInstance: 1}, "", 4, nil, 0, 0},
+		{"system board", EntityKey{EntityID: 0x07, EntityInstance: 1}, "23.1", 8, []string{"12V", "Airflow"}, 0, 1},
+		{"processor", EntityKey{EntityID: 0x03, EntityInstance: 1}, "23.1", 0, []string{"CPU1 Temp"}, 0, 0},
+		{"power supply", EntityKey{EntityID: 0x0a, EntityInstance: 1}, "23.1", 0, []string{"PS1 Status"}, 1, 0},
+		{"memory device in range", EntityKey{EntityID: 0x20, EntityInstance: 8}, "7.1", 0, nil, 0, 0},
+		{"shared sensor instance", EntityKey{EntityID: 0x20, EntityInstance: 3}, "7.1", 0, []string{"DIMM"}, 0, 0},
+		{"device-relative", EntityKey{EntityID: 0x03, EntityInstance: 0x62, DeviceAddress: 0x20}, "23.97 (controller 0x20, channel 0)", 0, nil, 0, 0},
+		{"controller", EntityKey{EntityID: 0x2e, EntityInstance: 1}, "", 0, []string{"Watchdog"}, 0, 1},
+	}
+
+	for _, test := range tests {
+		node := tree.Lookup(test.key)
+		if node == nil {
+			t.Errorf("test %s entity %s not found", test.name, test.key)
+			continue
+		}
+
+		var parent string
+		if node.Parent != nil {
+			parent = node.Parent.Key.String()
+		}
+		if parent != test.parent {
+			t.Errorf("test %s parent not matched, got: %q, expected: %q", test.name, parent, test.parent)
+		}
+		if len(node.Children) != test.children {
+			t.Errorf("test %s children not matched, got: %d, expected: %d", test.name, len(node.Children), test.children)
+		}
+		if len(node.Sensors) != len(test.sensors) {
+			t.Errorf("test %s sensors not matched, got: %d, expected: %d", test.name, len(node.Sensors), len(test.sensors))
+		} else {
+			for i, sdr := range node.Sensors {
+				if sdr.SensorName() != test.sensors[i] {
+					t.Errorf("test %s sensor not matched, got: %s, expected: %s", test.name, sdr.SensorName(), test.sensors[i])
+				}
+			}
+		}
+		if len(node.FRUs) != test.frus || len(node.Devices) != test.devices {
+			t.Errorf("test %s frus/devices not matched, got: %d/%d, expected: %d/%d", test.name, len(node.FRUs), len(node.Devices), test.frus, test.devices)
+		}
+	}
+
+	if got := len(tree.Find(0x20)); got != 8 {
+		t.Errorf("test find memory devices not matched, got: %d, expected: 8", got)
+	}
+}
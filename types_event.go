@@ -1,6 +1,10 @@
 package ipmi
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // 31.6.1 SEL Record Type Ranges
 type SELRecordType uint8
@@ -79,6 +83,37 @@ func (ed *EventData) EventReadingOffset() uint8 {
 	return ed.EventData1 & 0x0f
 }
 
+// EventDataUsage indicates how Event Data 2 and Event Data 3 are used.
+//
+// see: 29.7 Event Data Field Formats
+type EventDataUsage uint8
+
+const (
+	EventDataUsageUnspecified EventDataUsage = 0x00
+
+	// For threshold sensors, Event Data 2 holds the trigger reading, Event Data 3 holds the trigger threshold value.
+	// For discrete sensors, Event Data 2 holds the previous state and/or severity, Event Data 3 is reserved.
+	EventDataUsageStandard EventDataUsage = 0x01
+
+	EventDataUsageOEM EventDataUsage = 0x02
+
+	// The sensor-specific event extension code, defined in Table 42-3, Sensor Type Codes and Data.
+	// It is reserved for OEM sensors.
+	EventDataUsageSensorSpecific EventDataUsage = 0x03
+)
+
+// ED2Usage returns the usage of Event Data 2.
+// Event Data 1 [7:6]
+func (ed *EventData) ED2Usage() EventDataUsage {
+	return EventDataUsage(ed.EventData1 >> 6)
+}
+
+// ED3Usage returns the usage of Event Data 3.
+// Event Data 1 [5:4]
+func (ed *EventData) ED3Usage() EventDataUsage {
+	return EventDataUsage((ed.EventData1 >> 4) & 0x03)
+}
+
 func (ed *EventData) String() string {
 	return fmt.Sprintf("%02x%02x%02x", ed.EventData1, ed.EventData2, ed.EventData3)
 }
@@ -288,6 +323,8 @@ type Event struct {
 	AssertionSeverity   EventSeverity
 	DeassertionSeverity EventSeverity
 
+	// The descriptions of the sensor-specific Event Data 2 and Event Data 3 values of the event,
+	// nil if the event does not define the values.
 	ED2 map[uint8]string
 	ED3 map[uint8]string
 }
@@ -785,7 +822,7 @@ func (flag *SensorEventFlag) TrueEvents() []SensorEvent {
 		out = append(out, SensorEvent_UNC_High_Assert)
 	}
 	if flag.SensorEvent_UNC_Low_Assert {
-		out = append(out, SensorEvent_UNC_Low_Deassert)
+		out = append(out, SensorEvent_UNC_Low_Assert)
 	}
 	if flag.SensorEvent_LNR_High_Assert {
 		out = append(out, SensorEvent_LNR_High_Assert)
@@ -945,3 +982,206 @@ func (flag *SensorEventFlag) TrueEvents() []SensorEvent {
 	}
 	return out
 }
+
+// fields returns the pointers to the fields of the SensorEventFlag indexed by the SensorEvent.
+func (flag *SensorEventFlag) fields() map[SensorEvent]*bool {
+	return map[SensorEvent]*bool{
+		SensorEvent_UNC_High_Assert:   &flag.SensorEvent_UNC_High_Assert,
+		SensorEvent_UNC_Low_Assert:    &flag.SensorEvent_UNC_Low_Assert,
+		SensorEvent_LNR_High_Assert:   &flag.SensorEvent_LNR_High_Assert,
+		SensorEvent_LNR_Low_Assert:    &flag.SensorEvent_LNR_Low_Assert,
+		SensorEvent_LCR_High_Assert:   &flag.SensorEvent_LCR_High_Assert,
+		SensorEvent_LCR_Low_Assert:    &flag.SensorEvent_LCR_Low_Assert,
+		SensorEvent_LNC_High_Assert:   &flag.SensorEvent_LNC_High_Assert,
+		SensorEvent_LNC_Low_Assert:    &flag.SensorEvent_LNC_Low_Assert,
+		SensorEvent_State_7_Assert:    &flag.SensorEvent_State_7_Assert,
+		SensorEvent_State_6_Assert:    &flag.SensorEvent_State_6_Assert,
+		SensorEvent_State_5_Assert:    &flag.SensorEvent_State_5_Assert,
+		SensorEvent_State_4_Assert:    &flag.SensorEvent_State_4_Assert,
+		SensorEvent_State_3_Assert:    &flag.SensorEvent_State_3_Assert,
+		SensorEvent_State_2_Assert:    &flag.SensorEvent_State_2_Assert,
+		SensorEvent_State_1_Assert:    &flag.SensorEvent_State_1_Assert,
+		SensorEvent_State_0_Assert:    &flag.SensorEvent_State_0_Assert,
+		SensorEvent_UNR_High_Assert:   &flag.SensorEvent_UNR_High_Assert,
+		SensorEvent_UNR_Low_Assert:    &flag.SensorEvent_UNR_Low_Assert,
+		SensorEvent_UCR_High_Assert:   &flag.SensorEvent_UCR_High_Assert,
+		SensorEvent_UCR_Low_Assert:    &flag.SensorEvent_UCR_Low_Assert,
+		SensorEvent_State_14_Assert:   &flag.SensorEvent_State_14_Assert,
+		SensorEvent_State_13_Assert:   &flag.SensorEvent_State_13_Assert,
+		SensorEvent_State_12_Assert:   &flag.SensorEvent_State_12_Assert,
+		SensorEvent_State_11_Assert:   &flag.SensorEvent_State_11_Assert,
+		SensorEvent_State_10_Assert:   &flag.SensorEvent_State_10_Assert,
+		SensorEvent_State_9_Assert:    &flag.SensorEvent_State_9_Assert,
+		SensorEvent_State_8_Assert:    &flag.SensorEvent_State_8_Assert,
+		SensorEvent_UNC_High_Deassert: &flag.SensorEvent_UNC_High_Deassert,
+		SensorEvent_UNC_Low_Deassert:  &flag.SensorEvent_UNC_Low_Deassert,
+		SensorEvent_LNR_High_Deassert: &flag.SensorEvent_LNR_High_Deassert,
+		SensorEvent_LNR_Low_Deassert:  &flag.SensorEvent_LNR_Low_Deassert,
+		SensorEvent_LCR_High_Deassert: &flag.SensorEvent_LCR_High_Deassert,
+		SensorEvent_LCR_Low_Deassert:  &flag.SensorEvent_LCR_Low_Deassert,
+		SensorEvent_LNC_High_Deassert: &flag.SensorEvent_LNC_High_Deassert,
+		SensorEvent_LNC_Low_Deassert:  &flag.SensorEvent_LNC_Low_Deassert,
+		SensorEvent_State_7_Deassert:  &flag.SensorEvent_State_7_Deassert,
+		SensorEvent_State_6_Deassert:  &flag.SensorEvent_State_6_Deassert,
+		SensorEvent_State_5_Deassert:  &flag.SensorEvent_State_5_Deassert,
+		SensorEvent_State_4_Deassert:  &flag.SensorEvent_State_4_Deassert,
+		SensorEvent_State_3_Deassert:  &flag.SensorEvent_State_3_Deassert,
+		SensorEvent_State_2_Deassert:  &flag.SensorEvent_State_2_Deassert,
+		SensorEvent_State_1_Deassert:  &flag.SensorEvent_State_1_Deassert,
+		SensorEvent_State_0_Deassert:  &flag.SensorEvent_State_0_Deassert,
+		SensorEvent_UNR_High_Deassert: &flag.SensorEvent_UNR_High_Deassert,
+		SensorEvent_UNR_Low_Deassert:  &flag.SensorEvent_UNR_Low_Deassert,
+		SensorEvent_UCR_High_Deassert: &flag.SensorEvent_UCR_High_Deassert,
+		SensorEvent_UCR_Low_Deassert:  &flag.SensorEvent_UCR_Low_Deassert,
+		SensorEvent_State_14_Deassert: &flag.SensorEvent_State_14_Deassert,
+		SensorEvent_State_13_Deassert: &flag.SensorEvent_State_13_Deassert,
+		SensorEvent_State_12_Deassert: &flag.SensorEvent_State_12_Deassert,
+		SensorEvent_State_11_Deassert: &flag.SensorEvent_State_11_Deassert,
+		SensorEvent_State_10_Deassert: &flag.SensorEvent_State_10_Deassert,
+		SensorEvent_State_9_Deassert:  &flag.SensorEvent_State_9_Deassert,
+		SensorEvent_State_8_Deassert:  &flag.SensorEvent_State_8_Deassert,
+	}
+}
+
+// NewSensorEventFlag returns SensorEventFlag with the specified events set to true.
+func NewSensorEventFlag(events ...SensorEvent) SensorEventFlag {
+	flag := SensorEventFlag{}
+	flag.Set(events...)
+	return flag
+}
+
+// Set sets the specified events to true.
+func (flag *SensorEventFlag) Set(events ...SensorEvent) {
+	fields := flag.fields()
+	for _, event := range events {
+		if field, ok := fields[event]; ok {
+			*field = true
+		}
+	}
+}
+
+// pack returns the assertion event bits and deassertion event bits of the events those are set to true.
+// The bits layout is the same as bytes 3-6 of Set Sensor Event Enable command and Re-arm Sensor Events command,
+// that is, the LS byte and MS byte of assertion event bits, then the LS byte and MS byte of deassertion event bits.
+func (flag *SensorEventFlag) pack() []byte {
+	var assert, deassert uint16
+	for _, event := range flag.TrueEvents() {
+		if event.Assert {
+			assert |= 1 << event.Offset()
+		} else {
+			deassert |= 1 << event.Offset()
+		}
+	}
+
+	out := make([]byte, 4)
+	packUint16L(assert, out, 0)
+	packUint16L(deassert, out, 2)
+	return out
+}
+
+// Offset returns the event offset, which is also the bit position of the event in the event masks.
+//
+// see: Table 42-2, Generic Event/Reading Type Codes, Threshold
+func (e SensorEvent) Offset() uint8 {
+	if e.SensorClass != SensorClassThreshold {
+		return e.State
+	}
+
+	var offset uint8
+	switch e.ThresholdType {
+	case SensorThresholdType_LNC:
+		offset = 0
+	case SensorThresholdType_LCR:
+		offset = 2
+	case SensorThresholdType_LNR:
+		offset = 4
+	case SensorThresholdType_UNC:
+		offset = 6
+	case SensorThresholdType_UCR:
+		offset = 8
+	case SensorThresholdType_UNR:
+		offset = 10
+	}
+	if e.High {
+		offset++
+	}
+	return offset
+}
+
+// Deassertion returns the deassertion event of the same event offset.
+func (e SensorEvent) Deassertion() SensorEvent {
+	e.Assert = false
+	return e
+}
+
+// ParseSensorEvent parses the event name to the assertion SensorEvent for the sensor of the sensorType and eventReadingType.
+//
+// For threshold sensors, the name is the threshold abbreviation, optionally followed by "+" (going high) or "-" (going low),
+// like "ucr+", "lnc-". Without the suffix, upper thresholds mean going high, and lower thresholds mean going low.
+//
+// For discrete sensors, the name is "state<N>", or the event name (case-insensitive) defined for the sensor,
+// like "Presence detected" for Power Supply sensors.
+func ParseSensorEvent(name string, sensorType SensorType, eventReadingType EventReadingType) (SensorEvent, error) {
+	s := strings.ToLower(strings.TrimSpace(name))
+
+	if eventReadingType.IsThreshold() {
+		thresholdStr := strings.TrimRight(s, "+-")
+		for _, thresholdType := range []SensorThresholdType{
+			SensorThresholdType_LNC, SensorThresholdType_LCR, SensorThresholdType_LNR,
+			SensorThresholdType_UNC, SensorThresholdType_UCR, SensorThresholdType_UNR,
+		} {
+			if thresholdType.Abbr() != thresholdStr {
+				continue
+			}
+
+			var high bool
+			switch strings.TrimPrefix(s, thresholdStr) {
+			case "+":
+				high = true
+			case "-":
+				high = false
+			case "":
+				high = strings.HasPrefix(thresholdStr, "u")
+			default:
+				return SensorEvent{}, fmt.Errorf("invalid threshold event (%s)", name)
+			}
+
+			return SensorEvent{
+				SensorClass:   SensorClassThreshold,
+				ThresholdType: thresholdType,
+				Assert:        true,
+				High:          high,
+			}, nil
+		}
+		return SensorEvent{}, fmt.Errorf("unknown threshold event (%s), should be like unc+, ucr-, lnr", name)
+	}
+
+	if strings.HasPrefix(s, "state") {
+		state, err := strconv.ParseUint(strings.TrimPrefix(s, "state"), 10, 8)
+		if err == nil && state <= 14 {
+			return SensorEvent{
+				SensorClass: SensorClassDiscrete,
+				Assert:      true,
+				State:       uint8(state),
+			}, nil
+		}
+	}
+
+	var events map[uint8]Event
+	if eventReadingType == EventReadingTypeSensorSpecific {
+		events = SensorSpecificEvents[sensorType]
+	} else {
+		events = GenericEvents[eventReadingType]
+	}
+	for offset, event := range events {
+		if strings.ToLower(event.EventName) == s {
+			return SensorEvent{
+				SensorClass: SensorClassDiscrete,
+				Assert:      true,
+				State:       offset,
+			}, nil
+		}
+	}
+
+	return SensorEvent{}, fmt.Errorf("unknown discrete event (%s) for sensor type (%s) and event/reading type (%s)", name, sensorType, eventReadingType)
+}
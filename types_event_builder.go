@@ -0,0 +1,295 @@
+package ipmi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EventBuilder builds the System Event Records and Platform Event Messages of sensor events,
+// so the SELStandard fields need not be packed by hand.
+//
+// The sensor is specified by the sensor name or number, and the details (sensor number, sensor type,
+// event/reading type and generator ID) are resolved by the SDR of the sensor. Without the SDR,
+// the sensor number and the sensor type must be given.
+//
+// The event is specified by the event name defined in GenericEvents and SensorSpecificEvents,
+// like "Upper Critical going high" or "OS Graceful Shutdown" (case-insensitive, the hyphens are ignored),
+// or the names accepted by ParseSensorEvent, like "ucr+" and "state3".
+type EventBuilder struct {
+	eventName string
+	eventDir  EventDir
+
+	sensorName       string
+	sensorNumber     *SensorNumber
+	sensorType       *SensorType
+	eventReadingType *EventReadingType
+	generatorID      *GeneratorID
+
+	eventData2 *uint8
+	eventData3 *uint8
+
+	timestamp time.Time
+}
+
+// NewEventBuilder creates an EventBuilder of the assertion event of the event name.
+func NewEventBuilder(eventName string) *EventBuilder {
+	return &EventBuilder{
+		eventName: eventName,
+		eventDir:  EventDirAssertion,
+	}
+}
+
+// WithSensorName sets the sensor name, the sensor is resolved by the SDR of the name.
+func (b *EventBuilder) WithSensorName(sensorName string) *EventBuilder {
+	b.sensorName = sensorName
+	return b
+}
+
+// WithSensorNumber sets the sensor number, it overrides the sensor number of the SDR.
+func (b *EventBuilder) WithSensorNumber(sensorNumber SensorNumber) *EventBuilder {
+	b.sensorNumber = &sensorNumber
+	return b
+}
+
+// WithSensorType sets the sensor type, it overrides the sensor type of the SDR.
+func (b *EventBuilder) WithSensorType(sensorType SensorType) *EventBuilder {
+	b.sensorType = &sensorType
+	return b
+}
+
+// WithEventReadingType sets the event/reading type, it overrides the event/reading type of the SDR.
+// Without the SDR, it is determined by the event name if not set.
+func (b *EventBuilder) WithEventReadingType(eventReadingType EventReadingType) *EventBuilder {
+	b.eventReadingType = &eventReadingType
+	return b
+}
+
+// WithGeneratorID sets the generator ID, it overrides the sensor owner of the SDR.
+// The default is GeneratorBMC.
+func (b *EventBuilder) WithGeneratorID(generatorID GeneratorID) *EventBuilder {
+	b.generatorID = &generatorID
+	return b
+}
+
+// WithDirection sets the event direction, the default is EventDirAssertion.
+func (b *EventBuilder) WithDirection(eventDir EventDir) *EventBuilder {
+	b.eventDir = eventDir
+	return b
+}
+
+// WithTriggerValues sets the Event Data 2 and Event Data 3.
+//
+// For threshold events, they are the raw trigger reading and the raw trigger threshold value.
+// For discrete events, they are the sensor-specific event extension codes.
+func (b *EventBuilder) WithTriggerValues(eventData2 uint8, eventData3 uint8) *EventBuilder {
+	b.eventData2 = &eventData2
+	b.eventData3 = &eventData3
+	return b
+}
+
+// WithTimestamp sets the timestamp of the SEL record, the default is now.
+// The timestamp may be overwritten by the BMC when the record is added.
+func (b *EventBuilder) WithTimestamp(timestamp time.Time) *EventBuilder {
+	b.timestamp = timestamp
+	return b
+}
+
+// Build resolves the event to the SELStandard, the sdr of the sensor is optional.
+func (b *EventBuilder) Build(sdr *SDR) (*SELStandard, error) {
+	standard := &SELStandard{
+		Timestamp:   b.timestamp,
+		GeneratorID: GeneratorBMC,
+		EvMRev:      0x04, // IPMI v2.0
+		EventDir:    b.eventDir,
+	}
+	if standard.Timestamp.IsZero() {
+		standard.Timestamp = time.Now()
+	}
+
+	var hasSensorNumber, hasSensorType, hasEventReadingType bool
+	if sdr != nil {
+		switch sdr.RecordHeader.RecordType {
+		case SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor, SDRRecordTypeEventOnly:
+		default:
+			return nil, fmt.Errorf("SDR (%s) is not a sensor record", sdr.RecordHeader.RecordType)
+		}
+		standard.GeneratorID = sdr.GeneratorID()
+		standard.SensorNumber = sdr.SensorNumber()
+		standard.SensorType = sdr.SensorType()
+		standard.EventReadingType = sdr.EventReadingType()
+		hasSensorNumber, hasSensorType, hasEventReadingType = true, true, true
+	}
+
+	if b.generatorID != nil {
+		standard.GeneratorID = *b.generatorID
+	}
+	if b.sensorNumber != nil {
+		standard.SensorNumber = *b.sensorNumber
+		hasSensorNumber = true
+	}
+	if b.sensorType != nil {
+		standard.SensorType = *b.sensorType
+		hasSensorType = true
+	}
+	if b.eventReadingType != nil {
+		standard.EventReadingType = *b.eventReadingType
+		hasEventReadingType = true
+	}
+
+	if !hasSensorNumber {
+		return nil, fmt.Errorf("unknown sensor number, the sensor number or the SDR of the sensor is required")
+	}
+	if !hasSensorType {
+		return nil, fmt.Errorf("unknown sensor type, the sensor type or the SDR of the sensor is required")
+	}
+
+	eventReadingType, offset, err := resolveEvent(b.eventName, standard.SensorType, standard.EventReadingType, hasEventReadingType)
+	if err != nil {
+		return nil, err
+	}
+	standard.EventReadingType = eventReadingType
+
+	// 29.7 Event Data Field Formats, Event Data 2 and Event Data 3 are unspecified (FFh) if not given
+	standard.EventData = EventData{
+		EventData1: offset,
+		EventData2: 0xff,
+		EventData3: 0xff,
+	}
+	if b.eventData2 != nil && b.eventData3 != nil {
+		usage := EventDataUsageSensorSpecific
+		if eventReadingType.IsThreshold() {
+			usage = EventDataUsageStandard
+		}
+		standard.EventData.EventData1 |= uint8(usage)<<6 | uint8(usage)<<4
+		standard.EventData.EventData2 = *b.eventData2
+		standard.EventData.EventData3 = *b.eventData3
+	}
+
+	return standard, nil
+}
+
+// SEL builds the System Event Record (record type 02h) of the event, the sdr of the sensor is optional.
+func (b *EventBuilder) SEL(sdr *SDR) (*SEL, error) {
+	standard, err := b.Build(sdr)
+	if err != nil {
+		return nil, err
+	}
+	return &SEL{
+		RecordType: 0x02,
+		Standard:   standard,
+	}, nil
+}
+
+// PlatformEventMessage builds the Platform Event Message request of the event, the sdr of the sensor is optional.
+func (b *EventBuilder) PlatformEventMessage(sdr *SDR) (*PlatformEventMessageRequest, error) {
+	standard, err := b.Build(sdr)
+	if err != nil {
+		return nil, err
+	}
+	return &PlatformEventMessageRequest{
+		GeneratorID:  uint8(standard.GeneratorID),
+		EvMRev:       standard.EvMRev,
+		SensorType:   uint8(standard.SensorType),
+		SensorNumber: uint8(standard.SensorNumber),
+		EventDir:     standard.EventDir,
+		EventType:    standard.EventReadingType,
+		EventData:    standard.EventData,
+	}, nil
+}
+
+// resolveEvent returns the event/reading type and the event offset of the event name.
+// If the event/reading type is not known, it is determined by the event tables in which the event name is found.
+func resolveEvent(eventName string, sensorType SensorType, eventReadingType EventReadingType, known bool) (EventReadingType, uint8, error) {
+	candidates := []EventReadingType{eventReadingType}
+	if !known {
+		candidates = []EventReadingType{EventReadingTypeThreshold, EventReadingTypeSensorSpecific}
+		for typ := EventReadingTypeTransitionState; typ <= EventReadingTypeACPIPowerState; typ++ {
+			candidates = append(candidates, typ)
+		}
+	}
+
+	name := normalizeEventName(eventName)
+	for _, typ := range candidates {
+		events := GenericEvents[typ]
+		if typ == EventReadingTypeSensorSpecific {
+			events = SensorSpecificEvents[sensorType]
+		}
+
+		offsets := make([]int, 0, len(events))
+		for offset := range events {
+			offsets = append(offsets, int(offset))
+		}
+		sort.Ints(offsets)
+		for _, offset := range offsets {
+			if normalizeEventName(events[uint8(offset)].EventName) == name {
+				return typ, uint8(offset), nil
+			}
+		}
+	}
+
+	if !known {
+		eventReadingType = EventReadingTypeThreshold
+	}
+	sensorEvent, err := ParseSensorEvent(eventName, sensorType, eventReadingType)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unknown event (%s) for sensor type (%s), err: %s", eventName, sensorType, err)
+	}
+	return eventReadingType, sensorEvent.Offset(), nil
+}
+
+// normalizeEventName lowers the event name and ignores the hyphens, so "Upper Critical going high"
+// matches "Upper Critical - going high".
+func normalizeEventName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", " ")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// getEventSDR returns the SDR of the sensor of the event builder, or nil if the sensor name is not set
+// and the sensor type is set by WithSensorType.
+func (c *Client) getEventSDR(b *EventBuilder) (*SDR, error) {
+	switch {
+	case b.sensorName != "":
+		sdr, err := c.GetSDRBySensorName(b.sensorName)
+		if err != nil {
+			return nil, fmt.Errorf("GetSDRBySensorName failed, err: %s", err)
+		}
+		return sdr, nil
+
+	case b.sensorNumber != nil && b.sensorType == nil:
+		sdr, err := c.GetSDRBySensorID(uint8(*b.sensorNumber))
+		if err != nil {
+			return nil, fmt.Errorf("GetSDRBySensorID failed, err: %s", err)
+		}
+		return sdr, nil
+	}
+	return nil, nil
+}
+
+// AddSELEvent adds the System Event Record of the event to SEL.
+func (c *Client) AddSELEvent(b *EventBuilder) (*AddSELEntryResponse, error) {
+	sdr, err := c.getEventSDR(b)
+	if err != nil {
+		return nil, err
+	}
+	sel, err := b.SEL(sdr)
+	if err != nil {
+		return nil, err
+	}
+	return c.AddSELEntry(sel)
+}
+
+// SendEvent sends the Platform Event Message of the event to the BMC,
+// the event is logged to SEL and processed by PEF as if it was generated by the sensor.
+func (c *Client) SendEvent(b *EventBuilder) (*PlatformEventMessageResponse, error) {
+	sdr, err := c.getEventSDR(b)
+	if err != nil {
+		return nil, err
+	}
+	request, err := b.PlatformEventMessage(sdr)
+	if err != nil {
+		return nil, err
+	}
+	return c.PlatformEventMessage(request)
+}
@@ -0,0 +1,145 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_EventBuilder(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	psuSDR := &SDR{
+		RecordHeader: &SDRHeader{RecordType: SDRRecordTypeCompactSensor},
+		Compact: &SDRCompact{
+			GeneratorID:            GeneratorBMC,
+			SensorNumber:           0x52,
+			SensorType:             SensorTypePowserSupply,
+			SensorEventReadingType: EventReadingTypeSensorSpecific,
+			IDStringBytes:          []byte("PSU2 Status"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		builder  *EventBuilder
+		sdr      *SDR
+		expected []byte
+		event    string
+	}{
+		{
+			name: "threshold event name",
+			builder: NewEventBuilder("Upper Critical going high").
+				WithSensorNumber(0x30).WithSensorType(SensorTypeTemperature).WithTriggerValues(0x5f, 0x5a),
+			expected: []byte{0x00, 0x00, 0x02, 0x00, 0xf1, 0x53, 0x65, 0x20, 0x00, 0x04, 0x01, 0x30, 0x01, 0x59, 0x5f, 0x5a},
+			event:    "Upper Critical - going high",
+		},
+		{
+			name: "threshold abbreviation",
+			builder: NewEventBuilder("lnr").
+				WithSensorNumber(0x31).WithSensorType(SensorTypeVoltage).WithDirection(EventDirDeassertion),
+			expected: []byte{0x00, 0x00, 0x02, 0x00, 0xf1, 0x53, 0x65, 0x20, 0x00, 0x04, 0x02, 0x31, 0x81, 0x04, 0xff, 0xff},
+			event:    "Lower Non-recoverable - going low",
+		},
+		{
+			name: "sensor specific event",
+			builder: NewEventBuilder("os graceful shutdown").
+				WithSensorNumber(0x40).WithSensorType(SensorTypeOSStopShutdown).WithGeneratorID(GeneratorMicrosoftOS),
+			expected: []byte{0x00, 0x00, 0x02, 0x00, 0xf1, 0x53, 0x65, 0x41, 0x00, 0x04, 0x20, 0x40, 0x6f, 0x03, 0xff, 0xff},
+			event:    "OS Graceful Shutdown",
+		},
+		{
+			name:     "resolved by SDR",
+			builder:  NewEventBuilder("Presence detected").WithDirection(EventDirDeassertion),
+			sdr:      psuSDR,
+			expected: []byte{0x00, 0x00, 0x02, 0x00, 0xf1, 0x53, 0x65, 0x20, 0x00, 0x04, 0x08, 0x52, 0xef, 0x00, 0xff, 0xff},
+			event:    "Presence detected",
+		},
+		{
+			name:     "state of SDR",
+			builder:  NewEventBuilder("state1"),
+			sdr:      psuSDR,
+			expected: []byte{0x00, 0x00, 0x02, 0x00, 0xf1, 0x53, 0x65, 0x20, 0x00, 0x04, 0x08, 0x52, 0x6f, 0x01, 0xff, 0xff},
+			event:    "Power Supply Failure detected",
+		},
+	}
+
+	for _, test := range tests {
+		sel, err := test.builder.WithTimestamp(ts).SEL(test.sdr)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+
+		got := sel.Pack()
+		if !bytes.Equal(got, test.expected) {
+			t.Errorf("test %s not matched, got: % x, expected: % x", test.name, got, test.expected)
+		}
+
+		s := sel.Standard
+		if event := s.EventReadingType.Event(s.SensorType, s.SensorNumber, s.EventData); event == nil || event.EventName != test.event {
+			t.Errorf("test %s event not matched, got: %v, expected: %s", test.name, event, test.event)
+		}
+	}
+}
+
+func Test_EventBuilder_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *EventBuilder
+	}{
+		{"no sensor number", NewEventBuilder("ucr").WithSensorType(SensorTypeTemperature)},
+		{"no sensor type", NewEventBuilder("ucr").WithSensorNumber(0x30)},
+		{"unknown event", NewEventBuilder("melted").WithSensorNumber(0x30).WithSensorType(SensorTypeTemperature)},
+		{"event of other type", NewEventBuilder("OS Graceful Shutdown").WithSensorNumber(0x30).
+			WithSensorType(SensorTypeTemperature).WithEventReadingType(EventReadingTypeThreshold)},
+	}
+
+	for _, test := range tests {
+		if _, err := test.builder.SEL(nil); err == nil {
+			t.Errorf("test %s should fail", test.name)
+		}
+	}
+}
+
+func Test_PlatformEventMessageRequest_Pack(t *testing.T) {
+	request, err := NewEventBuilder("ucr").
+		WithSensorNumber(0x30).WithSensorType(SensorTypeTemperature).WithGeneratorID(GeneratorMicrosoftOS).
+		PlatformEventMessage(nil)
+	if err != nil {
+		t.Fatalf("test PlatformEventMessage failed, err: %s", err)
+	}
+
+	expected := []byte{0x04, 0x01, 0x30, 0x01, 0x09, 0xff, 0xff}
+	if got := request.Pack(); !bytes.Equal(got, expected) {
+		t.Errorf("test Pack not matched, got: % x, expected: % x", got, expected)
+	}
+
+	request.withGeneratorID = true
+	expected = append([]byte{0x41}, expected...)
+	if got := request.Pack(); !bytes.Equal(got, expected) {
+		t.Errorf("test Pack with generator ID not matched, got: % x, expected: % x", got, expected)
+	}
+
+	// a Slave Address is not a System Software ID, the software ID is sent instead
+	for _, generatorID := range []GeneratorID{GeneratorBMC, GeneratorIntelNMFirmware} {
+		request.GeneratorID = uint8(generatorID)
+		expected[0] = 0x41
+		if got := request.Pack(); !bytes.Equal(got, expected) {
+			t.Errorf("test Pack with generator ID %#04x not matched, got: % x, expected: % x", generatorID, got, expected)
+		}
+	}
+
+	// an explicit System Software ID is kept
+	request.GeneratorID = uint8(GeneratorBIOSPOST)
+	expected[0] = 0x01
+	if got := request.Pack(); !bytes.Equal(got, expected) {
+		t.Errorf("test Pack with software ID not matched, got: % x, expected: % x", got, expected)
+	}
+
+	// the Generator ID is not carried in the request data for IPMB (LAN) messages
+	request.GeneratorID = uint8(GeneratorBMC)
+	request.withGeneratorID = false
+	if got := request.Pack(); !bytes.Equal(got, expected[1:]) {
+		t.Errorf("test Pack without generator ID not matched, got: % x, expected: % x", got, expected[1:])
+	}
+}
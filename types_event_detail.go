@@ -0,0 +1,577 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventDetail is the event decoded from Event Data 1, 2 and 3.
+type EventDetail struct {
+	// The description of the event offset, empty if the event is not defined.
+	EventName string
+
+	// The trigger reading and the trigger threshold value of threshold events,
+	// nil if not provided in the event data.
+	TriggerReading   *EventReading
+	TriggerThreshold *EventReading
+
+	// The values carried in Event Data 2 and Event Data 3 for the other events,
+	// like the previous state and severity of discrete events, the sensor-specific
+	// extension codes (DIMM number, POST error code, ...), and the OEM codes.
+	Fields []EventDataField
+
+	goingHigh bool
+}
+
+// EventReading is the trigger reading or the trigger threshold value of threshold events.
+type EventReading struct {
+	Raw uint8
+
+	// Value is the reading converted to engineering units, only valid if Converted is true.
+	// The reading can only be converted with the Full SDR of an analog sensor.
+	Value     float64
+	Converted bool
+	Unit      string
+}
+
+func (r *EventReading) String() string {
+	if !r.Converted {
+		return fmt.Sprintf("%#02x", r.Raw)
+	}
+	return fmt.Sprintf("%.3f", r.Value)
+}
+
+type EventDataField struct {
+	Name  string
+	Raw   uint8
+	Value string
+}
+
+func (f EventDataField) String() string {
+	return fmt.Sprintf("%s: %s", f.Name, f.Value)
+}
+
+// String returns the event description followed by the decoded event data,
+// like "Upper Critical - going high, Reading 95.000 > Threshold 90.000 degrees C",
+// or "Correctable ECC / other correctable memory error, Memory Module: 3".
+func (d *EventDetail) String() string {
+	parts := make([]string, 0)
+	if d.EventName != "" {
+		parts = append(parts, d.EventName)
+	}
+
+	if d.TriggerReading != nil || d.TriggerThreshold != nil {
+		var s string
+		var unit string
+		switch {
+		case d.TriggerReading != nil && d.TriggerThreshold != nil:
+			op := "<"
+			if d.goingHigh {
+				op = ">"
+			}
+			s = fmt.Sprintf("Reading %s %s Threshold %s", d.TriggerReading, op, d.TriggerThreshold)
+			unit = d.TriggerThreshold.Unit
+		case d.TriggerReading != nil:
+			s = fmt.Sprintf("Reading %s", d.TriggerReading)
+			unit = d.TriggerReading.Unit
+		default:
+			s = fmt.Sprintf("Threshold %s", d.TriggerThreshold)
+			unit = d.TriggerThreshold.Unit
+		}
+		if unit != "" {
+			s = fmt.Sprintf("%s %s", s, unit)
+		}
+		parts = append(parts, s)
+	}
+
+	for _, field := range d.Fields {
+		parts = append(parts, field.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// EventDetail decodes the event, including the information carried in Event Data 2 and Event Data 3.
+//
+// The sdr is the SDR of the sensor which generated the event, it is optional.
+// If sdr is a Full SDR of an analog sensor, the trigger reading and trigger threshold value
+// of threshold events are converted to the engineering units of the sensor.
+func (typ EventReadingType) EventDetail(sensorType SensorType, sensorNumber SensorNumber, eventData EventData, sdr *SDR) *EventDetail {
+	detail := &EventDetail{}
+
+	event := typ.Event(sensorType, sensorNumber, eventData)
+	if event != nil {
+		detail.EventName = event.EventName
+	}
+
+	ed2Usage := eventData.ED2Usage()
+	ed3Usage := eventData.ED3Usage()
+	ed2 := eventData.EventData2
+	ed3 := eventData.EventData3
+
+	switch {
+	case typ == EventReadingTypeUnspecified:
+		return detail
+
+	case typ.IsThreshold():
+		// odd offsets are going-high events, see Table 42-2, Threshold
+		detail.goingHigh = eventData.EventReadingOffset()&0x01 == 0x01
+		if ed2Usage == EventDataUsageStandard {
+			detail.TriggerReading = newEventReading(ed2, sdr)
+		}
+		if ed3Usage == EventDataUsageStandard {
+			detail.TriggerThreshold = newEventReading(ed3, sdr)
+		}
+
+	case typ >= EventReadingTypeOEMMin && typ <= EventReadingTypeOEMMax:
+		if ed2Usage == EventDataUsageStandard {
+			detail.Fields = append(detail.Fields, previousStateFields(typ, sensorType, sensorNumber, ed2)...)
+		}
+
+	default:
+		if ed2Usage == EventDataUsageStandard {
+			detail.Fields = append(detail.Fields, previousStateFields(typ, sensorType, sensorNumber, ed2)...)
+		}
+
+		var sensorSpecificED2, sensorSpecificED3 *uint8
+		if ed2Usage == EventDataUsageSensorSpecific {
+			sensorSpecificED2 = &ed2
+		}
+		if ed3Usage == EventDataUsageSensorSpecific {
+			sensorSpecificED3 = &ed3
+		}
+		if typ == EventReadingTypeSensorSpecific && event != nil && (sensorSpecificED2 != nil || sensorSpecificED3 != nil) {
+			if decoder, ok := sensorSpecificEventDataDecoders[sensorType]; ok {
+				detail.Fields = append(detail.Fields, decoder(eventData.EventReadingOffset(), event, sensorSpecificED2, sensorSpecificED3)...)
+				sensorSpecificED2, sensorSpecificED3 = nil, nil
+			}
+		}
+
+		// the extension codes which are not decoded
+		if sensorSpecificED2 != nil {
+			detail.Fields = append(detail.Fields, EventDataField{"Event Data 2", ed2, fmt.Sprintf("%#02x", ed2)})
+		}
+		if sensorSpecificED3 != nil {
+			detail.Fields = append(detail.Fields, EventDataField{"Event Data 3", ed3, fmt.Sprintf("%#02x", ed3)})
+		}
+	}
+
+	if ed2Usage == EventDataUsageOEM {
+		detail.Fields = append(detail.Fields, EventDataField{"OEM Code 2", ed2, fmt.Sprintf("%#02x", ed2)})
+	}
+	if ed3Usage == EventDataUsageOEM {
+		detail.Fields = append(detail.Fields, EventDataField{"OEM Code 3", ed3, fmt.Sprintf("%#02x", ed3)})
+	}
+
+	return detail
+}
+
+func newEventReading(raw uint8, sdr *SDR) *EventReading {
+	reading := &EventReading{
+		Raw: raw,
+	}
+
+	if sdr == nil || sdr.Full == nil || !sdr.Full.SensorUnit.IsAnalog() {
+		return reading
+	}
+
+	reading.Value = sdr.Full.ConvertReading(raw)
+	reading.Converted = true
+	reading.Unit = sdr.Full.SensorUnit.String()
+	return reading
+}
+
+// previousStateFields decodes Event Data 2 of discrete events.
+// [7:4] - Optional offset from 'Severity' Event/Reading Code (0x0f if unspecified)
+// [3:0] - Optional offset from Event/Reading Type Code for previous discrete event state (0x0f if unspecified)
+func previousStateFields(typ EventReadingType, sensorType SensorType, sensorNumber SensorNumber, ed2 uint8) []EventDataField {
+	out := make([]EventDataField, 0)
+
+	previous := ed2 & 0x0f
+	if previous != 0x0f {
+		value := fmt.Sprintf("state%d", previous)
+		if event := typ.Event(sensorType, sensorNumber, EventData{EventData1: previous}); event != nil {
+			value = event.EventName
+		}
+		out = append(out, EventDataField{"Previous State", previous, value})
+	}
+
+	severity := ed2 >> 4
+	if severity != 0x0f {
+		value := fmt.Sprintf("%#02x", severity)
+		if event := genericEvent(EventReadingTypeTransitionSeverity, severity); event != nil {
+			value = event.EventName
+		}
+		out = append(out, EventDataField{"Severity", severity, value})
+	}
+
+	return out
+}
+
+// sensorSpecificEventDataDecoder decodes the sensor-specific extension codes of the event of the offset.
+// The ed2 and ed3 are nil if they do not hold the sensor-specific extension codes.
+type sensorSpecificEventDataDecoder func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField
+
+// Table 42-3, Sensor Type Codes and Data
+var sensorSpecificEventDataDecoders = map[SensorType]sensorSpecificEventDataDecoder{
+	SensorTypePhysicalSecurity: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		// LAN Leash Lost
+		if offset == 0x04 && ed2 != nil {
+			out = append(out, EventDataField{"Network Controller", *ed2, fmt.Sprintf("%d", *ed2)})
+		}
+		return out
+	},
+
+	SensorTypePowserSupply: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		// Configuration error
+		if offset == 0x06 && ed3 != nil {
+			errorType := *ed3 & 0x0f
+			out = append(out, EventDataField{"Error Type", errorType, eventDataString(event.ED3, errorType)})
+		}
+		return out
+	},
+
+	SensorTypeMemory: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		// Memory module/device (e.g. DIMM/SIMM/RIMM) identification, relative to the entity that the sensor is monitoring.
+		if ed3 != nil {
+			out = append(out, EventDataField{"Memory Module", *ed3, fmt.Sprintf("%d", *ed3)})
+		}
+		return out
+	},
+
+	SensorTypeSystemFirmwareProgress: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 == nil {
+			return out
+		}
+		name := "Progress"
+		if offset == 0x00 {
+			name = "Error"
+		}
+		out = append(out, EventDataField{name, *ed2, eventDataString(event.ED2, *ed2)})
+		return out
+	},
+
+	SensorTypeEventLoggingDisabled: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		switch offset {
+		case 0x00:
+			// Correctable Memory Error Logging Disabled
+			if ed2 != nil {
+				out = append(out, EventDataField{"Memory Module", *ed2, fmt.Sprintf("%d", *ed2)})
+			}
+
+		case 0x01:
+			// Event 'Type' Logging Disabled
+			if ed2 != nil {
+				out = append(out, EventDataField{"Event/Reading Type", *ed2, fmt.Sprintf("%#02x", *ed2)})
+			}
+			if ed3 != nil {
+				// [5] - 1b = logging has been disabled for all events of given type
+				// [4] - 1b = assertion event, 0b = deassertion event
+				// [3:0] - Event Offset
+				if isBit5Set(*ed3) {
+					out = append(out, EventDataField{"Event Offset", *ed3, "all"})
+				} else {
+					out = append(out, EventDataField{"Event Offset", *ed3 & 0x0f, fmt.Sprintf("%#02x", *ed3&0x0f)})
+				}
+				eventDir := EventDir(!isBit4Set(*ed3))
+				out = append(out, EventDataField{"Event Direction", *ed3, eventDir.String()})
+			}
+
+		case 0x05:
+			// SEL Almost Full
+			if ed3 != nil {
+				out = append(out, EventDataField{"SEL Full", *ed3, fmt.Sprintf("%d%%", *ed3)})
+			}
+
+		case 0x06:
+			// Correctable Machine Check Error Logging Disabled
+			// Event Data 3 [7] - 0b = Event Data 2 holds the Entity Instance number of the processor,
+			// 1b = Event Data 2 holds the vendor-specific processor number.
+			if ed2 != nil {
+				name := "Processor Entity Instance"
+				if ed3 != nil && isBit7Set(*ed3) {
+					name = "Processor"
+				}
+				out = append(out, EventDataField{name, *ed2, fmt.Sprintf("%d", *ed2)})
+			}
+		}
+		return out
+	},
+
+	SensorTypeSystemEvent: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 == nil {
+			return out
+		}
+		switch offset {
+		case 0x03:
+			// Entry added to Auxiliary Log
+			// [7:4] - Log Entry Action
+			// [3:0] - Log Type
+			action := *ed2 >> 4
+			logType := *ed2 & 0x0f
+			out = append(out,
+				EventDataField{"Log Action", action, eventDataString(auxLogActions, action)},
+				EventDataField{"Log Type", logType, eventDataString(auxLogTypes, logType)},
+			)
+
+		case 0x04:
+			// PEF Action
+			actions := make([]string, 0)
+			for i, action := range pefActions {
+				if *ed2&(1<<uint(i)) != 0 {
+					actions = append(actions, action)
+				}
+			}
+			out = append(out, EventDataField{"PEF Action", *ed2, strings.Join(actions, " / ")})
+
+		case 0x05:
+			// Timestamp Clock Synch
+			// [7] - 0b = event is first of pair, 1b = event is second of pair
+			// [3:0] - 0h = SEL Timestamp Clock updated, 1h = SDR Timestamp Clock updated
+			clock := "SEL"
+			if *ed2&0x0f == 0x01 {
+				clock = "SDR"
+			}
+			pair := "first"
+			if isBit7Set(*ed2) {
+				pair = "second"
+			}
+			out = append(out,
+				EventDataField{"Clock", *ed2 & 0x0f, clock},
+				EventDataField{"Pair", *ed2 >> 7, pair},
+			)
+		}
+		return out
+	},
+
+	SensorTypeSystemBootRestartInitiated: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		// System Restart, the restart cause and channel, same as the Get System Restart Cause command
+		if offset != 0x07 {
+			return out
+		}
+		if ed2 != nil {
+			cause := SystemRestartCause(*ed2 & 0x0f)
+			out = append(out, EventDataField{"Restart Cause", uint8(cause), cause.String()})
+		}
+		if ed3 != nil {
+			out = append(out, EventDataField{"Channel", *ed3, fmt.Sprintf("%d", *ed3)})
+		}
+		return out
+	},
+
+	SensorTypeSlotConnector: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 != nil {
+			slotType := *ed2 & 0x7f
+			out = append(out, EventDataField{"Slot Type", slotType, eventDataString(slotConnectorTypes, slotType)})
+		}
+		if ed3 != nil {
+			out = append(out, EventDataField{"Slot Number", *ed3, fmt.Sprintf("%d", *ed3)})
+		}
+		return out
+	},
+
+	SensorTypeWatchdog2: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 == nil {
+			return out
+		}
+		// [7:4] - interrupt type
+		// [3:0] - timer use at expiration
+		interruptType := *ed2 >> 4
+		timerUse := *ed2 & 0x0f
+		out = append(out,
+			EventDataField{"Interrupt Type", interruptType, eventDataString(watchdogInterruptTypes, interruptType)},
+			EventDataField{"Timer Use", timerUse, eventDataString(watchdogTimerUses, timerUse)},
+		)
+		return out
+	},
+
+	SensorTypeManagementSubsystemHealth: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		switch offset {
+		case 0x04:
+			// Sensor failure
+			if ed2 != nil {
+				out = append(out, EventDataField{"Sensor Number", *ed2, fmt.Sprintf("%#02x", *ed2)})
+			}
+
+		case 0x05:
+			// FRU failure
+			// Event Data 2
+			// [7] - logical/physical FRU device, 0b = device is not a logical FRU Device, 1b = device is logical FRU Device
+			// [4:3] - LUN for Master Write-Read command or FRU Command
+			// [2:0] - Private bus ID if bus = Private
+			// Event Data 3
+			// FRU Device ID within controller if logical FRU Device, or the Slave Address of non-intelligent FRU device
+			logical := ed2 != nil && isBit7Set(*ed2)
+			if ed2 != nil && !logical {
+				out = append(out,
+					EventDataField{"LUN", (*ed2 >> 3) & 0x03, fmt.Sprintf("%d", (*ed2>>3)&0x03)},
+					EventDataField{"Private Bus", *ed2 & 0x07, fmt.Sprintf("%d", *ed2&0x07)},
+				)
+			}
+			if ed3 != nil {
+				name := "FRU Slave Address"
+				if logical {
+					name = "FRU Device ID"
+				}
+				out = append(out, EventDataField{name, *ed3, fmt.Sprintf("%#02x", *ed3)})
+			}
+		}
+		return out
+	},
+
+	SensorTypeSessionAudit: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 != nil {
+			// [5:0] - User ID for user that had the session activated, 0 = unspecified
+			userID := *ed2 & 0x3f
+			value := "unspecified"
+			if userID != 0 {
+				value = fmt.Sprintf("%d", userID)
+			}
+			out = append(out, EventDataField{"User ID", userID, value})
+		}
+		if ed3 != nil {
+			// [5:4] - Deactivation cause, only for Session Deactivated
+			// [3:0] - Channel number that session was activated/deactivated over
+			if offset == 0x01 {
+				cause := (*ed3 >> 4) & 0x03
+				out = append(out, EventDataField{"Deactivation Cause", cause, eventDataString(sessionDeactivationCauses, cause)})
+			}
+			out = append(out, EventDataField{"Channel", *ed3 & 0x0f, fmt.Sprintf("%d", *ed3&0x0f)})
+		}
+		return out
+	},
+
+	SensorTypeVersionChange: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 != nil {
+			out = append(out, EventDataField{"Change Type", *ed2, eventDataString(event.ED2, *ed2)})
+		}
+		return out
+	},
+
+	SensorTypeFRUState: func(offset uint8, event *Event, ed2 *uint8, ed3 *uint8) []EventDataField {
+		out := make([]EventDataField, 0)
+		if ed2 == nil {
+			return out
+		}
+		// [7:4] - Cause of state change
+		// [3:0] - Previous state offset value
+		cause := *ed2 >> 4
+		previous := *ed2 & 0x0f
+		previousState := fmt.Sprintf("state%d", previous)
+		if e := sensorSpecificEvent(SensorTypeFRUState, previous); e != nil {
+			previousState = e.EventName
+		}
+		out = append(out,
+			EventDataField{"Cause", cause, eventDataString(fruStateChangeCauses, cause)},
+			EventDataField{"Previous State", previous, previousState},
+		)
+		return out
+	},
+}
+
+// eventDataString returns the description of the event data value in m,
+// or the hex value if it is not defined.
+func eventDataString(m map[uint8]string, v uint8) string {
+	if s, ok := m[v]; ok {
+		return s
+	}
+	return fmt.Sprintf("%#02x", v)
+}
+
+// System Event, Entry added to Auxiliary Log, Event Data 2 [7:4] - Log Entry Action
+var auxLogActions = map[uint8]string{
+	0x00: "entry added",
+	0x01: "entry added because event did not map to standard IPMI event",
+	0x02: "entry added along with one or more corresponding SEL entries",
+	0x03: "log cleared",
+	0x04: "log disabled",
+	0x05: "log enabled",
+}
+
+// System Event, Entry added to Auxiliary Log, Event Data 2 [3:0] - Log Type
+var auxLogTypes = map[uint8]string{
+	0x00: "MCA Log",
+	0x01: "OEM 1",
+	0x02: "OEM 2",
+}
+
+// System Event, PEF Action, Event Data 2, indexed by the bit position
+var pefActions = []string{
+	"Alert",
+	"power off",
+	"reset",
+	"power cycle",
+	"OEM action",
+	"Diagnostic Interrupt (NMI)",
+}
+
+// Slot / Connector, Event Data 2 [6:0] - Slot/Connector Type
+var slotConnectorTypes = map[uint8]string{
+	0x00: "PCI",
+	0x01: "Drive Array",
+	0x02: "External Peripheral Connector",
+	0x03: "Docking",
+	0x04: "other standard internal expansion slot",
+	0x05: "slot associated with entity specified by Entity ID for sensor",
+	0x06: "AdvancedTCA",
+	0x07: "DIMM/memory device",
+	0x08: "FAN",
+	0x09: "PCI Express",
+	0x0a: "SCSI (parallel)",
+	0x0b: "SATA / SAS",
+}
+
+// Watchdog 2, Event Data 2 [7:4] - interrupt type
+var watchdogInterruptTypes = map[uint8]string{
+	0x00: "none",
+	0x01: "SMI",
+	0x02: "NMI",
+	0x03: "Messaging Interrupt",
+	0x0f: "unspecified",
+}
+
+// Watchdog 2, Event Data 2 [3:0] - timer use at expiration
+var watchdogTimerUses = map[uint8]string{
+	0x01: "BIOS FRB2",
+	0x02: "BIOS/POST",
+	0x03: "OS Load",
+	0x04: "SMS/OS",
+	0x05: "OEM",
+	0x0f: "unspecified",
+}
+
+// Session Audit, Event Data 3 [5:4] - Deactivation cause
+var sessionDeactivationCauses = map[uint8]string{
+	0x00: "Session deactivation cause unspecified",
+	0x01: "Session deactivated by Close Session command",
+	0x02: "Session deactivated by timeout",
+	0x03: "Session deactivated by configuration change",
+}
+
+// FRU State, Event Data 2 [7:4] - Cause of state change
+var fruStateChangeCauses = map[uint8]string{
+	0x00: "Normal State Change",
+	0x01: "Change Commanded by software external to FRU",
+	0x02: "State Change due to operator changing a Handle latch",
+	0x03: "State Change due to operator pressing the hot swap push button",
+	0x04: "State Change due to FRU programmatic action",
+	0x05: "Communication Lost",
+	0x06: "Communication Lost due to local failure",
+	0x07: "State Change due to unexpected extraction",
+	0x08: "State Change due to operator intervention/update",
+	0x09: "Unable to compute IPMB address",
+	0x0a: "Unexpected Deactivation",
+	0x0f: "State Change, Cause Unknown",
+}
@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func Test_EventDetail(t *testing.T) {
+	temperatureSDR := &SDR{
+		Full: &SDRFull{
+			SensorUnit: SensorUnit{
+				AnalogDataFormat: SensorAnalogUnitFormat_Unsigned,
+				BaseUnit:         SensorUnitType_DegressC,
+			},
+			ReadingFactors:    ReadingFactors{M: 1},
+			LinearizationFunc: LinearizationFunc_Linear,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		typ        EventReadingType
+		sensorType SensorType
+		eventData  EventData
+		sdr        *SDR
+		expected   string
+	}{
+		{
+			name:       "threshold with sdr",
+			typ:        EventReadingTypeThreshold,
+			sensorType: SensorTypeTemperature,
+			eventData:  EventData{0x59, 0x5f, 0x5a},
+			sdr:        temperatureSDR,
+			expected:   "Upper Critical - going high, Reading 95.000 > Threshold 90.000 degrees C",
+		},
+		{
+			name:       "threshold without sdr",
+			typ:        EventReadingTypeThreshold,
+			sensorType: SensorTypeTemperature,
+			eventData:  EventData{0x52, 0x05, 0x0a},
+			expected:   "Lower Critical - going low, Reading 0x05 < Threshold 0x0a",
+		},
+		{
+			name:       "threshold reading only",
+			typ:        EventReadingTypeThreshold,
+			sensorType: SensorTypeTemperature,
+			eventData:  EventData{0x49, 0x5f, 0xff},
+			sdr:        temperatureSDR,
+			expected:   "Upper Critical - going high, Reading 95.000 degrees C",
+		},
+		{
+			name:       "memory dimm",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeMemory,
+			eventData:  EventData{0xb0, 0x00, 0x03},
+			expected:   "Correctable ECC / other correctable memory error, Memory Module: 3, OEM Code 2: 0x00",
+		},
+		{
+			name:       "post error",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeSystemFirmwareProgress,
+			eventData:  EventData{0xc0, 0x01, 0xff},
+			expected:   "System Firmware Error (POST Error), Error: No system memory is physically installed in the system",
+		},
+		{
+			name:       "watchdog",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeWatchdog2,
+			eventData:  EventData{0xc1, 0x04, 0xff},
+			expected:   "Hard Reset, Interrupt Type: none, Timer Use: SMS/OS",
+		},
+		{
+			name:       "system restart",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeSystemBootRestartInitiated,
+			eventData:  EventData{0xf7, 0x01, 0x00},
+			expected:   "System Restart, Restart Cause: chassis power control command, Channel: 0",
+		},
+		{
+			name:       "discrete previous state",
+			typ:        EventReadingTypeState,
+			sensorType: SensorTypeOtherUnitsbased,
+			eventData:  EventData{0x41, 0xf0, 0xff},
+			expected:   "State Asserted, Previous State: State Deasserted",
+		},
+		{
+			name:       "sensor specific not decoded",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeBattery,
+			eventData:  EventData{0xc1, 0x12, 0xff},
+			expected:   "battery failed, Event Data 2: 0x12",
+		},
+		{
+			name:       "unspecified event data",
+			typ:        EventReadingTypeSensorSpecific,
+			sensorType: SensorTypeMemory,
+			eventData:  EventData{0x01, 0xff, 0xff},
+			expected:   "Uncorrectable ECC / other uncorrectable memory error",
+		},
+	}
+
+	for _, test := range tests {
+		got := test.typ.EventDetail(test.sensorType, 0x01, test.eventData, test.sdr).String()
+		if got != test.expected {
+			t.Errorf("test %s not matched, got: %s, expected: %s", test.name, got, test.expected)
+		}
+	}
+}
@@ -813,6 +813,7 @@ var SensorSpecificEvents = map[SensorType]map[uint8]Event{
 			EventName:           "Configuration error",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED3:                 powerSupplyConfigurationErrors,
 		},
 		0x07: {
 			EventName:           "Power Supply Inactive (in standby state)",
@@ -975,16 +976,19 @@ var SensorSpecificEvents = map[SensorType]map[uint8]Event{
 			EventName:           "System Firmware Error (POST Error)",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 systemFirmwareErrors,
 		},
 		0x01: {
 			EventName:           "System Firmware Hang",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 systemFirmwareProgresses,
 		},
 		0x02: {
 			EventName:           "System Firmware Progress",
 			AssertionSeverity:   EventSeverityInfo,
 			DeassertionSeverity: EventSeverityInfo,
+			ED2:                 systemFirmwareProgresses,
 		},
 	},
 	SensorTypeEventLoggingDisabled: {
@@ -1638,41 +1642,49 @@ var SensorSpecificEvents = map[SensorType]map[uint8]Event{
 			EventName:           "Hardware change detected with associated Entity",
 			AssertionSeverity:   EventSeverityWarning,
 			DeassertionSeverity: EventSeverityWarning,
+			ED2:                 versionChangeTypes,
 		},
 		0x01: {
 			EventName:           "Firmware or software change detected with associated Entity",
 			AssertionSeverity:   EventSeverityWarning,
 			DeassertionSeverity: EventSeverityWarning,
+			ED2:                 versionChangeTypes,
 		},
 		0x02: {
 			EventName:           "Hardware incompatibility detected with associated Entity",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 versionChangeTypes,
 		},
 		0x03: {
 			EventName:           "Firmware or software incompatibility detected with associated Entity",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 versionChangeTypes,
 		},
 		0x04: {
 			EventName:           "Entity is of an invalid or unsupported hardware version",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 versionChangeTypes,
 		},
 		0x05: {
 			EventName:           "Entity contains an invalid or unsupported firmware or software version",
 			AssertionSeverity:   EventSeverityCritical,
 			DeassertionSeverity: EventSeverityCritical,
+			ED2:                 versionChangeTypes,
 		},
 		0x06: {
 			EventName:           "Hardware Change detected with associated Entity was successfu",
 			AssertionSeverity:   EventSeverityInfo,
 			DeassertionSeverity: EventSeverityInfo,
+			ED2:                 versionChangeTypes,
 		},
 		0x07: {
 			EventName:           "Software or F/W Change detected with associated Entity was successful",
 			AssertionSeverity:   EventSeverityInfo,
 			DeassertionSeverity: EventSeverityInfo,
+			ED2:                 versionChangeTypes,
 		},
 	},
 	SensorTypeFRUState: {
@@ -1718,3 +1730,90 @@ var SensorSpecificEvents = map[SensorType]map[uint8]Event{
 		},
 	},
 }
+
+// Table 42-3, Sensor Type Codes and Data
+// The values of Event Data 2 and Event Data 3 for the sensor-specific offsets.
+
+// Power Supply, Configuration error, Event Data 3 [3:0] - Error Type
+var powerSupplyConfigurationErrors = map[uint8]string{
+	0x00: "Vendor mismatch",
+	0x01: "Revision mismatch",
+	0x02: "Processor missing",
+	0x03: "Power Supply rating mismatch",
+	0x04: "Voltage rating mismatch",
+}
+
+// System Firmware Progress, System Firmware Error (POST Error), Event Data 2 - POST error code
+var systemFirmwareErrors = map[uint8]string{
+	0x00: "Unspecified",
+	0x01: "No system memory is physically installed in the system",
+	0x02: "No usable system memory, all installed memory has experienced an unrecoverable failure",
+	0x03: "Unrecoverable hard-disk/ATAPI/IDE device failure",
+	0x04: "Unrecoverable system-board failure",
+	0x05: "Unrecoverable diskette subsystem failure",
+	0x06: "Unrecoverable hard-disk controller failure",
+	0x07: "Unrecoverable PS/2 or USB keyboard failure",
+	0x08: "Removable boot media not found",
+	0x09: "Unrecoverable video controller failure",
+	0x0a: "No video device detected",
+	0x0b: "Firmware (BIOS) ROM corruption detected",
+	0x0c: "CPU voltage mismatch (processors that share same supply have mismatched voltage requirements)",
+	0x0d: "CPU speed matching failure",
+}
+
+// System Firmware Progress, System Firmware Hang and System Firmware Progress, Event Data 2 - progress code
+var systemFirmwareProgresses = map[uint8]string{
+	0x00: "Unspecified",
+	0x01: "Memory initialization",
+	0x02: "Hard-disk initialization",
+	0x03: "Secondary processor(s) initialization",
+	0x04: "User authentication",
+	0x05: "User-initiated system setup",
+	0x06: "USB resource configuration",
+	0x07: "PCI resource configuration",
+	0x08: "Option ROM initialization",
+	0x09: "Video initialization",
+	0x0a: "Cache initialization",
+	0x0b: "SM Bus initialization",
+	0x0c: "Keyboard controller initialization",
+	0x0d: "Embedded controller/management controller initialization",
+	0x0e: "Docking station attachment",
+	0x0f: "Enabling docking station",
+	0x10: "Docking station ejection",
+	0x11: "Disabling docking station",
+	0x12: "Calling operating system wake-up vector",
+	0x13: "Starting operating system boot process",
+	0x14: "Baseboard or motherboard initialization",
+	0x16: "Floppy initialization",
+	0x17: "Keyboard test",
+	0x18: "Pointing device test",
+	0x19: "Primary processor initialization",
+}
+
+// Version Change, Event Data 2 - version change type
+var versionChangeTypes = map[uint8]string{
+	0x00: "unspecified",
+	0x01: "management controller device ID",
+	0x02: "management controller firmware revision",
+	0x03: "management controller device revision",
+	0x04: "management controller manufacturer ID",
+	0x05: "management controller IPMI version",
+	0x06: "management controller auxiliary firmware ID",
+	0x07: "management controller firmware boot block",
+	0x08: "other management controller firmware",
+	0x09: "system firmware (EFI / BIOS) change",
+	0x0a: "SMBIOS change",
+	0x0b: "operating system change",
+	0x0c: "operating system loader change",
+	0x0d: "service or diagnostic partition change",
+	0x0e: "management software agent change",
+	0x0f: "management software application change",
+	0x10: "management software middleware change",
+	0x11: "programmable hardware change (e.g. FPGA)",
+	0x12: "board/FRU module change",
+	0x13: "board/FRU component change",
+	0x14: "board/FRU replaced with equivalent version",
+	0x15: "board/FRU replaced with newer version",
+	0x16: "board/FRU replaced with older version",
+	0x17: "board/FRU hardware configuration change",
+}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "fmt"
+
 // 5.4 Sensor Owner Identification
 // the "owner" of the sensor.
 // The combination of Sensor Owner ID and Sensor Number uniquely identify a sensor in the system.
@@ -88,9 +90,48 @@ const (
 	GeneratorLinuxKernelPanic GeneratorID = 0x0021
 )
 
+// OwnerID returns the LSB of GeneratorID, that is the Slave Address or Software ID.
+// The least significant bit is 0b for Slave Address, 1b for Software ID.
+func (g GeneratorID) OwnerID() uint8 {
+	return uint8(g)
+}
+
+// Channel returns the channel number carried in the MSB of GeneratorID.
+func (g GeneratorID) Channel() uint8 {
+	return uint8(g>>8) >> 4
+}
+
+// LUN returns the LUN carried in the MSB of GeneratorID.
+func (g GeneratorID) LUN() uint8 {
+	return uint8(g>>8) & 0x03
+}
+
 // see: Intel System Event Log (SEL) Troubleshooting Guide Rev 3.4 September 2019 section 3.1
 type SensorNumber uint8
 
 const SensorNumberReserved = 0xff
 
 type SDRMapBySensorNumber map[GeneratorID]map[SensorNumber]*SDR
+
+// SensorKey identifies a sensor by the sensor owner, the sensor owner LUN and the sensor number.
+//
+// Sensor numbers are only unique for a sensor owner and LUN, sensors owned by different
+// management controllers (like the BMC and the ME) can have the same sensor number.
+type SensorKey struct {
+	OwnerID  uint8 // Slave Address or Software ID of the sensor owner
+	OwnerLUN uint8
+	Number   SensorNumber
+}
+
+// SensorKey returns the key of the sensor with the sensor number owned by the GeneratorID.
+func (g GeneratorID) SensorKey(number SensorNumber) SensorKey {
+	return SensorKey{
+		OwnerID:  g.OwnerID(),
+		OwnerLUN: g.LUN(),
+		Number:   number,
+	}
+}
+
+func (k SensorKey) String() string {
+	return fmt.Sprintf("%#02x:%d:%#02x", k.OwnerID, k.OwnerLUN, k.Number)
+}
@@ -148,25 +148,32 @@ func (c *Client) BuildIPMIRequest(reqCmd Request) (*IPMIRequest, error) {
 		ResponderAddr: BMC_SA,
 
 		NetFn:        reqCmd.Command().NetFn,
-		ResponderLUN: uint8(IPMB_LUN_BMC),
+		ResponderLUN: c.responder.responderLUN(),
 
 		RequesterAddr: RemoteConsole_SWID,
 
-		RequesterSequence: c.session.ipmiSeq,
+		RequesterSequence: c.nextIPMISeq(),
 		RequesterLUN:      0x00,
 
 		Command:     reqCmd.Command().ID,
 		CommandData: reqCmd.Pack(),
 	}
 
+	ipmiReq.ComputeChecksum()
+
+	return ipmiReq, nil
+}
+
+// nextIPMISeq returns the requester sequence for the next request.
+func (c *Client) nextIPMISeq() uint8 {
+	seq := c.session.ipmiSeq
+
 	c.session.ipmiSeq += 1
 	if c.session.ipmiSeq > IPMIRequesterSequenceMax {
 		c.session.ipmiSeq = 1
 	}
 
-	ipmiReq.ComputeChecksum()
-
-	return ipmiReq, nil
+	return seq
 }
 
 // AllCC returns all possible completion codes for the specified response.
@@ -0,0 +1,132 @@
+package ipmi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// OutputFormat is the format of the output of SEL records, sensors, SDRs, FRUs and device ID.
+type OutputFormat string
+
+const (
+	// The tablewriter table returned by the FormatXxx functions, not supported by OutputEncoder.
+	OutputFormatTable OutputFormat = "table"
+
+	// A JSON array of the records.
+	OutputFormatJSON OutputFormat = "json"
+
+	// One JSON object per line for each record.
+	OutputFormatNDJSON OutputFormat = "ndjson"
+
+	// CSV rows without header, the columns are compatible with the ipmitool -c output if ipmitool has one.
+	OutputFormatCSV OutputFormat = "csv"
+
+	// One RFC 5424 syslog line per record.
+	OutputFormatSyslog OutputFormat = "syslog"
+)
+
+var OutputFormats = []OutputFormat{
+	OutputFormatTable,
+	OutputFormatJSON,
+	OutputFormatNDJSON,
+	OutputFormatCSV,
+	OutputFormatSyslog,
+}
+
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	for _, format := range OutputFormats {
+		if string(format) == s {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format (%s), supported: %v", s, OutputFormats)
+}
+
+// OutputRecord is the machine-readable output of a SEL record, sensor, SDR, FRU or device ID.
+// The JSON schema of the record is given by the json tags of the struct,
+// the fields of the schema are only added, not renamed or removed.
+type OutputRecord interface {
+	// CSVRows returns the CSV rows of the record, mostly one row.
+	CSVRows() [][]string
+
+	// SyslogMessage returns the record as a syslog message,
+	// the Facility, Hostname and AppName are filled by OutputEncoder.
+	SyslogMessage() *SyslogMessage
+}
+
+// OutputEncoder writes the records in the output format.
+type OutputEncoder struct {
+	Format OutputFormat
+
+	// Used by the syslog format.
+	Facility SyslogFacility
+	Hostname string
+	AppName  string
+}
+
+// NewOutputEncoder returns an OutputEncoder for the format, the syslog messages
+// are written with local0 facility and "goipmi" app name.
+func NewOutputEncoder(format OutputFormat) *OutputEncoder {
+	return &OutputEncoder{
+		Format:   format,
+		Facility: SyslogFacilityLocal0,
+		AppName:  "goipmi",
+	}
+}
+
+// Encode writes the records to w.
+func (e *OutputEncoder) Encode(w io.Writer, records ...OutputRecord) error {
+	switch e.Format {
+	case OutputFormatJSON:
+		if records == nil {
+			records = []OutputRecord{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(records); err != nil {
+			return fmt.Errorf("encode records failed, err: %s", err)
+		}
+		return nil
+
+	case OutputFormatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, record := range records {
+			if err := enc.Encode(record); err != nil {
+				return fmt.Errorf("encode record failed, err: %s", err)
+			}
+		}
+		return nil
+
+	case OutputFormatCSV:
+		csvWriter := csv.NewWriter(w)
+		for _, record := range records {
+			if err := csvWriter.WriteAll(record.CSVRows()); err != nil {
+				return fmt.Errorf("write csv failed, err: %s", err)
+			}
+		}
+		return nil
+
+	case OutputFormatSyslog:
+		for _, record := range records {
+			if _, err := fmt.Fprintln(w, e.SyslogMessage(record).String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("output format (%s) not supported by OutputEncoder", e.Format)
+}
+
+// SyslogMessage returns the syslog message of the record, with the Facility, Hostname and AppName of the encoder.
+func (e *OutputEncoder) SyslogMessage(record OutputRecord) *SyslogMessage {
+	msg := record.SyslogMessage()
+	msg.Facility = e.Facility
+	msg.Hostname = e.Hostname
+	msg.AppName = e.AppName
+	return msg
+}
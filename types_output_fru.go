@@ -0,0 +1,316 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// FRUOutput is the machine-readable output of a FRU device.
+//
+// There's no ipmitool equivalent for the CSV output, the CSV has one row per field
+// with the field names of ipmitool fru print:
+//
+//	device_id,device_name,field,value
+type FRUOutput struct {
+	DeviceID   uint8  `json:"device_id"`
+	DeviceName string `json:"device_name"`
+	Present    bool   `json:"present"`
+
+	Chassis *FRUChassisOutput `json:"chassis,omitempty"`
+	Board   *FRUBoardOutput   `json:"board,omitempty"`
+	Product *FRUProductOutput `json:"product,omitempty"`
+
+	// The record types of the multi records
+	MultiRecords []string `json:"multi_records,omitempty"`
+}
+
+type FRUChassisOutput struct {
+	Type         string   `json:"type"`
+	PartNumber   string   `json:"part_number"`
+	SerialNumber string   `json:"serial_number"`
+	Extra        []string `json:"extra,omitempty"`
+}
+
+type FRUBoardOutput struct {
+	// RFC 3339 timestamp
+	MfgDate      string   `json:"mfg_date"`
+	Manufacturer string   `json:"manufacturer"`
+	ProductName  string   `json:"product_name"`
+	SerialNumber string   `json:"serial_number"`
+	PartNumber   string   `json:"part_number"`
+	FRUFileID    string   `json:"fru_file_id,omitempty"`
+	Extra        []string `json:"extra,omitempty"`
+}
+
+type FRUProductOutput struct {
+	Manufacturer string   `json:"manufacturer"`
+	Name         string   `json:"name"`
+	PartNumber   string   `json:"part_number"`
+	Version      string   `json:"version"`
+	SerialNumber string   `json:"serial_number"`
+	AssetTag     string   `json:"asset_tag"`
+	FRUFileID    string   `json:"fru_file_id,omitempty"`
+	Extra        []string `json:"extra,omitempty"`
+}
+
+func NewFRUOutput(fru *FRU) *FRUOutput {
+	out := &FRUOutput{
+		DeviceID:   fru.DeviceID(),
+		DeviceName: fru.DeviceName(),
+		Present:    fru.Present(),
+	}
+
+	if c := fru.ChassisInfoArea; c != nil {
+		out.Chassis = &FRUChassisOutput{
+			Type:         c.ChassisType.String(),
+			PartNumber:   fruFieldString(c.PartNumberTypeLength, c.PartNumber),
+			SerialNumber: fruFieldString(c.SerialNumberTypeLength, c.SerialNumber),
+			Extra:        fruCustomStrings(c.Custom),
+		}
+	}
+
+	if b := fru.BoardInfoArea; b != nil {
+		out.Board = &FRUBoardOutput{
+			MfgDate:      b.MfgDateTime.Format(time.RFC3339),
+			Manufacturer: fruFieldString(b.ManufacturerTypeLength, b.Manufacturer),
+			ProductName:  fruFieldString(b.ProductNameTypeLength, b.ProductName),
+			SerialNumber: fruFieldString(b.SerialNumberTypeLength, b.SerialNumber),
+			PartNumber:   fruFieldString(b.PartNumberTypeLength, b.PartNumber),
+			FRUFileID:    fruFieldString(b.FRUFileIDTypeLength, b.FRUFileID),
+			Extra:        fruCustomStrings(b.Custom),
+		}
+	}
+
+	if p := fru.ProductInfoArea; p != nil {
+		out.Product = &FRUProductOutput{
+			Manufacturer: fruFieldString(p.ManufacturerTypeLength, p.Manufacturer),
+			Name:         fruFieldString(p.NameTypeLength, p.Name),
+			PartNumber:   fruFieldString(p.PartModelTypeLength, p.PartModel),
+			Version:      fruFieldString(p.VersionTypeLength, p.Version),
+			SerialNumber: fruFieldString(p.SerialNumberTypeLength, p.SerialNumber),
+			AssetTag:     fruFieldString(p.AssetTagTypeLength, p.AssetTag),
+			FRUFileID:    fruFieldString(p.FRUFileIDTypeLength, p.FRUFileID),
+			Extra:        fruCustomStrings(p.Custom),
+		}
+	}
+
+	for _, multiRecord := range fru.MultiRecords {
+		out.MultiRecords = append(out.MultiRecords, multiRecord.RecordType.String())
+	}
+
+	return out
+}
+
+func NewFRUOutputs(frus []*FRU) []OutputRecord {
+	out := make([]OutputRecord, 0, len(frus))
+	for _, fru := range frus {
+		out = append(out, NewFRUOutput(fru))
+	}
+	return out
+}
+
+// fruFieldString decodes the FRU field by its type/length byte, the raw bytes are used if it fails.
+func fruFieldString(typeLength TypeLength, raw []byte) string {
+	s, err := typeLength.CharsString(raw)
+	if err != nil {
+		return string(raw)
+	}
+	return s
+}
+
+func fruCustomStrings(custom [][]byte) []string {
+	var out []string
+	for _, v := range custom {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+// fields returns the fields of the FRU in the order and names of ipmitool fru print.
+func (out *FRUOutput) fields() [][2]string {
+	fields := [][2]string{}
+	if !out.Present {
+		return append(fields, [2]string{"Device not present", ""})
+	}
+
+	if c := out.Chassis; c != nil {
+		fields = append(fields,
+			[2]string{"Chassis Type", c.Type},
+			[2]string{"Chassis Part Number", c.PartNumber},
+			[2]string{"Chassis Serial", c.SerialNumber},
+		)
+		for _, v := range c.Extra {
+			fields = append(fields, [2]string{"Chassis Extra", v})
+		}
+	}
+
+	if b := out.Board; b != nil {
+		fields = append(fields,
+			[2]string{"Board Mfg Date", b.MfgDate},
+			[2]string{"Board Mfg", b.Manufacturer},
+			[2]string{"Board Product", b.ProductName},
+			[2]string{"Board Serial", b.SerialNumber},
+			[2]string{"Board Part Number", b.PartNumber},
+		)
+		for _, v := range b.Extra {
+			fields = append(fields, [2]string{"Board Extra", v})
+		}
+	}
+
+	if p := out.Product; p != nil {
+		fields = append(fields,
+			[2]string{"Product Manufacturer", p.Manufacturer},
+			[2]string{"Product Name", p.Name},
+			[2]string{"Product Part Number", p.PartNumber},
+			[2]string{"Product Version", p.Version},
+			[2]string{"Product Serial", p.SerialNumber},
+			[2]string{"Product Asset Tag", p.AssetTag},
+		)
+		for _, v := range p.Extra {
+			fields = append(fields, [2]string{"Product Extra", v})
+		}
+	}
+
+	for _, v := range out.MultiRecords {
+		fields = append(fields, [2]string{"Multi Record", v})
+	}
+
+	return fields
+}
+
+func (out *FRUOutput) CSVRows() [][]string {
+	rows := [][]string{}
+	for _, field := range out.fields() {
+		rows = append(rows, []string{fmt.Sprintf("%d", out.DeviceID), out.DeviceName, field[0], field[1]})
+	}
+	return rows
+}
+
+func (out *FRUOutput) SyslogMessage() *SyslogMessage {
+	msg := &SyslogMessage{
+		Severity: SyslogSeverityInformational,
+		MsgID:    "FRU",
+		Params: []SyslogParam{
+			{"deviceId", fmt.Sprintf("%d", out.DeviceID)},
+			{"deviceName", out.DeviceName},
+		},
+		Message: fmt.Sprintf("FRU %s (ID %d)", out.DeviceName, out.DeviceID),
+	}
+
+	if !out.Present {
+		msg.Message += " not present"
+		return msg
+	}
+
+	if out.Board != nil {
+		msg.Params = append(msg.Params,
+			SyslogParam{"boardManufacturer", out.Board.Manufacturer},
+			SyslogParam{"boardSerial", out.Board.SerialNumber},
+		)
+	}
+	if out.Product != nil {
+		msg.Params = append(msg.Params,
+			SyslogParam{"productName", out.Product.Name},
+			SyslogParam{"productSerial", out.Product.SerialNumber},
+		)
+	}
+
+	return msg
+}
+
+// DeviceIDOutput is the machine-readable output of the Get Device ID response.
+//
+// There's no ipmitool equivalent for the CSV output, the CSV has one row per field
+// with the field names of ipmitool mc info:
+//
+//	field,value
+type DeviceIDOutput struct {
+	DeviceID          uint8  `json:"device_id"`
+	DeviceRevision    uint8  `json:"device_revision"`
+	FirmwareRevision  string `json:"firmware_revision"`
+	IPMIVersion       string `json:"ipmi_version"`
+	ManufacturerID    uint32 `json:"manufacturer_id"`
+	ManufacturerName  string `json:"manufacturer_name"`
+	ProductID         uint16 `json:"product_id"`
+	DeviceAvailable   bool   `json:"device_available"`
+	ProvideDeviceSDRs bool   `json:"provide_device_sdrs"`
+
+	// Like "Chassis Device", "SEL Device"
+	AdditionalDeviceSupport []string `json:"additional_device_support"`
+
+	// The hex of the auxiliary firmware revision, empty if not present
+	AuxFirmwareRevision string `json:"aux_firmware_revision,omitempty"`
+}
+
+func NewDeviceIDOutput(res *GetDeviceIDResponse) *DeviceIDOutput {
+	out := &DeviceIDOutput{
+		DeviceID:                res.DeviceID,
+		DeviceRevision:          res.DeviceRevision,
+		FirmwareRevision:        fmt.Sprintf("%d.%02d", res.MajorFirmwareRevision, res.MinorFirmwareRevision),
+		IPMIVersion:             fmt.Sprintf("%d.%d", res.MajorIPMIVersion, res.MinorIPMIVersion),
+		ManufacturerID:          res.ManufacturerID,
+		ManufacturerName:        OEM(res.ManufacturerID).String(),
+		ProductID:               res.ProductID,
+		DeviceAvailable:         res.DeviceAvailable,
+		ProvideDeviceSDRs:       res.ProvideDeviceSDRs,
+		AdditionalDeviceSupport: []string{},
+		AuxFirmwareRevision:     fmt.Sprintf("%x", res.AuxiliaryFirmwareRevision),
+	}
+
+	supports := []struct {
+		supported bool
+		name      string
+	}{
+		{res.SupportChassis, "Chassis Device"},
+		{res.SupportBridge, "Bridge"},
+		{res.SupportIPMBEventGenerator, "IPMB Event Generator"},
+		{res.SupportIPMBEventReceiver, "IPMB Event Receiver"},
+		{res.SupportFRUInventory, "FRU Inventory Device"},
+		{res.SupportSEL, "SEL Device"},
+		{res.SupportSDRRepo, "SDR Repository Device"},
+		{res.SupportSensor, "Sensor Device"},
+	}
+	for _, support := range supports {
+		if support.supported {
+			out.AdditionalDeviceSupport = append(out.AdditionalDeviceSupport, support.name)
+		}
+	}
+
+	return out
+}
+
+func (out *DeviceIDOutput) CSVRows() [][]string {
+	rows := [][]string{
+		{"Device ID", fmt.Sprintf("%d", out.DeviceID)},
+		{"Device Revision", fmt.Sprintf("%d", out.DeviceRevision)},
+		{"Firmware Revision", out.FirmwareRevision},
+		{"IPMI Version", out.IPMIVersion},
+		{"Manufacturer ID", fmt.Sprintf("%d", out.ManufacturerID)},
+		{"Manufacturer Name", out.ManufacturerName},
+		{"Product ID", fmt.Sprintf("%d", out.ProductID)},
+		{"Device Available", formatBool(out.DeviceAvailable, "yes", "no")},
+		{"Provides Device SDRs", formatBool(out.ProvideDeviceSDRs, "yes", "no")},
+	}
+	for _, v := range out.AdditionalDeviceSupport {
+		rows = append(rows, []string{"Additional Device Support", v})
+	}
+	if out.AuxFirmwareRevision != "" {
+		rows = append(rows, []string{"Aux Firmware Rev Info", out.AuxFirmwareRevision})
+	}
+	return rows
+}
+
+func (out *DeviceIDOutput) SyslogMessage() *SyslogMessage {
+	return &SyslogMessage{
+		Severity: SyslogSeverityInformational,
+		MsgID:    "MC",
+		Params: []SyslogParam{
+			{"deviceId", fmt.Sprintf("%d", out.DeviceID)},
+			{"firmwareRevision", out.FirmwareRevision},
+			{"ipmiVersion", out.IPMIVersion},
+			{"manufacturerId", fmt.Sprintf("%d", out.ManufacturerID)},
+			{"productId", fmt.Sprintf("%d", out.ProductID)},
+		},
+		Message: fmt.Sprintf("%s firmware %s", out.ManufacturerName, out.FirmwareRevision),
+	}
+}
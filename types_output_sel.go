@@ -0,0 +1,225 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// SELOutput is the machine-readable output of a SEL record.
+//
+// The CSV columns are the same as ipmitool -c sel list (sel elist if the SDR of the sensor is given):
+//
+//	standard:            id,date,time,sensor,event,direction[,detail]
+//	timestamped OEM:     id,date,time,OEM record <type>,<manufacturer id>,<OEM data>
+//	non-timestamped OEM: id,OEM record <type>,<OEM data>
+//
+// The id is hex without 0x prefix, date is mm/dd/yyyy, and the sensor is the sensor type followed by
+// the sensor name, or the sensor number like "#0x01" if the SDR is not given. The detail column is the
+// event data 2/3 and OEM information decoded, only present if there's any.
+type SELOutput struct {
+	RecordID   uint16 `json:"record_id"`
+	RecordType uint8  `json:"record_type"`
+	// "standard", "timestamped OEM" or "non-timestamped OEM"
+	RecordTypeRange string `json:"record_type_range"`
+	// RFC 3339 timestamp, empty for non-timestamped OEM records
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Only for standard records
+	Standard *SELStandardOutput `json:"standard,omitempty"`
+	// Only for timestamped and non-timestamped OEM records
+	OEM *SELOEMOutput `json:"oem,omitempty"`
+
+	timestamp time.Time
+}
+
+type SELStandardOutput struct {
+	GeneratorID    uint16 `json:"generator_id"`
+	EvMRev         uint8  `json:"evm_rev"`
+	SensorNumber   uint8  `json:"sensor_number"`
+	SensorTypeCode uint8  `json:"sensor_type_code"`
+	SensorType     string `json:"sensor_type"`
+	// empty if the SDR of the sensor is not given
+	SensorName string `json:"sensor_name,omitempty"`
+
+	EventReadingType uint8 `json:"event_reading_type"`
+	// "Asserted" or "Deasserted"
+	EventDirection string `json:"event_direction"`
+	EventSeverity  string `json:"event_severity"`
+	// The description of the event offset
+	Event string `json:"event"`
+	// The event data 2/3 and OEM information decoded, see EventDetail and SELOEMDecoder
+	EventDetail string `json:"event_detail,omitempty"`
+	// The hex of event data 1, 2 and 3
+	EventData string `json:"event_data"`
+}
+
+type SELOEMOutput struct {
+	// Only for timestamped OEM records
+	ManufacturerID uint32 `json:"manufacturer_id,omitempty"`
+	// The hex of the OEM defined bytes
+	Data string `json:"data"`
+	// Decoded by SELOEMDecoder
+	Description string `json:"description,omitempty"`
+}
+
+// NewSELOutput returns the output of the SEL record.
+// The sdr of the sensor and the OEM decoder are optional.
+func NewSELOutput(sel *SEL, sdr *SDR, decoder SELOEMDecoder) *SELOutput {
+	out := &SELOutput{
+		RecordID:        sel.RecordID,
+		RecordType:      uint8(sel.RecordType),
+		RecordTypeRange: string(sel.RecordType.Range()),
+		timestamp:       sel.Timestamp(),
+	}
+	if !out.timestamp.IsZero() {
+		out.Timestamp = out.timestamp.Format(time.RFC3339)
+	}
+
+	oemDesc, _ := DecodeOEMSEL(sel, decoder)
+
+	switch {
+	case sel.Standard != nil:
+		s := sel.Standard
+
+		detail := s.EventDetail(sdr)
+		detail.EventName = ""
+		eventDetail := detail.String()
+		if oemDesc != "" {
+			if eventDetail != "" {
+				eventDetail += ", "
+			}
+			eventDetail += oemDesc
+		}
+
+		out.Standard = &SELStandardOutput{
+			GeneratorID:      uint16(s.GeneratorID),
+			EvMRev:           s.EvMRev,
+			SensorNumber:     uint8(s.SensorNumber),
+			SensorTypeCode:   uint8(s.SensorType),
+			SensorType:       s.SensorType.String(),
+			EventReadingType: uint8(s.EventReadingType),
+			EventDirection:   eventDirectionString(s.EventDir),
+			EventSeverity:    string(s.EventSeverity()),
+			Event:            s.EventString(),
+			EventDetail:      eventDetail,
+			EventData:        s.EventData.String(),
+		}
+		if sdr != nil {
+			out.Standard.SensorName = sdr.SensorName()
+		}
+
+	case sel.OEMTimestamped != nil:
+		out.OEM = &SELOEMOutput{
+			ManufacturerID: sel.OEMTimestamped.ManufacturerID,
+			Data:           fmt.Sprintf("%x", sel.OEMTimestamped.OEMDefined),
+			Description:    oemDesc,
+		}
+
+	case sel.OEMNonTimestamped != nil:
+		out.OEM = &SELOEMOutput{
+			Data:        fmt.Sprintf("%x", sel.OEMNonTimestamped.OEM),
+			Description: oemDesc,
+		}
+	}
+
+	return out
+}
+
+// NewSELOutputs returns the outputs of the SEL records,
+// the SDRs of the sensors are looked up in sdrMap. The sdrMap and the decoder are optional.
+func NewSELOutputs(records []*SEL, sdrMap SDRMapBySensorNumber, decoder SELOEMDecoder) []OutputRecord {
+	out := make([]OutputRecord, 0, len(records))
+	for _, sel := range records {
+		var sdr *SDR
+		if sel.Standard != nil {
+			sdr = sdrMap[sel.Standard.GeneratorID][sel.Standard.SensorNumber]
+		}
+		out = append(out, NewSELOutput(sel, sdr, decoder))
+	}
+	return out
+}
+
+// eventDirectionString returns the event direction in ipmitool words.
+func eventDirectionString(d EventDir) string {
+	if d {
+		return "Deasserted"
+	}
+	return "Asserted"
+}
+
+func (out *SELOutput) CSVRows() [][]string {
+	id := fmt.Sprintf("%x", out.RecordID)
+	date := out.timestamp.Format("01/02/2006")
+	clock := out.timestamp.Format("15:04:05")
+
+	switch {
+	case out.Standard != nil:
+		s := out.Standard
+		sensor := fmt.Sprintf("%s #0x%02x", s.SensorType, s.SensorNumber)
+		if s.SensorName != "" {
+			sensor = fmt.Sprintf("%s %s", s.SensorType, s.SensorName)
+		}
+		row := []string{id, date, clock, sensor, s.Event, s.EventDirection}
+		if s.EventDetail != "" {
+			row = append(row, s.EventDetail)
+		}
+		return [][]string{row}
+
+	case out.OEM != nil && out.Timestamp != "":
+		return [][]string{{id, date, clock, fmt.Sprintf("OEM record %02x", out.RecordType), fmt.Sprintf("%06x", out.OEM.ManufacturerID), out.OEM.Data}}
+
+	case out.OEM != nil:
+		return [][]string{{id, fmt.Sprintf("OEM record %02x", out.RecordType), out.OEM.Data}}
+	}
+
+	return [][]string{{id}}
+}
+
+func (out *SELOutput) SyslogMessage() *SyslogMessage {
+	msg := &SyslogMessage{
+		Severity:  SyslogSeverityInformational,
+		Timestamp: out.timestamp,
+		MsgID:     "SEL",
+		Params: []SyslogParam{
+			{"recordId", fmt.Sprintf("%#04x", out.RecordID)},
+			{"recordType", fmt.Sprintf("%#02x", out.RecordType)},
+		},
+	}
+
+	switch {
+	case out.Standard != nil:
+		s := out.Standard
+		msg.Severity = eventSeverityToSyslog(EventSeverity(s.EventSeverity))
+		msg.Params = append(msg.Params,
+			SyslogParam{"generatorId", fmt.Sprintf("%#04x", s.GeneratorID)},
+			SyslogParam{"sensorNumber", fmt.Sprintf("%#02x", s.SensorNumber)},
+			SyslogParam{"sensorType", s.SensorType},
+		)
+		if s.SensorName != "" {
+			msg.Params = append(msg.Params, SyslogParam{"sensorName", s.SensorName})
+		}
+		msg.Params = append(msg.Params,
+			SyslogParam{"eventDirection", s.EventDirection},
+			SyslogParam{"eventSeverity", s.EventSeverity},
+			SyslogParam{"eventData", s.EventData},
+		)
+
+		msg.Message = fmt.Sprintf("%s %s", s.Event, s.EventDirection)
+		if s.EventDetail != "" {
+			msg.Message = fmt.Sprintf("%s, %s", msg.Message, s.EventDetail)
+		}
+
+	case out.OEM != nil:
+		if out.OEM.ManufacturerID != 0 {
+			msg.Params = append(msg.Params, SyslogParam{"manufacturerId", fmt.Sprintf("%d", out.OEM.ManufacturerID)})
+		}
+		msg.Params = append(msg.Params, SyslogParam{"oemData", out.OEM.Data})
+
+		msg.Message = fmt.Sprintf("OEM record %02x", out.RecordType)
+		if out.OEM.Description != "" {
+			msg.Message = fmt.Sprintf("%s, %s", msg.Message, out.OEM.Description)
+		}
+	}
+
+	return msg
+}
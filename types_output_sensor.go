@@ -0,0 +1,297 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The threshold types in the order of ipmitool sensor list columns.
+var outputThresholdTypes = []SensorThresholdType{
+	SensorThresholdType_LNR,
+	SensorThresholdType_LCR,
+	SensorThresholdType_LNC,
+	SensorThresholdType_UNC,
+	SensorThresholdType_UCR,
+	SensorThresholdType_UNR,
+}
+
+// SensorOutput is the machine-readable output of a sensor.
+//
+// The CSV columns are the same as ipmitool -c sensor list:
+//
+//	name,value,unit,status,lnr,lcr,lnc,unc,ucr,unr
+//
+// The value and thresholds are "na" if not available. For discrete sensors,
+// the value is the raw reading in hex, the unit is "discrete", and the status is the state bits in hex.
+type SensorOutput struct {
+	Number         uint8  `json:"number"`
+	Name           string `json:"name"`
+	GeneratorID    uint16 `json:"generator_id"`
+	EntityID       uint8  `json:"entity_id"`
+	EntityInstance uint8  `json:"entity_instance"`
+	SensorTypeCode uint8  `json:"sensor_type_code"`
+	SensorType     string `json:"sensor_type"`
+
+	EventReadingType uint8 `json:"event_reading_type"`
+	// "threshold" or "discrete"
+	SensorClass string `json:"sensor_class"`
+
+	ReadingValid bool  `json:"reading_valid"`
+	Raw          uint8 `json:"raw"`
+	// The converted reading of threshold sensors, null if the reading is not valid or for discrete sensors
+	Value *float64 `json:"value"`
+	Unit  string   `json:"unit"`
+	// Threshold status like "ok", "lcr" for threshold sensors, or the state bits in hex for discrete sensors
+	Status string `json:"status"`
+
+	// The readable thresholds, keyed by the threshold abbreviation, like "ucr"
+	Thresholds map[string]float64 `json:"thresholds,omitempty"`
+
+	// The asserted states of discrete sensors
+	ActiveStates []string `json:"active_states,omitempty"`
+
+	// The error occurred when reading the sensor, see WithSensorErrorTolerance
+	Error string `json:"error,omitempty"`
+}
+
+func NewSensorOutput(sensor *Sensor) *SensorOutput {
+	out := &SensorOutput{
+		Number:           sensor.Number,
+		Name:             sensor.Name,
+		GeneratorID:      uint16(sensor.GeneratorID),
+		EntityID:         uint8(sensor.EntityID),
+		EntityInstance:   uint8(sensor.EntityInstance),
+		SensorTypeCode:   uint8(sensor.SensorType),
+		SensorType:       sensor.SensorType.String(),
+		EventReadingType: uint8(sensor.EventReadingType),
+		SensorClass:      string(sensor.EventReadingType.SensorClass()),
+		ReadingValid:     sensor.IsReadingValid(),
+		Raw:              sensor.Raw,
+		Unit:             sensor.SensorUnit.String(),
+		Status:           sensor.Status(),
+	}
+
+	if sensor.IsThreshold() {
+		if sensor.IsReadingValid() {
+			value := sensor.Value
+			out.Value = &value
+		}
+
+		for _, thresholdType := range outputThresholdTypes {
+			if sensor.IsThresholdReadable(thresholdType) {
+				if out.Thresholds == nil {
+					out.Thresholds = make(map[string]float64)
+				}
+				out.Thresholds[thresholdType.Abbr()] = sensor.ThresholdValue(thresholdType)
+			}
+		}
+	} else {
+		for _, offset := range sensor.Discrete.ActiveStates.TrueEvents() {
+			eventData := EventData{EventData1: uint8(offset)}
+			state := fmt.Sprintf("state %d", offset)
+			if event := sensor.EventReadingType.Event(sensor.SensorType, SensorNumber(sensor.Number), eventData); event != nil {
+				state = event.EventName
+			}
+			out.ActiveStates = append(out.ActiveStates, state)
+		}
+	}
+
+	if sensor.Err != nil {
+		out.Error = sensor.Err.Error()
+	}
+
+	return out
+}
+
+func NewSensorOutputs(sensors []*Sensor) []OutputRecord {
+	out := make([]OutputRecord, 0, len(sensors))
+	for _, sensor := range sensors {
+		out = append(out, NewSensorOutput(sensor))
+	}
+	return out
+}
+
+func (out *SensorOutput) CSVRows() [][]string {
+	value := "na"
+	if out.Value != nil {
+		value = fmt.Sprintf("%.3f", *out.Value)
+	} else if out.SensorClass == string(SensorClassDiscrete) && out.ReadingValid {
+		value = fmt.Sprintf("%#x", out.Raw)
+	}
+
+	unit := out.Unit
+	if out.SensorClass == string(SensorClassDiscrete) {
+		unit = "discrete"
+	}
+
+	status := out.Status
+	if !out.ReadingValid {
+		status = "na"
+	}
+
+	row := []string{out.Name, value, unit, status}
+	for _, thresholdType := range outputThresholdTypes {
+		threshold := "na"
+		if v, ok := out.Thresholds[thresholdType.Abbr()]; ok {
+			threshold = fmt.Sprintf("%.3f", v)
+		}
+		row = append(row, threshold)
+	}
+
+	return [][]string{row}
+}
+
+func (out *SensorOutput) SyslogMessage() *SyslogMessage {
+	msg := &SyslogMessage{
+		Severity: sensorStatusToSyslog(out.Status),
+		MsgID:    "SENSOR",
+		Params: []SyslogParam{
+			{"sensorNumber", fmt.Sprintf("%#02x", out.Number)},
+			{"sensorName", out.Name},
+			{"sensorType", out.SensorType},
+			{"status", out.Status},
+		},
+	}
+
+	reading := "na"
+	if out.Value != nil {
+		reading = fmt.Sprintf("%.3f %s", *out.Value, out.Unit)
+	} else if out.SensorClass == string(SensorClassDiscrete) && out.ReadingValid {
+		reading = fmt.Sprintf("%#x", out.Raw)
+	}
+	msg.Message = fmt.Sprintf("%s %s %s", out.Name, reading, out.Status)
+
+	if out.Error != "" {
+		msg.Severity = SyslogSeverityError
+		msg.Message = fmt.Sprintf("%s %s", out.Name, out.Error)
+	}
+
+	return msg
+}
+
+// sensorStatusToSyslog maps the threshold status of the sensor to the syslog severity.
+func sensorStatusToSyslog(status string) SyslogSeverity {
+	switch SensorThresholdStatus(strings.ToLower(status)) {
+	case SensorThresholdStatus_LNC, SensorThresholdStatus_UNC:
+		return SyslogSeverityWarning
+	case SensorThresholdStatus_LCR, SensorThresholdStatus_UCR:
+		return SyslogSeverityCritical
+	case SensorThresholdStatus_LNR, SensorThresholdStatus_UNR:
+		return SyslogSeverityAlert
+	}
+	return SyslogSeverityInformational
+}
+
+// SDROutput is the machine-readable output of a SDR.
+//
+// The SDR only holds the definition of the sensor, there's no ipmitool equivalent for the CSV output,
+// the CSV columns are:
+//
+//	record_id,record_type,name,sensor_number,entity,sensor_type,unit
+//
+// The record_id and sensor_number are hex like ipmitool "30h", the entity is "<entity id>.<entity instance>".
+type SDROutput struct {
+	RecordID       uint16 `json:"record_id"`
+	RecordTypeCode uint8  `json:"record_type_code"`
+	RecordType     string `json:"record_type"`
+
+	// The sensor name, or the device ID string of FRU, Generic and Management Controller Device Locator records
+	Name string `json:"name,omitempty"`
+
+	// The following fields are only set for Full, Compact and Event-Only sensor records
+	GeneratorID      uint16 `json:"generator_id,omitempty"`
+	SensorNumber     uint8  `json:"sensor_number,omitempty"`
+	EntityID         uint8  `json:"entity_id,omitempty"`
+	EntityInstance   uint8  `json:"entity_instance,omitempty"`
+	SensorTypeCode   uint8  `json:"sensor_type_code,omitempty"`
+	SensorType       string `json:"sensor_type,omitempty"`
+	EventReadingType uint8  `json:"event_reading_type,omitempty"`
+	// Only for Full and Compact sensor records
+	Unit string `json:"unit,omitempty"`
+	// The readable thresholds of Full sensor records of analog sensors, keyed by the threshold abbreviation
+	Thresholds map[string]float64 `json:"thresholds,omitempty"`
+}
+
+func NewSDROutput(sdr *SDR) *SDROutput {
+	out := &SDROutput{
+		RecordID:       sdr.RecordHeader.RecordID,
+		RecordTypeCode: uint8(sdr.RecordHeader.RecordType),
+		RecordType:     sdr.RecordHeader.RecordType.String(),
+	}
+
+	switch sdr.RecordHeader.RecordType {
+	case SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor, SDRRecordTypeEventOnly:
+		entityID, entityInstance := sdr.Entity()
+		out.GeneratorID = uint16(sdr.GeneratorID())
+		out.SensorNumber = uint8(sdr.SensorNumber())
+		out.Name = sdr.SensorName()
+		out.EntityID = uint8(entityID)
+		out.EntityInstance = uint8(entityInstance)
+		out.SensorTypeCode = uint8(sdr.SensorType())
+		out.SensorType = sdr.SensorType().String()
+		out.EventReadingType = uint8(sdr.EventReadingType())
+	}
+
+	switch sdr.RecordHeader.RecordType {
+	case SDRRecordTypeFullSensor:
+		out.Unit = sdr.Full.SensorUnit.String()
+		if sdr.Full.SensorUnit.IsAnalog() && sdr.Full.SensorEventReadingType.IsThreshold() {
+			for _, thresholdType := range outputThresholdTypes {
+				threshold := sdr.Full.SensorThreshold(thresholdType)
+				if threshold.Mask.Readable {
+					if out.Thresholds == nil {
+						out.Thresholds = make(map[string]float64)
+					}
+					out.Thresholds[thresholdType.Abbr()] = sdr.Full.ConvertReading(threshold.Raw)
+				}
+			}
+		}
+	case SDRRecordTypeCompactSensor:
+		out.Unit = sdr.Compact.SensorUnit.String()
+	case SDRRecordTypeFRUDeviceLocator:
+		out.Name = string(sdr.FRUDeviceLocator.DeviceIDBytes)
+	case SDRRecordTypeGenericLocator:
+		out.Name = string(sdr.GenericDeviceLocator.DeviceIDString)
+	case SDRRecordTypeManagementControllerDeviceLocator:
+		out.Name = string(sdr.MgmtControllerDeviceLocator.DeviceIDBytes)
+	}
+
+	return out
+}
+
+func NewSDROutputs(sdrs []*SDR) []OutputRecord {
+	out := make([]OutputRecord, 0, len(sdrs))
+	for _, sdr := range sdrs {
+		if sdr == nil || sdr.RecordHeader == nil {
+			continue
+		}
+		out = append(out, NewSDROutput(sdr))
+	}
+	return out
+}
+
+func (out *SDROutput) CSVRows() [][]string {
+	return [][]string{{
+		fmt.Sprintf("%02xh", out.RecordID),
+		out.RecordType,
+		out.Name,
+		fmt.Sprintf("%02xh", out.SensorNumber),
+		fmt.Sprintf("%d.%d", out.EntityID, out.EntityInstance),
+		out.SensorType,
+		out.Unit,
+	}}
+}
+
+func (out *SDROutput) SyslogMessage() *SyslogMessage {
+	return &SyslogMessage{
+		Severity: SyslogSeverityInformational,
+		MsgID:    "SDR",
+		Params: []SyslogParam{
+			{"recordId", fmt.Sprintf("%#04x", out.RecordID)},
+			{"recordType", out.RecordType},
+			{"sensorNumber", fmt.Sprintf("%#02x", out.SensorNumber)},
+			{"sensorType", out.SensorType},
+		},
+		Message: strings.TrimSpace(fmt.Sprintf("%s %s", out.RecordType, out.Name)),
+	}
+}
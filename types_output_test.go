@@ -0,0 +1,165 @@
+package ipmi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_SyslogMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      *SyslogMessage
+		expected string
+	}{
+		{
+			name: "full",
+			msg: &SyslogMessage{
+				Facility:  SyslogFacilityLocal0,
+				Severity:  SyslogSeverityCritical,
+				Timestamp: time.Date(2024, 3, 16, 18, 30, 0, 0, time.UTC),
+				Hostname:  "bmc-01",
+				AppName:   "goipmi",
+				MsgID:     "SEL",
+				Params: []SyslogParam{
+					{"recordId", "0x0001"},
+					{"sensorName", `PSU1 "Status" [a\b]`},
+				},
+				Message: "Power Supply AC lost Asserted",
+			},
+			expected: `<130>1 2024-03-16T18:30:00Z bmc-01 goipmi - SEL [ipmi@7154 recordId="0x0001" sensorName="PSU1 \"Status\" [a\\b\]"] Power Supply AC lost Asserted`,
+		},
+		{
+			name: "nil values",
+			msg: &SyslogMessage{
+				Facility: SyslogFacilityUser,
+				Severity: SyslogSeverityInformational,
+				Hostname: "bmc 01",
+			},
+			expected: `<14>1 - bmc01 - - - -`,
+		},
+	}
+
+	for _, test := range tests {
+		got := test.msg.String()
+		if got != test.expected {
+			t.Errorf("test %s not matched\ngot:      %s\nexpected: %s", test.name, got, test.expected)
+		}
+	}
+}
+
+func Test_SELOutput(t *testing.T) {
+	// Temperature, Upper Critical going high
+	msg := []byte{0x01, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x01, 0x30, 0x01, 0x59, 0x5f, 0x5a}
+	sel, err := ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+
+	sdr := &SDR{
+		RecordHeader: &SDRHeader{RecordType: SDRRecordTypeFullSensor},
+		Full: &SDRFull{
+			SensorNumber: 0x30,
+			SensorUnit: SensorUnit{
+				AnalogDataFormat: SensorAnalogUnitFormat_Unsigned,
+				BaseUnit:         SensorUnitType_DegressC,
+			},
+			ReadingFactors:    ReadingFactors{M: 1},
+			LinearizationFunc: LinearizationFunc_Linear,
+			IDStringBytes:     []byte("CPU Temp"),
+		},
+	}
+
+	out := NewSELOutput(sel, sdr, nil)
+	date := sel.Timestamp().Format("01/02/2006")
+	clock := sel.Timestamp().Format("15:04:05")
+
+	rows := out.CSVRows()
+	expectedRow := []string{"1", date, clock, "Temperature CPU Temp", "Upper Critical - going high", "Asserted", "Reading 95.000 > Threshold 90.000 degrees C"}
+	if len(rows) != 1 || !stringsEqual(rows[0], expectedRow) {
+		t.Errorf("test csv not matched, got: %q, expected: %q", rows, expectedRow)
+	}
+
+	// without SDR, the sensor number is printed in two hex digits like ipmitool
+	msg[11] = 0x05
+	noSDR, err := ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+	if rows := NewSELOutput(noSDR, nil, nil).CSVRows(); len(rows) != 1 || rows[0][3] != "Temperature #0x05" {
+		t.Errorf("test csv sensor number not matched, got: %q", rows)
+	}
+
+	var b bytes.Buffer
+	if err := NewOutputEncoder(OutputFormatJSON).Encode(&b, out); err != nil {
+		t.Fatalf("test encode json failed, err: %s", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("test unmarshal json failed, err: %s", err)
+	}
+	standard, _ := decoded[0]["standard"].(map[string]interface{})
+	if decoded[0]["record_type_range"] != "standard" || standard["sensor_name"] != "CPU Temp" || standard["event_severity"] != string(EventSeverityWarning) {
+		t.Errorf("test json not matched, got: %s", b.String())
+	}
+
+	syslog := NewOutputEncoder(OutputFormatSyslog).SyslogMessage(out)
+	if syslog.Severity != SyslogSeverityWarning || syslog.Message != "Upper Critical - going high Asserted, Reading 95.000 > Threshold 90.000 degrees C" {
+		t.Errorf("test syslog not matched, got: %s", syslog)
+	}
+}
+
+func Test_SensorOutput_CSVRows(t *testing.T) {
+	threshold := &Sensor{
+		Name:             "CPU Temp",
+		EventReadingType: EventReadingTypeThreshold,
+		SensorUnit:       SensorUnit{BaseUnit: SensorUnitType_DegressC},
+		Value:            45,
+	}
+	threshold.Threshold.ThresholdStatus = SensorThresholdStatus_OK
+	threshold.Threshold.Mask.UCR.Readable = true
+	threshold.Threshold.UCR = 90
+
+	discrete := &Sensor{
+		Name:             "PSU1 Status",
+		SensorType:       SensorTypePowserSupply,
+		EventReadingType: EventReadingTypeSensorSpecific,
+		Raw:              0x01,
+	}
+
+	unavailable := &Sensor{
+		Name:               "Fan 1",
+		EventReadingType:   EventReadingTypeThreshold,
+		readingUnavailable: true,
+	}
+
+	tests := []struct {
+		name     string
+		sensor   *Sensor
+		expected []string
+	}{
+		{"threshold", threshold, []string{"CPU Temp", "45.000", "degrees C", "ok", "na", "na", "na", "na", "90.000", "na"}},
+		{"discrete", discrete, []string{"PSU1 Status", "0x1", "discrete", "0x0000", "na", "na", "na", "na", "na", "na"}},
+		{"unavailable", unavailable, []string{"Fan 1", "na", "unspecified", "na", "na", "na", "na", "na", "na", "na"}},
+	}
+
+	for _, test := range tests {
+		rows := NewSensorOutput(test.sensor).CSVRows()
+		if len(rows) != 1 || !stringsEqual(rows[0], test.expected) {
+			t.Errorf("test %s not matched, got: %q, expected: %q", test.name, rows, test.expected)
+		}
+	}
+}
+
+func stringsEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "fmt"
+
 // 13.27.3
 // The Get Channel Payload Support command returns which standard payload type numbers and OEM payload
 // type handles are available on a given channel of a BMC.
@@ -114,3 +116,109 @@ func (cryptAlg CryptAlg) String() string {
 	}
 	return ""
 }
+
+func (payloadType PayloadType) String() string {
+	m := map[PayloadType]string{
+		0x00: "IPMI",
+		0x01: "SOL",
+		0x02: "OEM Explicit",
+		0x10: "RMCP+ Open Session Request",
+		0x11: "RMCP+ Open Session Response",
+		0x12: "RAKP Message 1",
+		0x13: "RAKP Message 2",
+		0x14: "RAKP Message 3",
+		0x15: "RAKP Message 4",
+	}
+	s, ok := m[payloadType]
+	if ok {
+		return s
+	}
+	if payloadType >= PayloadTypeOEM0 && payloadType <= PayloadTypeOEM7 {
+		return fmt.Sprintf("OEM%d", payloadType-PayloadTypeOEM0)
+	}
+	return fmt.Sprintf("Payload %#02x", uint8(payloadType))
+}
+
+// 24.6 Set User Payload Access Command, 24.7 Get User Payload Access Command
+//
+// PayloadAccess holds the payload enable bits of an user.
+// Only standard payloads (01h-07h) and OEM payloads (20h-27h) can be enabled per user.
+type PayloadAccess struct {
+	// index N is standard payload type N, index 0 (IPMI) is reserved.
+	StandardPayloads [8]bool
+
+	// index N is OEM payload type 20h+N
+	OEMPayloads [8]bool
+}
+
+// Enabled reports whether the payload type is enabled.
+func (access *PayloadAccess) Enabled(payloadType PayloadType) bool {
+	switch {
+	case payloadType > PayloadTypeIPMI && payloadType <= 0x07:
+		return access.StandardPayloads[payloadType]
+	case payloadType >= PayloadTypeOEM0 && payloadType <= PayloadTypeOEM7:
+		return access.OEMPayloads[payloadType-PayloadTypeOEM0]
+	}
+	return false
+}
+
+// SetEnabled sets the enable bit of the payload type.
+// It returns error if the payload type can not be enabled per user.
+func (access *PayloadAccess) SetEnabled(payloadType PayloadType, enabled bool) error {
+	switch {
+	case payloadType > PayloadTypeIPMI && payloadType <= 0x07:
+		access.StandardPayloads[payloadType] = enabled
+	case payloadType >= PayloadTypeOEM0 && payloadType <= PayloadTypeOEM7:
+		access.OEMPayloads[payloadType-PayloadTypeOEM0] = enabled
+	default:
+		return fmt.Errorf("payload type (%#02x) can not be enabled per user", uint8(payloadType))
+	}
+	return nil
+}
+
+// EnabledPayloads returns all the enabled payload types.
+func (access *PayloadAccess) EnabledPayloads() []PayloadType {
+	out := make([]PayloadType, 0)
+	for i := 1; i < 8; i++ {
+		if access.StandardPayloads[i] {
+			out = append(out, PayloadType(i))
+		}
+	}
+	for i := 0; i < 8; i++ {
+		if access.OEMPayloads[i] {
+			out = append(out, PayloadTypeOEM0+PayloadType(i))
+		}
+	}
+	return out
+}
+
+// Pack encodes the payload enables to 4 bytes.
+func (access *PayloadAccess) Pack() []byte {
+	out := make([]byte, 4)
+	var std, oem uint8
+	for i := 0; i < 8; i++ {
+		if i > 0 && access.StandardPayloads[i] {
+			std |= 1 << i
+		}
+		if access.OEMPayloads[i] {
+			oem |= 1 << i
+		}
+	}
+	packUint8(std, out, 0)
+	packUint8(oem, out, 2)
+	return out
+}
+
+// Unpack decodes the payload enables from (at least) 4 bytes.
+func (access *PayloadAccess) Unpack(msg []byte) error {
+	if len(msg) < 4 {
+		return ErrUnpackedDataTooShort
+	}
+	std, _, _ := unpackUint8(msg, 0)
+	oem, _, _ := unpackUint8(msg, 2)
+	for i := 0; i < 8; i++ {
+		access.StandardPayloads[i] = i > 0 && std&(1<<i) != 0
+		access.OEMPayloads[i] = oem&(1<<i) != 0
+	}
+	return nil
+}
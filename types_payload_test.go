@@ -0,0 +1,104 @@
+package ipmi
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_PayloadRequest_Pack(t *testing.T) {
+	solAndOEM1 := PayloadAccess{}
+	solAndOEM1.SetEnabled(PayloadTypeSOL, true)
+	solAndOEM1.SetEnabled(PayloadTypeOEM1, true)
+
+	tests := []struct {
+		name     string
+		request  Request
+		expected []byte
+	}{
+		{"GetPayloadActivationStatus", &GetPayloadActivationStatusRequest{PayloadType: PayloadTypeSOL}, []byte{0x01}},
+		{"GetPayloadInstanceInfo", &GetPayloadInstanceInfoRequest{PayloadType: PayloadTypeSOL, PayloadInstance: 2}, []byte{0x01, 0x02}},
+		{"GetUserPayloadAccess", &GetUserPayloadAccessRequest{ChannelNumber: 0x01, UserID: 0x03}, []byte{0x01, 0x03}},
+		{"SetUserPayloadAccess enable", &SetUserPayloadAccessRequest{ChannelNumber: 0x01, UserID: 0x03, Payloads: solAndOEM1},
+			[]byte{0x01, 0x03, 0x02, 0x00, 0x02, 0x00}},
+		{"SetUserPayloadAccess disable", &SetUserPayloadAccessRequest{ChannelNumber: 0x0e, UserID: 0x02, Disable: true, Payloads: solAndOEM1},
+			[]byte{0x0e, 0x42, 0x02, 0x00, 0x02, 0x00}},
+		{"GetChannelPayloadSupport", &GetChannelPayloadSupportRequest{ChannelNumber: 0x01}, []byte{0x01}},
+		{"GetChannelPayloadVersion", &GetChannelPayloadVersionRequest{ChannelNumber: 0x01, PayloadType: PayloadTypeSOL}, []byte{0x01, 0x01}},
+		{"GetChannelOEMPayloadInfo", &GetChannelOEMPayloadInfoRequest{ChannelNumber: 0x01, PayloadType: PayloadTypeOEM, OEMIANA: 0x0002a2, OEMPayloadID: 0x0102},
+			[]byte{0x01, 0x02, 0xa2, 0x02, 0x00, 0x02, 0x01}},
+	}
+
+	for _, test := range tests {
+		got := test.request.Pack()
+		if !bytes.Equal(got, test.expected) {
+			t.Errorf("test %s not matched, got: %x, expected: %x", test.name, got, test.expected)
+		}
+	}
+}
+
+func Test_PayloadResponse_Unpack(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      []byte
+		response Response
+		expected Response
+	}{
+		{
+			name:     "GetPayloadActivationStatus",
+			msg:      []byte{0x02, 0x02, 0x00},
+			response: &GetPayloadActivationStatusResponse{},
+			expected: &GetPayloadActivationStatusResponse{InstanceCapacity: 2, InstanceActivated: [16]bool{false, true}},
+		},
+		{
+			name:     "GetPayloadInstanceInfo",
+			msg:      []byte{0x78, 0x56, 0x34, 0x12, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			response: &GetPayloadInstanceInfoResponse{},
+			expected: &GetPayloadInstanceInfoResponse{SessionID: 0x12345678, PayloadSpecificData: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		},
+		{
+			name:     "GetUserPayloadAccess",
+			msg:      []byte{0x03, 0x00, 0x80, 0x00},
+			response: &GetUserPayloadAccessResponse{},
+			// bit 0 (IPMI) is reserved
+			expected: &GetUserPayloadAccessResponse{PayloadAccess{StandardPayloads: [8]bool{false, true}, OEMPayloads: [8]bool{7: true}}},
+		},
+		{
+			name:     "GetChannelPayloadSupport",
+			msg:      []byte{0x03, 0x00, 0x3f, 0x00, 0x01, 0x00, 0x00, 0x00},
+			response: &GetChannelPayloadSupportResponse{},
+			expected: &GetChannelPayloadSupportResponse{PayloadTypes: []PayloadType{
+				PayloadTypeIPMI, PayloadTypeSOL,
+				PayloadTypeRmcpOpenSessionRequest, PayloadTypeRmcpOpenSessionResponse,
+				PayloadTypeRAKPMessage1, PayloadTypeRAKPMessage2, PayloadTypeRAKPMessage3, PayloadTypeRAKPMessage4,
+				PayloadTypeOEM0,
+			}},
+		},
+		{
+			name:     "GetChannelPayloadVersion",
+			msg:      []byte{0x10},
+			response: &GetChannelPayloadVersionResponse{},
+			expected: &GetChannelPayloadVersionResponse{MajorVersion: 1, MinorVersion: 0},
+		},
+		{
+			name:     "GetChannelOEMPayloadInfo",
+			msg:      []byte{0x20, 0xa2, 0x02, 0x00, 0x02, 0x01, 0x11},
+			response: &GetChannelOEMPayloadInfoResponse{},
+			expected: &GetChannelOEMPayloadInfoResponse{PayloadType: PayloadTypeOEM0, OEMIANA: 0x0002a2, OEMPayloadID: 0x0102, MajorVersion: 1, MinorVersion: 1},
+		},
+	}
+
+	for _, test := range tests {
+		if err := test.response.Unpack(test.msg); err != nil {
+			t.Errorf("test %s unpack failed, err: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.response, test.expected) {
+			t.Errorf("test %s not matched, got: %+v, expected: %+v", test.name, test.response, test.expected)
+		}
+	}
+
+	if err := (&GetChannelPayloadSupportResponse{}).Unpack([]byte{0x03, 0x00}); err == nil {
+		t.Errorf("test short payload support expected error")
+	}
+}
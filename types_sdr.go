@@ -142,6 +142,67 @@ func (sdr *SDR) SensorName() string {
 	return ""
 }
 
+// GeneratorID returns the sensor owner of Full/Compact/EventOnly SDRs.
+func (sdr *SDR) GeneratorID() GeneratorID {
+	recordType := sdr.RecordHeader.RecordType
+	switch recordType {
+	case SDRRecordTypeFullSensor:
+		return sdr.Full.GeneratorID
+	case SDRRecordTypeCompactSensor:
+		return sdr.Compact.GeneratorID
+	case SDRRecordTypeEventOnly:
+		return sdr.EventOnly.GeneratorID
+	}
+	return 0
+}
+
+// SensorKey returns the key of the sensor of Full/Compact/EventOnly SDRs.
+func (sdr *SDR) SensorKey() SensorKey {
+	return sdr.GeneratorID().SensorKey(sdr.SensorNumber())
+}
+
+// Entity returns the entity monitored by the sensor of Full/Compact/EventOnly SDRs.
+func (sdr *SDR) Entity() (EntityID, EntityInstance) {
+	recordType := sdr.RecordHeader.RecordType
+	switch recordType {
+	case SDRRecordTypeFullSensor:
+		return sdr.Full.SensorEntityID, sdr.Full.SensorEntityInstance
+	case SDRRecordTypeCompactSensor:
+		return sdr.Compact.SensorEntityID, sdr.Compact.SensorEntityInstance
+	case SDRRecordTypeEventOnly:
+		return sdr.EventOnly.SensorEntityID, sdr.EventOnly.SensorEntityInstance
+	}
+	return 0, 0
+}
+
+// SensorType returns the sensor type of Full/Compact/EventOnly SDRs.
+func (sdr *SDR) SensorType() SensorType {
+	recordType := sdr.RecordHeader.RecordType
+	switch recordType {
+	case SDRRecordTypeFullSensor:
+		return sdr.Full.SensorType
+	case SDRRecordTypeCompactSensor:
+		return sdr.Compact.SensorType
+	case SDRRecordTypeEventOnly:
+		return sdr.EventOnly.SensorType
+	}
+	return SensorTypeReserved
+}
+
+// EventReadingType returns the event/reading type of Full/Compact/EventOnly SDRs.
+func (sdr *SDR) EventReadingType() EventReadingType {
+	recordType := sdr.RecordHeader.RecordType
+	switch recordType {
+	case SDRRecordTypeFullSensor:
+		return sdr.Full.SensorEventReadingType
+	case SDRRecordTypeCompactSensor:
+		return sdr.Compact.SensorEventReadingType
+	case SDRRecordTypeEventOnly:
+		return sdr.EventOnly.SensorEventReadingType
+	}
+	return EventReadingTypeUnspecified
+}
+
 // Determine if sensor has an analog reading
 // Todo, logic is not clear.
 func (sdr *SDR) HasAnalogReading() bool {
@@ -262,6 +323,83 @@ func ParseSDR(data []byte, nextRecordID uint16) (*SDR, error) {
 	return sdr, nil
 }
 
+// Pack encodes the SDR struct to raw SDR record data (including the record header),
+// it is the reverse of ParseSDR.
+// The Record Length field of the header is calculated from the packed record body,
+// the RecordLength of RecordHeader is ignored.
+func (sdr *SDR) Pack() ([]byte, error) {
+	if sdr.RecordHeader == nil {
+		return nil, fmt.Errorf("sdr record header is nil")
+	}
+
+	var body []byte
+	var missing bool
+
+	switch sdr.RecordHeader.RecordType {
+	case SDRRecordTypeFullSensor:
+		if missing = sdr.Full == nil; !missing {
+			body = sdr.Full.Pack()
+		}
+	case SDRRecordTypeCompactSensor:
+		if missing = sdr.Compact == nil; !missing {
+			body = sdr.Compact.Pack()
+		}
+	case SDRRecordTypeEventOnly:
+		if missing = sdr.EventOnly == nil; !missing {
+			body = sdr.EventOnly.Pack()
+		}
+	case SDRRecordTypeEntityAssociation:
+		if missing = sdr.EntityAssociation == nil; !missing {
+			body = sdr.EntityAssociation.Pack()
+		}
+	case SDRRecordTypeDeviceRelativeEntityAssociation:
+		if missing = sdr.DeviceRelative == nil; !missing {
+			body = sdr.DeviceRelative.Pack()
+		}
+	case SDRRecordTypeGenericLocator:
+		if missing = sdr.GenericDeviceLocator == nil; !missing {
+			body = sdr.GenericDeviceLocator.Pack()
+		}
+	case SDRRecordTypeFRUDeviceLocator:
+		if missing = sdr.FRUDeviceLocator == nil; !missing {
+			body = sdr.FRUDeviceLocator.Pack()
+		}
+	case SDRRecordTypeManagementControllerDeviceLocator:
+		if missing = sdr.MgmtControllerDeviceLocator == nil; !missing {
+			body = sdr.MgmtControllerDeviceLocator.Pack()
+		}
+	case SDRRecordTypeManagementControllerConfirmation:
+		if missing = sdr.MgmtControllerConfirmation == nil; !missing {
+			body = sdr.MgmtControllerConfirmation.Pack()
+		}
+	case SDRRecordTypeBMCMessageChannelInfo:
+		if missing = sdr.BMCChannelInfo == nil; !missing {
+			body = sdr.BMCChannelInfo.Pack()
+		}
+	case SDRRecordTypeOEM:
+		if missing = sdr.OEM == nil; !missing {
+			body = sdr.OEM.Pack()
+		}
+	default:
+		return nil, fmt.Errorf("not supported sdr record type (%#02x)", uint8(sdr.RecordHeader.RecordType))
+	}
+
+	if missing {
+		return nil, fmt.Errorf("sdr record of type %s is nil", sdr.RecordHeader.RecordType)
+	}
+	if len(body) > 0xff {
+		return nil, fmt.Errorf("sdr record body too long, got %d bytes", len(body))
+	}
+
+	out := make([]byte, int(sdrRecordHeaderSize)+len(body))
+	packUint16L(sdr.RecordHeader.RecordID, out, 0)
+	packUint8(sdr.RecordHeader.SDRVersion, out, 2)
+	packUint8(uint8(sdr.RecordHeader.RecordType), out, 3)
+	packUint8(uint8(len(body)), out, 4)
+	packBytes(body, out, int(sdrRecordHeaderSize))
+	return out, nil
+}
+
 // Format SDRs of FRU record type
 func FormatSDRs_FRU(records []*SDR) string {
 	var buf = new(bytes.Buffer)
@@ -634,6 +772,74 @@ func (mask *Mask) ParseReading(b uint16) {
 	mask.Threshold.LNC.Readable = isBit0Set(msb)
 }
 
+// bits returns the states as a 15-bit value, bit N for State_N.
+func (e Mask_DiscreteEvent) bits() uint16 {
+	states := []bool{
+		e.State_0, e.State_1, e.State_2, e.State_3, e.State_4,
+		e.State_5, e.State_6, e.State_7, e.State_8, e.State_9,
+		e.State_10, e.State_11, e.State_12, e.State_13, e.State_14,
+	}
+
+	var v uint16
+	for i, set := range states {
+		if set {
+			v |= 1 << i
+		}
+	}
+	return v
+}
+
+// packMaskBits converts bits (bit N of the spec defined 2 bytes field) to the
+// value that unpacked as big-endian uint16, which is what the Parse* methods accept.
+func packMaskBits(bits []bool) uint16 {
+	var v uint16
+	for i, set := range bits {
+		if set {
+			v |= 1 << i
+		}
+	}
+	return v<<8 | v>>8
+}
+
+// PackAssertLower is the reverse of ParseAssertLower.
+// Both the discrete and the threshold masks are packed, so whichever is filled
+// for the sensor, the returned value holds it.
+func (mask *Mask) PackAssertLower() uint16 {
+	d := mask.Discrete.Assert.bits()
+	t := mask.Threshold
+	tv := packMaskBits([]bool{
+		t.LNC.Low_Assert, t.LNC.High_Assert, t.LCR.Low_Assert, t.LCR.High_Assert,
+		t.LNR.Low_Assert, t.LNR.High_Assert, t.UNC.Low_Assert, t.UNC.High_Assert,
+		t.UCR.Low_Assert, t.UCR.High_Assert, t.UNR.Low_Assert, t.UNR.High_Assert,
+		t.LNC.StatusReturned, t.LCR.StatusReturned, t.LNR.StatusReturned,
+	})
+	return (d<<8 | d>>8) | tv
+}
+
+// PackDeassertUpper is the reverse of ParseDeassertUpper.
+func (mask *Mask) PackDeassertUpper() uint16 {
+	d := mask.Discrete.Deassert.bits()
+	t := mask.Threshold
+	tv := packMaskBits([]bool{
+		t.LNC.Low_Deassert, t.LNC.High_Deassert, t.LCR.Low_Deassert, t.LCR.High_Deassert,
+		t.LNR.Low_Deassert, t.LNR.High_Deassert, t.UNC.Low_Deassert, t.UNC.High_Deassert,
+		t.UCR.Low_Deassert, t.UCR.High_Deassert, t.UNR.Low_Deassert, t.UNR.High_Deassert,
+		t.UNC.StatusReturned, t.UCR.StatusReturned, t.UNR.StatusReturned,
+	})
+	return (d<<8 | d>>8) | tv
+}
+
+// PackReading is the reverse of ParseReading.
+func (mask *Mask) PackReading() uint16 {
+	d := mask.Discrete.Reading.bits()
+	t := mask.Threshold
+	tv := packMaskBits([]bool{
+		t.LNC.Readable, t.LCR.Readable, t.LNR.Readable, t.UNC.Readable, t.UCR.Readable, t.UNR.Readable, false, false,
+		t.LNC.Settable, t.LCR.Settable, t.LNR.Settable, t.UNC.Settable, t.UCR.Settable, t.UNR.Settable,
+	})
+	return (d<<8 | d>>8) | tv
+}
+
 // StatusReturnedThresholds returns all supported thresholds comparison status
 // via the Get Sensor Reading command.
 func (mask *Mask) StatusReturnedThresholds() SensorThresholdTypes {
@@ -818,6 +1024,18 @@ type SensorCapabilitites struct {
 	EventMessageControl SensorEventMessageControl
 }
 
+// pack returns the Sensor Capabilities byte of Full/Compact SDR.
+func (c SensorCapabilitites) pack() uint8 {
+	b := (uint8(c.HysteresisAccess)&0x03)<<4 | (uint8(c.ThresholdAccess)&0x03)<<2 | uint8(c.EventMessageControl)&0x03
+	if c.IgnoreWithEntity {
+		b = setBit7(b)
+	}
+	if c.AutoRearm {
+		b = setBit6(b)
+	}
+	return b
+}
+
 // SDRs of Full/Compact record type has this field.
 type SensorInitialization struct {
 	// 1b = Sensor is settable (Support the Set Sensor Reading And Event Status command)
@@ -860,3 +1078,33 @@ type SensorInitialization struct {
 	// 0b = sensor scanning disabled, 1b = sensor scanning enabled
 	SensorScanningEnabled bool
 }
+
+// pack returns the Sensor Initialization byte of Full/Compact SDR.
+func (i SensorInitialization) pack() uint8 {
+	var b uint8
+	if i.Settable {
+		b = setBit7(b)
+	}
+	if i.InitScanning {
+		b = setBit6(b)
+	}
+	if i.InitEvents {
+		b = setBit5(b)
+	}
+	if i.InitThresholds {
+		b = setBit4(b)
+	}
+	if i.InitHysteresis {
+		b = setBit3(b)
+	}
+	if i.InitSensorType {
+		b = setBit2(b)
+	}
+	if i.EventGenerationEnabled {
+		b = setBit1(b)
+	}
+	if i.SensorScanningEnabled {
+		b = setBit0(b)
+	}
+	return b
+}
@@ -0,0 +1,241 @@
+package ipmi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SDRCache holds the raw SDR records of the SDR Repository of a BMC,
+// so that the SDR Repository need not be walked (by Get SDR command) every time.
+//
+// The cache is considered fresh as long as the most recent addition and erase timestamps
+// (returned by Get SDR Repository Info command) are unchanged. If the BMC reports unspecified
+// timestamps, the changes can not be detected, so the cache is never considered fresh.
+type SDRCache struct {
+	// Key identifies the BMC that the SDR Repository belongs to.
+	Key string `json:"key"`
+
+	RecordCount            uint16    `json:"record_count"`
+	MostRecentAdditionTime time.Time `json:"most_recent_addition_time"`
+	MostRecentEraseTime    time.Time `json:"most_recent_erase_time"`
+
+	Records []*SDRCacheRecord `json:"records"`
+
+	// parsed records
+	sdrs []*SDR
+
+	// the cache is loaded from a binary dump file, which has no repository
+	// timestamps, so it is always considered fresh, like the "-S" option of ipmitool.
+	fromDump bool
+}
+
+type SDRCacheRecord struct {
+	RecordID     uint16 `json:"record_id"`
+	NextRecordID uint16 `json:"next_record_id"`
+	// Raw record data, including the 5 bytes record header.
+	Data []byte `json:"data"`
+}
+
+// NewSDRCache creates an empty SDRCache bound to the state of the SDR Repository described by repoInfo.
+func NewSDRCache(key string, repoInfo *GetSDRRepoInfoResponse) *SDRCache {
+	return &SDRCache{
+		Key:                    key,
+		RecordCount:            repoInfo.RecordCount,
+		MostRecentAdditionTime: repoInfo.MostRecentAddititionTime,
+		MostRecentEraseTime:    repoInfo.MostRecentEraseTime,
+		Records:                make([]*SDRCacheRecord, 0),
+		sdrs:                   make([]*SDR, 0),
+	}
+}
+
+// IsFresh reports whether the cache still matches the SDR Repository described by repoInfo.
+func (cache *SDRCache) IsFresh(repoInfo *GetSDRRepoInfoResponse) bool {
+	if cache.fromDump {
+		return true
+	}
+	if isUnspecifiedTimestamp(repoInfo.MostRecentAddititionTime) || isUnspecifiedTimestamp(repoInfo.MostRecentEraseTime) {
+		return false
+	}
+	return cache.RecordCount == repoInfo.RecordCount &&
+		cache.MostRecentAdditionTime.Equal(repoInfo.MostRecentAddititionTime) &&
+		cache.MostRecentEraseTime.Equal(repoInfo.MostRecentEraseTime)
+}
+
+// Add adds a raw SDR record to the cache, the record must be able to be parsed.
+func (cache *SDRCache) Add(data []byte, nextRecordID uint16) (*SDR, error) {
+	sdr, err := ParseSDR(data, nextRecordID)
+	if err != nil {
+		return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
+	}
+
+	cache.Records = append(cache.Records, &SDRCacheRecord{
+		RecordID:     sdr.RecordHeader.RecordID,
+		NextRecordID: nextRecordID,
+		Data:         data,
+	})
+	cache.sdrs = append(cache.sdrs, sdr)
+	return sdr, nil
+}
+
+// SDRs returns the parsed SDR records of the cache.
+func (cache *SDRCache) SDRs() ([]*SDR, error) {
+	if len(cache.sdrs) == len(cache.Records) {
+		return cache.sdrs, nil
+	}
+
+	sdrs := make([]*SDR, 0, len(cache.Records))
+	for _, record := range cache.Records {
+		sdr, err := ParseSDR(record.Data, record.NextRecordID)
+		if err != nil {
+			return nil, fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %s", record.RecordID, err)
+		}
+		sdrs = append(sdrs, sdr)
+	}
+	cache.sdrs = sdrs
+	return sdrs, nil
+}
+
+// RawRecords returns the raw data of all the records.
+func (cache *SDRCache) RawRecords() [][]byte {
+	out := make([][]byte, 0, len(cache.Records))
+	for _, record := range cache.Records {
+		out = append(out, record.Data)
+	}
+	return out
+}
+
+// Dump encodes the records to the binary format used by "ipmitool sdr dump",
+// that is the raw data (including the record header) of all the records concatenated.
+func (cache *SDRCache) Dump() []byte {
+	out := make([]byte, 0)
+	for _, record := range cache.Records {
+		out = append(out, record.Data...)
+	}
+	return out
+}
+
+// ParseSDRDump parses the binary format used by "ipmitool sdr dump".
+// The returned SDRCache is always considered fresh.
+func ParseSDRDump(data []byte) (*SDRCache, error) {
+	cache := &SDRCache{
+		Records:  make([]*SDRCacheRecord, 0),
+		sdrs:     make([]*SDR, 0),
+		fromDump: true,
+	}
+
+	records := make([][]byte, 0)
+	for offset := 0; offset < len(data); {
+		if offset+int(sdrRecordHeaderSize) > len(data) {
+			return nil, fmt.Errorf("truncated sdr record header at offset %d", offset)
+		}
+		end := offset + int(sdrRecordHeaderSize) + int(data[offset+4])
+		if end > len(data) {
+			return nil, fmt.Errorf("truncated sdr record at offset %d", offset)
+		}
+		records = append(records, data[offset:end])
+		offset = end
+	}
+
+	for i, record := range records {
+		var nextRecordID uint16 = 0xffff
+		if i+1 < len(records) {
+			nextRecordID, _, _ = unpackUint16L(records[i+1], 0)
+		}
+		if _, err := cache.Add(record, nextRecordID); err != nil {
+			return nil, fmt.Errorf("add record #%d failed, err: %s", i, err)
+		}
+	}
+	cache.RecordCount = uint16(len(records))
+	return cache, nil
+}
+
+// LoadSDRCache reads SDRCache from file.
+// The file is either written by SDRCache.Save, or by "ipmitool sdr dump" (or SDRCache.Dump).
+//
+// The file is parsed as JSON first, and as a binary dump if it is not valid JSON.
+// The first byte of a dump is the low byte of the record ID, which can be any value, so it does not tell the format,
+// and ParseSDR accepts unknown record types, so the bytes of a JSON cache may happen to parse as a dump too.
+func LoadSDRCache(file string) (*SDRCache, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read sdr cache file failed, err: %s", err)
+	}
+
+	if json.Valid(b) {
+		cache := &SDRCache{}
+		if err := json.Unmarshal(b, cache); err != nil {
+			return nil, fmt.Errorf("unmarshal json sdr cache failed, err: %s", err)
+		}
+		return cache, nil
+	}
+
+	cache, err := ParseSDRDump(b)
+	if err != nil {
+		return nil, fmt.Errorf("sdr cache file is neither a json sdr cache nor a sdr dump, err: %s", err)
+	}
+	if len(cache.Records) == 0 {
+		return nil, fmt.Errorf("sdr cache file is empty")
+	}
+	return cache, nil
+}
+
+// Save writes SDRCache to file. The file is written to a temporary file in the same directory
+// and renamed to file, so the file is replaced atomically, even if multiple processes share the file.
+func (cache *SDRCache) Save(file string) error {
+	b, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("marshal sdr cache failed, err: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("create sdr cache dir failed, err: %s", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temp sdr cache file failed, err: %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp sdr cache file failed, err: %s", err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod temp sdr cache file failed, err: %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp sdr cache file failed, err: %s", err)
+	}
+
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("rename sdr cache file failed, err: %s", err)
+	}
+	return nil
+}
+
+// DefaultSDRCacheDir returns the default directory to store SDR cache files,
+// that is "go-ipmi/sdr" under the user cache directory.
+func DefaultSDRCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-ipmi", "sdr")
+}
+
+// sdrCacheFileName returns the file name of the SDR cache for the key.
+func sdrCacheFileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, key)
+	return name + ".json"
+}
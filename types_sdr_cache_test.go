@@ -0,0 +1,273 @@
+package ipmi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// sdrTestRecords holds raw full sensor records (including the record header) linked by record ID.
+var sdrTestRecords = []struct {
+	name string
+	raw  string
+}{
+	{"full, temperature threshold sensor", "010051013420000103017f680101800a807a383800010000010000000000011900007f80645f5a0000000202000000c9435055312054656d70"},
+	{"full, voltage sensor with negative M and B", "020051012e20003007017f680201957a957a3f3f00040000f085ffca19d207c0c8b8ff00e0d8d0a0a8b00101000000c3313256"},
+	{"full, rate unit and modifier unit", "030051013220004007016340040100000000000023110500010000000000000000000000000000000000000000003cc7416972666c6f77"},
+}
+
+func newTestSDRCache(t *testing.T, repoInfo *GetSDRRepoInfoResponse) *SDRCache {
+	cache := NewSDRCache("test", repoInfo)
+	for i, test := range sdrTestRecords {
+		raw, _ := hex.DecodeString(test.raw)
+		var nextRecordID uint16 = 0xffff
+		if i < 2 {
+			nextRecordID = uint16(i + 2)
+		}
+		if _, err := cache.Add(raw, nextRecordID); err != nil {
+			t.Fatalf("test %s Add failed, err: %s", test.name, err)
+		}
+	}
+	return cache
+}
+
+func Test_SDRCache_IsFresh(t *testing.T) {
+	repoInfo := &GetSDRRepoInfoResponse{
+		RecordCount:              3,
+		MostRecentAddititionTime: time.Unix(1700000000, 0),
+		MostRecentEraseTime:      time.Unix(1600000000, 0),
+	}
+	cache := newTestSDRCache(t, repoInfo)
+
+	unspecified := parseTimestamp(timestampUnspecified)
+	tests := []struct {
+		name     string
+		change   func(info *GetSDRRepoInfoResponse)
+		expected bool
+	}{
+		{"unchanged", func(info *GetSDRRepoInfoResponse) {}, true},
+		{"record added", func(info *GetSDRRepoInfoResponse) {
+			info.RecordCount++
+			info.MostRecentAddititionTime = info.MostRecentAddititionTime.Add(time.Second)
+		}, false},
+		{"erased", func(info *GetSDRRepoInfoResponse) { info.MostRecentEraseTime = time.Unix(1700000001, 0) }, false},
+		{"unspecified addition timestamp", func(info *GetSDRRepoInfoResponse) { info.MostRecentAddititionTime = unspecified }, false},
+		{"unspecified erase timestamp", func(info *GetSDRRepoInfoResponse) { info.MostRecentEraseTime = unspecified }, false},
+	}
+
+	for _, test := range tests {
+		info := *repoInfo
+		test.change(&info)
+		if got := cache.IsFresh(&info); got != test.expected {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+	}
+
+	// the cache of unspecified timestamps is not fresh even if unchanged
+	info := *repoInfo
+	info.MostRecentEraseTime = unspecified
+	if NewSDRCache("test", &info).IsFresh(&info) {
+		t.Errorf("test unchanged unspecified timestamps should not be fresh")
+	}
+}
+
+func Test_SDRCache_SaveLoad(t *testing.T) {
+	repoInfo := &GetSDRRepoInfoResponse{
+		RecordCount:              3,
+		MostRecentAddititionTime: time.Unix(1700000000, 0),
+		MostRecentEraseTime:      time.Unix(1600000000, 0),
+	}
+	cache := newTestSDRCache(t, repoInfo)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "sub", sdrCacheFileName("test"))
+	for i := 0; i < 2; i++ {
+		// the second save replaces the file
+		if err := cache.Save(file); err != nil {
+			t.Fatalf("test save failed, err: %s", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(file))
+	if len(entries) != 1 {
+		t.Errorf("test temp files should be removed, got %d files", len(entries))
+	}
+
+	loaded, err := LoadSDRCache(file)
+	if err != nil {
+		t.Fatalf("test load failed, err: %s", err)
+	}
+	if loaded.Key != "test" || !loaded.IsFresh(repoInfo) {
+		t.Errorf("test loaded cache not matched, got: key %s, fresh %v", loaded.Key, loaded.IsFresh(repoInfo))
+	}
+	sdrs, err := loaded.SDRs()
+	if err != nil || len(sdrs) != 3 {
+		t.Fatalf("test loaded SDRs not matched, got: %d, err: %v", len(sdrs), err)
+	}
+	if sdrs[0].SensorName() != "CPU1 Temp" || sdrs[2].NextRecordID != 0xffff {
+		t.Errorf("test loaded SDRs not matched, got: %s, next %#04x", sdrs[0].SensorName(), sdrs[2].NextRecordID)
+	}
+}
+
+func Test_ParseSDRDump(t *testing.T) {
+	cache := newTestSDRCache(t, &GetSDRRepoInfoResponse{})
+	dump := cache.Dump()
+
+	file := filepath.Join(t.TempDir(), "sdr.dump")
+	if err := os.WriteFile(file, dump, 0o644); err != nil {
+		t.Fatalf("test write dump failed, err: %s", err)
+	}
+
+	loaded, err := LoadSDRCache(file)
+	if err != nil {
+		t.Fatalf("test load dump failed, err: %s", err)
+	}
+	if !bytes.Equal(loaded.Dump(), dump) || loaded.RecordCount != 3 {
+		t.Errorf("test dump not matched, got: %x, expected: %x", loaded.Dump(), dump)
+	}
+	// the dump has no timestamps, it is always fresh
+	if !loaded.IsFresh(&GetSDRRepoInfoResponse{MostRecentEraseTime: parseTimestamp(timestampUnspecified)}) {
+		t.Errorf("test dump should be fresh")
+	}
+	for i, record := range loaded.Records {
+		if record.RecordID != uint16(i+1) {
+			t.Errorf("test dump record #%d id not matched, got: %#04x", i, record.RecordID)
+		}
+	}
+
+	// the low byte of the first record ID is '{', the dump is not taken as JSON
+	braceDump := append([]byte{}, dump...)
+	braceDump[0] = '{'
+	if err := os.WriteFile(file, braceDump, 0o644); err != nil {
+		t.Fatalf("test write dump failed, err: %s", err)
+	}
+	loaded, err = LoadSDRCache(file)
+	if err != nil {
+		t.Fatalf("test load dump with record id 0x007b failed, err: %s", err)
+	}
+	if loaded.Records[0].RecordID != 0x007b || !bytes.Equal(loaded.Dump(), braceDump) {
+		t.Errorf("test dump with record id 0x007b not matched, got: %x, expected: %x", loaded.Dump(), braceDump)
+	}
+
+	if _, err := ParseSDRDump(dump[:len(dump)-1]); err == nil {
+		t.Errorf("test truncated dump expected error")
+	}
+	if _, err := ParseSDRDump(dump[:3]); err == nil {
+		t.Errorf("test truncated header expected error")
+	}
+}
+
+func Test_LoadSDRCache_JSONLikeDump(t *testing.T) {
+	// The bytes written by Save for this cache also happen to parse as a binary dump,
+	// the first "record" is `{"key` (record id 0x227b, type 0x65, length 'y').
+	repoInfo := &GetSDRRepoInfoResponse{
+		RecordCount:              1,
+		MostRecentAddititionTime: time.Unix(1700000000, 0),
+		MostRecentEraseTime:      time.Unix(1600000000, 0),
+	}
+	cache := NewSDRCache("10.0.0.61", repoInfo)
+	records := []string{
+		"010051c0183fbb3ed8995af71143c6f340efceefda679ae76f6ed7f26e",
+		"020051c018aa4848efbb06400f9695b18ba279e8947c032a84a40ca647",
+		"030051c00c2494d6bd7be4e7928e749c78",
+	}
+	for i, record := range records {
+		raw, _ := hex.DecodeString(record)
+		var nextRecordID uint16 = 0xffff
+		if i+1 < len(records) {
+			nextRecordID = uint16(i + 2)
+		}
+		if _, err := cache.Add(raw, nextRecordID); err != nil {
+			t.Fatalf("test record #%d Add failed, err: %s", i, err)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), sdrCacheFileName(cache.Key))
+	if err := cache.Save(file); err != nil {
+		t.Fatalf("test save failed, err: %s", err)
+	}
+	b, _ := os.ReadFile(file)
+	if _, err := ParseSDRDump(b); err != nil {
+		t.Fatalf("test saved cache should also parse as a dump, err: %s", err)
+	}
+
+	loaded, err := LoadSDRCache(file)
+	if err != nil {
+		t.Fatalf("test load failed, err: %s", err)
+	}
+	if loaded.fromDump || loaded.Key != cache.Key || !bytes.Equal(loaded.Dump(), cache.Dump()) {
+		t.Errorf("test loaded cache not matched, got: fromDump %v, key %s, dump %x", loaded.fromDump, loaded.Key, loaded.Dump())
+	}
+	if loaded.IsFresh(&GetSDRRepoInfoResponse{RecordCount: 1, MostRecentAddititionTime: time.Unix(1700000001, 0), MostRecentEraseTime: repoInfo.MostRecentEraseTime}) {
+		t.Errorf("test loaded json cache should not be fresh after the repository changed")
+	}
+}
+
+// testSDRDumpRoundTrip checks the dump is kept byte for byte by ParseSDRDump, Dump, LoadSDRCache and Save,
+// and each record is kept by ParseSDR and Pack.
+func testSDRDumpRoundTrip(t *testing.T, name string, dump []byte) {
+	cache, err := ParseSDRDump(dump)
+	if err != nil {
+		t.Fatalf("test %s ParseSDRDump failed, err: %s", name, err)
+	}
+	if !bytes.Equal(cache.Dump(), dump) {
+		t.Errorf("test %s Dump not matched, got: %x, expected: %x", name, cache.Dump(), dump)
+	}
+
+	sdrs, err := cache.SDRs()
+	if err != nil || len(sdrs) != len(cache.Records) {
+		t.Fatalf("test %s SDRs not matched, got: %d, err: %v", name, len(sdrs), err)
+	}
+	for i, sdr := range sdrs {
+		packed, err := sdr.Pack()
+		if err != nil {
+			t.Errorf("test %s record #%d (%s) Pack failed, err: %s", name, i, sdr.RecordHeader.RecordType, err)
+			continue
+		}
+		if !bytes.Equal(packed, cache.Records[i].Data) {
+			t.Errorf("test %s record #%d (%s) not matched, got: %x, expected: %x", name, i, sdr.RecordHeader.RecordType, packed, cache.Records[i].Data)
+		}
+	}
+
+	dir := t.TempDir()
+	dumpFile := filepath.Join(dir, "sdr.dump")
+	if err := os.WriteFile(dumpFile, dump, 0o644); err != nil {
+		t.Fatalf("test %s write dump failed, err: %s", name, err)
+	}
+	cacheFile := filepath.Join(dir, sdrCacheFileName(name))
+	if err := cache.Save(cacheFile); err != nil {
+		t.Fatalf("test %s save failed, err: %s", name, err)
+	}
+	for _, file := range []string{dumpFile, cacheFile} {
+		loaded, err := LoadSDRCache(file)
+		if err != nil {
+			t.Errorf("test %s load %s failed, err: %s", name, filepath.Base(file), err)
+			continue
+		}
+		if !bytes.Equal(loaded.Dump(), dump) {
+			t.Errorf("test %s load %s not matched, got: %x, expected: %x", name, filepath.Base(file), loaded.Dump(), dump)
+		}
+	}
+}
+
+func Test_SDRDump_RoundTrip(t *testing.T) {
+	dump := make([]byte, 0)
+	for _, record := range syntheticSDRRecords {
+		raw, _ := hex.DecodeString(record.raw)
+		dump = append(dump, raw...)
+	}
+	testSDRDumpRoundTrip(t, "synthetic", dump)
+
+	// dumps captured by "ipmitool sdr dump" from real BMCs, see testdata/sdr/README.md
+	files, _ := filepath.Glob(filepath.Join("testdata", "sdr", "*.sdr"))
+	for _, file := range files {
+		dump, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("test read %s failed, err: %s", file, err)
+		}
+		testSDRDumpRoundTrip(t, filepath.Base(file), dump)
+	}
+}
@@ -48,8 +48,23 @@ type SDRCompact struct {
 	// 11b = reserved
 	SensorDirection uint8
 
+	// ID String Instance Modifier Type
+	// 00b = numeric
+	// 01b = alpha
+	IDStringInstanceModifierType uint8
+
+	// Share count (number of sensors sharing this record). Sensor numbers sharing this
+	// record are sequential starting with the sensor number specified by the Sensor
+	// Number field for this record.
+	ShareCount uint8
+
+	// 0b = Entity Instance same for all shared records
+	// 1b = Entity Instance number increments for each shared record
 	EntityInstanceSharing uint8
 
+	// ID String Instance Modifier Offset
+	IDStringInstanceModifierOffset uint8
+
 	// Positive hysteresis is defined as the unsigned number of counts that are
 	// subtracted from the raw threshold values to create the "re-arm" point for all
 	// positive-going thresholds on the sensor. 0 indicates that there is no hysteresis on
@@ -68,6 +83,8 @@ type SDRCompact struct {
 	// compact SDR can have pos/neg hysteresis, but they cannot be analog!
 	NegativeHysteresisRaw uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	IDStringTypeLength TypeLength // Sensor ID String Type/Length Code
 	IDStringBytes      []byte     // Sensor ID String bytes.
 }
@@ -160,10 +177,10 @@ func (record *SDRCompact) NegativeHysteresis() (raw uint8, valid bool) {
 	return
 }
 
-func parseSDRCompactSensor(data []byte, sdr *SDR) error {
-	const SDRCompactSensorMinSize int = 32 // plus the ID String Bytes (optional 16 bytes maximum)
+const sdrCompactSensorMinSize int = 32 // plus the ID String Bytes (optional 16 bytes maximum)
 
-	minSize := SDRCompactSensorMinSize
+func parseSDRCompactSensor(data []byte, sdr *SDR) error {
+	minSize := sdrCompactSensorMinSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (compact sensor) data must be longer than %d", minSize)
 	}
@@ -225,16 +242,28 @@ func parseSDRCompactSensor(data []byte, sdr *SDR) error {
 	b22, _, _ := unpackUint8(data, 22)
 	s.SensorUnit = SensorUnit{
 		AnalogDataFormat: SensorAnalogUnitFormat((b20 & 0xc0) >> 6),
-		RateUnit:         SensorRateUnit((b20 & 0x38) >> 4),
-		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 2),
+		RateUnit:         SensorRateUnit((b20 & 0x38) >> 3),
+		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 1),
 		Percentage:       isBit0Set(b20),
 		BaseUnit:         SensorUnitType(b21),
 		ModifierUnit:     SensorUnitType(b22),
 	}
 
+	b23, _, _ := unpackUint8(data, 23)
+	s.SensorDirection = b23 >> 6
+	s.IDStringInstanceModifierType = (b23 & 0x30) >> 4
+	s.ShareCount = b23 & 0x0f
+
+	b24, _, _ := unpackUint8(data, 24)
+	s.EntityInstanceSharing = b24 >> 7
+	s.IDStringInstanceModifierOffset = b24 & 0x7f
+
 	s.PositiveHysteresisRaw, _, _ = unpackUint8(data, 25)
 	s.NegativeHysteresisRaw, _, _ = unpackUint8(data, 26)
 
+	// index 27, 28, 29 reserved
+	s.OEM, _, _ = unpackUint8(data, 30)
+
 	typeLength, _, _ := unpackUint8(data, 31)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -245,3 +274,48 @@ func parseSDRCompactSensor(data []byte, sdr *SDR) error {
 	s.IDStringBytes, _, _ = unpackBytes(data, minSize, idStrLen)
 	return nil
 }
+
+// Pack encodes the Compact Sensor Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRCompactSensor.
+func (s *SDRCompact) Pack() []byte {
+	// the offsets are the same as parseSDRCompactSensor which counts the record header in
+	out := make([]byte, sdrCompactSensorMinSize+len(s.IDStringBytes))
+
+	packUint16L(uint16(s.GeneratorID), out, 5)
+	packUint8(uint8(s.SensorNumber), out, 7)
+
+	packUint8(uint8(s.SensorEntityID), out, 8)
+	b9 := uint8(s.SensorEntityInstance) & 0x7f
+	if s.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(s.SensorInitialization.pack(), out, 10)
+	packUint8(s.SensorCapabilitites.pack(), out, 11)
+
+	packUint8(uint8(s.SensorType), out, 12)
+	packUint8(uint8(s.SensorEventReadingType), out, 13)
+
+	packUint16(s.Mask.PackAssertLower(), out, 14)
+	packUint16(s.Mask.PackDeassertUpper(), out, 16)
+	packUint16(s.Mask.PackReading(), out, 18)
+
+	units1, units2, units3 := s.SensorUnit.pack()
+	packUint8(units1, out, 20)
+	packUint8(units2, out, 21)
+	packUint8(units3, out, 22)
+
+	packUint8((s.SensorDirection&0x03)<<6|(s.IDStringInstanceModifierType&0x03)<<4|s.ShareCount&0x0f, out, 23)
+	packUint8((s.EntityInstanceSharing&0x01)<<7|s.IDStringInstanceModifierOffset&0x7f, out, 24)
+
+	packUint8(s.PositiveHysteresisRaw, out, 25)
+	packUint8(s.NegativeHysteresisRaw, out, 26)
+
+	packUint8(s.OEM, out, 30)
+
+	packUint8(uint8(s.IDStringTypeLength), out, 31)
+	packBytes(s.IDStringBytes, out, sdrCompactSensorMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
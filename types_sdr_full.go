@@ -130,6 +130,8 @@ type SDRFull struct {
 	// 负向迟滞量
 	NegativeHysteresisRaw uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	IDStringTypeLength TypeLength
 	IDStringBytes      []byte
 }
@@ -139,9 +141,10 @@ func (full *SDRFull) ConvertReading(raw uint8) float64 {
 	return ConvertReading(raw, full.SensorUnit.AnalogDataFormat, full.ReadingFactors, full.LinearizationFunc)
 }
 
-// ConvertSensorHysteresis converts raw sensor hysteresis value to real value in the desired units for the sensor.
+// ConvertSensorHysteresis converts raw sensor hysteresis value to real value in the desired units for the sensor,
+// see ConvertSensorHysteresisFromRaw.
 func (full *SDRFull) ConvertSensorHysteresis(raw uint8) float64 {
-	return ConvertSensorHysteresis(raw, full.SensorUnit.AnalogDataFormat, full.ReadingFactors, full.LinearizationFunc)
+	return ConvertSensorHysteresisFromRaw(raw, full.ReadingFactors)
 }
 
 // ConvertSensorTolerance converts raw sensor tolerance value to real value in the desired units for the sensor.
@@ -346,10 +349,10 @@ Reading Factors       : %s`,
 	)
 }
 
-func parseSDRFullSensor(data []byte, sdr *SDR) error {
-	const SDRFullSensorMinSize int = 48 // plus the ID String Bytes (optional 16 bytes maximum)
+const sdrFullSensorMinSize int = 48 // plus the ID String Bytes (optional 16 bytes maximum)
 
-	minSize := SDRFullSensorMinSize
+func parseSDRFullSensor(data []byte, sdr *SDR) error {
+	minSize := sdrFullSensorMinSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (full sensor) data must be longer than %d", minSize)
 	}
@@ -411,8 +414,8 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 	b22, _, _ := unpackUint8(data, 22)
 	s.SensorUnit = SensorUnit{
 		AnalogDataFormat: SensorAnalogUnitFormat((b20 & 0xc0) >> 6),
-		RateUnit:         SensorRateUnit((b20 & 0x38) >> 4),
-		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 2),
+		RateUnit:         SensorRateUnit((b20 & 0x38) >> 3),
+		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 1),
 		Percentage:       isBit0Set(b20),
 		BaseUnit:         SensorUnitType(b21),
 		ModifierUnit:     SensorUnitType(b22),
@@ -470,6 +473,9 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 	s.PositiveHysteresisRaw, _, _ = unpackUint8(data, 42)
 	s.NegativeHysteresisRaw, _, _ = unpackUint8(data, 43)
 
+	// index 44, 45 reserved
+	s.OEM, _, _ = unpackUint8(data, 46)
+
 	typeLength, _, _ := unpackUint8(data, 47)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -482,3 +488,83 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 
 	return nil
 }
+
+// Pack encodes the Full Sensor Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRFullSensor.
+func (s *SDRFull) Pack() []byte {
+	// the offsets are the same as parseSDRFullSensor which counts the record header in
+	out := make([]byte, sdrFullSensorMinSize+len(s.IDStringBytes))
+
+	packUint16L(uint16(s.GeneratorID), out, 5)
+	packUint8(uint8(s.SensorNumber), out, 7)
+
+	packUint8(uint8(s.SensorEntityID), out, 8)
+	b9 := uint8(s.SensorEntityInstance) & 0x7f
+	if s.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(s.SensorInitialization.pack(), out, 10)
+	packUint8(s.SensorCapabilitites.pack(), out, 11)
+
+	packUint8(uint8(s.SensorType), out, 12)
+	packUint8(uint8(s.SensorEventReadingType), out, 13)
+
+	packUint16(s.Mask.PackAssertLower(), out, 14)
+	packUint16(s.Mask.PackDeassertUpper(), out, 16)
+	packUint16(s.Mask.PackReading(), out, 18)
+
+	units1, units2, units3 := s.SensorUnit.pack()
+	packUint8(units1, out, 20)
+	packUint8(units2, out, 21)
+	packUint8(units3, out, 22)
+
+	packUint8(uint8(s.LinearizationFunc), out, 23)
+
+	m := uint16(s.M) & 0x03ff
+	packUint8(uint8(m), out, 24)
+	packUint8(uint8(m>>2)&0xc0|s.Tolerance&0x3f, out, 25)
+
+	b := uint16(s.B) & 0x03ff
+	packUint8(uint8(b), out, 26)
+	packUint8(uint8(b>>2)&0xc0|uint8(s.Accuracy)&0x3f, out, 27)
+	packUint8(uint8(s.Accuracy>>2)&0xf0|(s.Accuracy_Exp&0x03)<<2|s.SensorDirection&0x03, out, 28)
+
+	packUint8(uint8(s.R_Exp)<<4|uint8(s.B_Exp)&0x0f, out, 29)
+
+	var b30 uint8
+	if s.NormalMinSpecified {
+		b30 = setBit2(b30)
+	}
+	if s.NormalMaxSpecified {
+		b30 = setBit1(b30)
+	}
+	if s.NominalReadingSpecified {
+		b30 = setBit0(b30)
+	}
+	packUint8(b30, out, 30)
+
+	packUint8(s.NominalReadingRaw, out, 31)
+	packUint8(s.NormalMaxRaw, out, 32)
+	packUint8(s.NormalMinRaw, out, 33)
+	packUint8(s.SensorMaxReadingRaw, out, 34)
+	packUint8(s.SensorMinReadingRaw, out, 35)
+
+	packUint8(s.UNR_Raw, out, 36)
+	packUint8(s.UCR_Raw, out, 37)
+	packUint8(s.UNC_Raw, out, 38)
+	packUint8(s.LNR_Raw, out, 39)
+	packUint8(s.LCR_Raw, out, 40)
+	packUint8(s.LNC_Raw, out, 41)
+
+	packUint8(s.PositiveHysteresisRaw, out, 42)
+	packUint8(s.NegativeHysteresisRaw, out, 43)
+
+	packUint8(s.OEM, out, 46)
+
+	packUint8(uint8(s.IDStringTypeLength), out, 47)
+	packBytes(s.IDStringBytes, out, sdrFullSensorMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
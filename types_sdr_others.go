@@ -50,6 +50,8 @@ type SDREventOnly struct {
 	// (alpha characters are considered to be base 26 for ASCII)
 	IDStringInstanceModifierOffset uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	IDStringTypeLength TypeLength
 	IDStringBytes      []byte
 }
@@ -66,9 +68,10 @@ func (eventOnly *SDREventOnly) String() string {
 	)
 }
 
+const sdrEventOnlyMinSize int = 17
+
 func parseSDREventOnly(data []byte, sdr *SDR) error {
-	const SDREventOnlyMinSize int = 17
-	minSize := SDREventOnlyMinSize
+	minSize := sdrEventOnlyMinSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (event-only) data must be longer than %d", minSize)
 	}
@@ -95,6 +98,18 @@ func parseSDREventOnly(data []byte, sdr *SDR) error {
 	eventReadingType, _, _ := unpackUint8(data, 11)
 	s.SensorEventReadingType = EventReadingType(eventReadingType)
 
+	b12, _, _ := unpackUint8(data, 12)
+	s.SensorDirection = b12 >> 6
+	s.IDStringInstanceModifierType = (b12 & 0x30) >> 4
+	s.ShareCount = b12 & 0x0f
+
+	b13, _, _ := unpackUint8(data, 13)
+	s.EntityInstanceSharing = isBit7Set(b13)
+	s.IDStringInstanceModifierOffset = b13 & 0x7f
+
+	// index 14 reserved
+	s.OEM, _, _ = unpackUint8(data, 15)
+
 	typeLength, _, _ := unpackUint8(data, 16)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -106,6 +121,39 @@ func parseSDREventOnly(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Event-Only Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDREventOnly.
+func (s *SDREventOnly) Pack() []byte {
+	out := make([]byte, sdrEventOnlyMinSize+len(s.IDStringBytes))
+
+	packUint16L(uint16(s.GeneratorID), out, 5)
+	packUint8(uint8(s.SensorNumber), out, 7)
+
+	packUint8(uint8(s.SensorEntityID), out, 8)
+	b9 := uint8(s.SensorEntityInstance) & 0x7f
+	if s.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(uint8(s.SensorType), out, 10)
+	packUint8(uint8(s.SensorEventReadingType), out, 11)
+
+	packUint8((s.SensorDirection&0x03)<<6|(s.IDStringInstanceModifierType&0x03)<<4|s.ShareCount&0x0f, out, 12)
+	b13 := s.IDStringInstanceModifierOffset & 0x7f
+	if s.EntityInstanceSharing {
+		b13 = setBit7(b13)
+	}
+	packUint8(b13, out, 13)
+
+	packUint8(s.OEM, out, 15)
+
+	packUint8(uint8(s.IDStringTypeLength), out, 16)
+	packBytes(s.IDStringBytes, out, sdrEventOnlyMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.4 SDR Type 08h - Entity Association Record
 type SDREntityAssociation struct {
 	//
@@ -146,10 +194,11 @@ type SDREntityAssociation struct {
 	ContaineredEntity4Instance uint8
 }
 
+const sdrEntityAssociationSize int = 16
+
 func parseSDREntityAssociation(data []byte, sdr *SDR) error {
-	const SDREntityAssociationSize int = 16
-	if len(data) < SDREntityAssociationSize {
-		return fmt.Errorf("sdr (entity association) data must be longer than %d", SDREntityAssociationSize)
+	if len(data) < sdrEntityAssociationSize {
+		return fmt.Errorf("sdr (entity association) data must be longer than %d", sdrEntityAssociationSize)
 	}
 
 	s := &SDREntityAssociation{}
@@ -175,6 +224,41 @@ func parseSDREntityAssociation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Entity Association Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDREntityAssociation.
+func (s *SDREntityAssociation) Pack() []byte {
+	out := make([]byte, sdrEntityAssociationSize)
+
+	packUint8(s.ContainerEntityID, out, 5)
+	packUint8(s.ContainerEntityInstance, out, 6)
+	packUint8(packEntityAssociationFlag(s.ContainedEntitiesAsRange, s.LinkedEntityAssiactionExist, s.PresenceSensorAlwaysAccessible), out, 7)
+
+	packUint8(s.ContaineredEntity1ID, out, 8)
+	packUint8(s.ContaineredEntity1Instance, out, 9)
+	packUint8(s.ContaineredEntity2ID, out, 10)
+	packUint8(s.ContaineredEntity2Instance, out, 11)
+	packUint8(s.ContaineredEntity3ID, out, 12)
+	packUint8(s.ContaineredEntity3Instance, out, 13)
+	packUint8(s.ContaineredEntity4ID, out, 14)
+	packUint8(s.ContaineredEntity4Instance, out, 15)
+
+	return out[sdrRecordHeaderSize:]
+}
+
+func packEntityAssociationFlag(asRange bool, linkedExist bool, presenceSensorAlwaysAccessible bool) uint8 {
+	var flag uint8
+	if asRange {
+		flag = setBit7(flag)
+	}
+	if linkedExist {
+		flag = setBit6(flag)
+	}
+	if presenceSensorAlwaysAccessible {
+		flag = setBit5(flag)
+	}
+	return flag
+}
+
 // 43.5 SDR Type 09h - Device-relative Entity Association Record
 type SDRDeviceRelative struct {
 	//
@@ -227,10 +311,11 @@ type SDRDeviceRelative struct {
 	ContaineredEntity4Instance      uint8
 }
 
+const sdrDeviceRelativeEntityAssociationSize = 32
+
 func parseSDRDeviceRelativeEntityAssociation(data []byte, sdr *SDR) error {
-	const SDRDeviceRelativeEntityAssociationSize = 32
-	if len(data) < SDRDeviceRelativeEntityAssociationSize {
-		return fmt.Errorf("sdr (device-relative entity association) data must be longer than %d", SDRDeviceRelativeEntityAssociationSize)
+	if len(data) < sdrDeviceRelativeEntityAssociationSize {
+		return fmt.Errorf("sdr (device-relative entity association) data must be longer than %d", sdrDeviceRelativeEntityAssociationSize)
 	}
 
 	s := &SDRDeviceRelative{}
@@ -270,6 +355,41 @@ func parseSDRDeviceRelativeEntityAssociation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Device-relative Entity Association Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRDeviceRelativeEntityAssociation.
+func (s *SDRDeviceRelative) Pack() []byte {
+	out := make([]byte, sdrDeviceRelativeEntityAssociationSize)
+
+	packUint8(s.ContainerEntityID, out, 5)
+	packUint8(s.ContainerEntityInstance, out, 6)
+	packUint8(s.ContainerEntityDeviceAddress, out, 7)
+	packUint8(s.ContainerEntityDeviceChannel, out, 8)
+	packUint8(packEntityAssociationFlag(s.ContainedEntitiesAsRange, s.LinkedEntityAssiactionExist, s.PresenceSensorAlwaysAccessible), out, 9)
+
+	packUint8(s.ContaineredEntity1DeviceAddress, out, 10)
+	packUint8(s.ContaineredEntity1DeviceChannel, out, 11)
+	packUint8(s.ContaineredEntity1ID, out, 12)
+	packUint8(s.ContaineredEntity1Instance, out, 13)
+
+	packUint8(s.ContaineredEntity2DeviceAddress, out, 14)
+	packUint8(s.ContaineredEntity2DeviceChannel, out, 15)
+	packUint8(s.ContaineredEntity2ID, out, 16)
+	packUint8(s.ContaineredEntity2Instance, out, 17)
+
+	packUint8(s.ContaineredEntity3DeviceAddress, out, 18)
+	packUint8(s.ContaineredEntity3DeviceChannel, out, 19)
+	packUint8(s.ContaineredEntity3ID, out, 20)
+	packUint8(s.ContaineredEntity3Instance, out, 21)
+
+	packUint8(s.ContaineredEntity4DeviceAddress, out, 22)
+	packUint8(s.ContaineredEntity4DeviceChannel, out, 23)
+	packUint8(s.ContaineredEntity4ID, out, 24)
+	packUint8(s.ContaineredEntity4Instance, out, 25)
+
+	// last 6 bytes reserved
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.7 SDR Type 10h - Generic Device Locator Record
 // This record is used to store the location and type information for devices
 // on the IPMB or management controller private busses that are neither
@@ -300,13 +420,16 @@ type SDRGenericDeviceLocator struct {
 	EntityID           uint8
 	EntityInstance     uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDString     []byte // Short ID string for the device
 }
 
+const sdrGenericLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
+
 func parseSDRGenericLocator(data []byte, sdr *SDR) error {
-	const SDRGenericLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
-	minSize := SDRGenericLocatorMinSize
+	minSize := sdrGenericLocatorMinSize
 
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (generic-locator) data must be longer than %d", minSize)
@@ -321,7 +444,7 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	s.DeviceSlaveAddress = b
 
 	c, _, _ := unpackUint8(data, 7)
-	s.ChannelNumber = ((b & 0x01) << 4) | (c >> 5)
+	s.ChannelNumber = ((b & 0x01) << 3) | (c >> 5)
 	s.AccessLUN = (c & 0x1f) >> 3
 	s.PrivateBusID = (c & 0x07)
 
@@ -332,6 +455,8 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	s.EntityID, _, _ = unpackUint8(data, 12)
 	s.EntityInstance, _, _ = unpackUint8(data, 13)
 
+	s.OEM, _, _ = unpackUint8(data, 14)
+
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
 
@@ -343,6 +468,31 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Generic Device Locator Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRGenericLocator.
+func (s *SDRGenericDeviceLocator) Pack() []byte {
+	out := make([]byte, sdrGenericLocatorMinSize+len(s.DeviceIDString))
+
+	packUint8(s.DeviceAccessAddress, out, 5)
+	// [0] - Channel Number MS bit
+	packUint8(s.DeviceSlaveAddress&0xfe|(s.ChannelNumber>>3)&0x01, out, 6)
+	packUint8((s.ChannelNumber&0x07)<<5|(s.AccessLUN&0x03)<<3|s.PrivateBusID&0x07, out, 7)
+
+	packUint8(s.AddressSpan, out, 8)
+	packUint8(s.DeviceType, out, 10)
+	packUint8(s.DeviceTypeModifier, out, 11)
+
+	packUint8(s.EntityID, out, 12)
+	packUint8(s.EntityInstance, out, 13)
+
+	packUint8(s.OEM, out, 14)
+
+	packUint8(uint8(s.DeviceIDTypeLength), out, 15)
+	packBytes(s.DeviceIDString, out, sdrGenericLocatorMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.8 SDR Type 11h - FRU Device Locator Record
 // 38. Accessing FRU Devices
 type SDRFRUDeviceLocator struct {
@@ -398,6 +548,8 @@ type SDRFRUDeviceLocator struct {
 	FRUEntityID        uint8
 	FRUEntityInstance  uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDBytes      []byte // Short ID string for the FRU Device
 }
@@ -424,9 +576,10 @@ func (sdrFRU *SDRFRUDeviceLocator) Location() FRULocation {
 	return FRULocation_PrivateBus
 }
 
+const sdrFRUDeviceLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
+
 func parseSDRFRUDeviceLocator(data []byte, sdr *SDR) error {
-	const SDRFRUDeviceLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
-	minSize := SDRFRUDeviceLocatorMinSize
+	minSize := sdrFRUDeviceLocatorMinSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (fru device) data must be longer than %d", minSize)
 	}
@@ -456,7 +609,7 @@ func parseSDRFRUDeviceLocator(data []byte, sdr *SDR) error {
 	s.FRUEntityID, _, _ = unpackUint8(data, 12)
 	s.FRUEntityInstance, _, _ = unpackUint8(data, 13)
 
-	// index 14 Reserved for OEM use.
+	s.OEM, _, _ = unpackUint8(data, 14)
 
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
@@ -476,6 +629,35 @@ func parseSDRFRUDeviceLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the FRU Device Locator Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRFRUDeviceLocator.
+func (s *SDRFRUDeviceLocator) Pack() []byte {
+	out := make([]byte, sdrFRUDeviceLocatorMinSize+len(s.DeviceIDBytes))
+
+	packUint8(s.DeviceAccessAddress, out, 5)
+	packUint8(s.FRUDeviceID_SlaveAddress, out, 6)
+
+	b8 := (s.AccessLUN&0x03)<<3 | s.PrivateBusID&0x07
+	if s.IsLogicalFRUDevice {
+		b8 = setBit7(b8)
+	}
+	packUint8(b8, out, 7)
+	packUint8(s.ChannelNumber<<4, out, 8)
+
+	packUint8(uint8(s.DeviceType), out, 10)
+	packUint8(s.DeviceTypeModifier, out, 11)
+
+	packUint8(s.FRUEntityID, out, 12)
+	packUint8(s.FRUEntityInstance, out, 13)
+
+	packUint8(s.OEM, out, 14)
+
+	packUint8(uint8(s.DeviceIDTypeLength), out, 15)
+	packBytes(s.DeviceIDBytes, out, sdrFRUDeviceLocatorMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.9 SDR Type 12h - Management Controller Device Locator Record
 type SDRMgmtControllerDeviceLocator struct {
 	//
@@ -494,6 +676,13 @@ type SDRMgmtControllerDeviceLocator struct {
 	ControllerLogsInitializationAgentErrors  bool
 	LogInitializationAgentErrors             bool
 
+	// Global Initialization
+	// 00b = Enable event message generation from controller (Init agent will set Event Receiver address into controller)
+	// 01b = Disable event message generation from controller (Init agent will set Event Receiver to FFh).
+	// 10b = Do not initialize controller.
+	// 11b = reserved.
+	GlobalInitialization uint8
+
 	DeviceCap_ChassisDevice      bool // device functions as chassis device
 	DeviceCap_Bridge             bool // Controller responds to Bridge NetFn command
 	DeviceCap_IPMBEventGenerator bool // device generates event messages on IPMB
@@ -506,13 +695,16 @@ type SDRMgmtControllerDeviceLocator struct {
 	EntityID       uint8
 	EntityInstance uint8
 
+	OEM uint8 // Reserved for OEM use.
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDBytes      []byte
 }
 
+const sdrManagementControllerDeviceLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
+
 func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
-	const SDRManagementControllerDeviceLocatorMinSize = 16 // plus the ID String Bytes (optional 16 bytes maximum)
-	minSize := SDRManagementControllerDeviceLocatorMinSize
+	minSize := sdrManagementControllerDeviceLocatorMinSize
 
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (mgmt controller device locator) data must be longer than %d", minSize)
@@ -532,6 +724,7 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	s.ACPIDevicePowerStateNotificationRequired = isBit6Set(b8)
 	s.ControllerLogsInitializationAgentErrors = isBit3Set(b8)
 	s.LogInitializationAgentErrors = isBit2Set(b8)
+	s.GlobalInitialization = b8 & 0x03
 
 	b9, _, _ := unpackUint8(data, 8)
 	s.DeviceCap_ChassisDevice = isBit7Set(b9)
@@ -546,6 +739,8 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	s.EntityID, _, _ = unpackUint8(data, 12)
 	s.EntityInstance, _, _ = unpackUint8(data, 13)
 
+	s.OEM, _, _ = unpackUint8(data, 14)
+
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
 
@@ -557,6 +752,58 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Management Controller Device Locator Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRManagementControllerDeviceLocator.
+func (s *SDRMgmtControllerDeviceLocator) Pack() []byte {
+	out := make([]byte, sdrManagementControllerDeviceLocatorMinSize+len(s.DeviceIDBytes))
+
+	packUint8(s.DeviceSlaveAddress, out, 5)
+	packUint8(s.ChannelNumber, out, 6)
+
+	b8 := s.GlobalInitialization & 0x03
+	if s.ACPISystemPowerStateNotificationRequired {
+		b8 = setBit7(b8)
+	}
+	if s.ACPIDevicePowerStateNotificationRequired {
+		b8 = setBit6(b8)
+	}
+	if s.ControllerLogsInitializationAgentErrors {
+		b8 = setBit3(b8)
+	}
+	if s.LogInitializationAgentErrors {
+		b8 = setBit2(b8)
+	}
+	packUint8(b8, out, 7)
+
+	var b9 uint8
+	caps := []bool{
+		s.DeviceCap_SensorDevice,
+		s.DeviceCap_SDRRepoDevice,
+		s.DeviceCap_SELDevice,
+		s.DeviceCap_FRUInventoryDevice,
+		s.DeviceCap_IPMBEventReceiver,
+		s.DeviceCap_IPMBEventGenerator,
+		s.DeviceCap_Bridge,
+		s.DeviceCap_ChassisDevice,
+	}
+	for i, c := range caps {
+		if c {
+			b9 |= 1 << i
+		}
+	}
+	packUint8(b9, out, 8)
+
+	packUint8(s.EntityID, out, 12)
+	packUint8(s.EntityInstance, out, 13)
+
+	packUint8(s.OEM, out, 14)
+
+	packUint8(uint8(s.DeviceIDTypeLength), out, 15)
+	packBytes(s.DeviceIDBytes, out, sdrManagementControllerDeviceLocatorMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.10 SDR Type 13h - Management Controller Confirmation Record
 type SDRMgmtControllerConfirmation struct {
 	//
@@ -587,9 +834,10 @@ type SDRMgmtControllerConfirmation struct {
 	DeviceGUID     []byte // 16 bytes
 }
 
+const sdrManagementControllerConfirmationSize = 32
+
 func parseSDRManagementControllerConfirmation(data []byte, sdr *SDR) error {
-	const SDRManagementControllerConfirmationSize = 32
-	minSize := SDRManagementControllerConfirmationSize
+	minSize := sdrManagementControllerConfirmationSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (mgmt controller confirmation) data must be longer than %d", minSize)
 	}
@@ -621,6 +869,29 @@ func parseSDRManagementControllerConfirmation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the Management Controller Confirmation Record to raw data (the record key and record body, without the record header),
+// it is the reverse of parseSDRManagementControllerConfirmation.
+func (s *SDRMgmtControllerConfirmation) Pack() []byte {
+	out := make([]byte, sdrManagementControllerConfirmationSize)
+
+	packUint8(s.DeviceSlaveAddress, out, 5)
+	packUint8(s.DeviceID, out, 6)
+	packUint8(s.ChannelNumber<<4|s.DeviceRevision&0x0f, out, 7)
+	packUint8(s.FirmwareMajorRevision&0x7f, out, 8)
+	packUint8(s.FirmwareMinorRevision, out, 9)
+	packUint8(s.MinorIPMIVersion<<4|s.MajorIPMIVersion&0x0f, out, 10)
+	packUint24L(s.ManufacturerID, out, 11)
+	packUint16L(s.ProductID, out, 14)
+
+	guid := s.DeviceGUID
+	if len(guid) > 16 {
+		guid = guid[:16]
+	}
+	packBytes(guid, out, 16)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.11 SDR Type 14h - BMC Message Channel Info Record
 type SDRBMCChannelInfo struct {
 	//
@@ -659,9 +930,18 @@ func parseChannelInfo(b uint8) ChannelInfo {
 	}
 }
 
+const sdrBMCMessageChannelInfoSize = 16
+
+func packChannelInfo(info ChannelInfo) uint8 {
+	b := (info.MessageReceiveLUN&0x07)<<4 | info.ChannelProtocol&0x0f
+	if info.TransmitSupported {
+		b = setBit7(b)
+	}
+	return b
+}
+
 func parseSDRBMCMessageChannelInfo(data []byte, sdr *SDR) error {
-	const SDRBMCMessageChannelInfoSize = 16
-	minSize := SDRBMCMessageChannelInfoSize
+	minSize := sdrBMCMessageChannelInfoSize
 	if len(data) < minSize {
 		return fmt.Errorf("sdr (bmc message channel info) data must be longer than %d", minSize)
 	}
@@ -683,6 +963,22 @@ func parseSDRBMCMessageChannelInfo(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack encodes the BMC Message Channel Info Record to raw data (the record body, without the record header),
+// it is the reverse of parseSDRBMCMessageChannelInfo.
+func (s *SDRBMCChannelInfo) Pack() []byte {
+	out := make([]byte, sdrBMCMessageChannelInfoSize)
+
+	channels := []ChannelInfo{s.Channel0, s.Channel1, s.Channel2, s.Channel3, s.Channel4, s.Channel5, s.Channel6, s.Channel7}
+	for i, channel := range channels {
+		packUint8(packChannelInfo(channel), out, 5+i)
+	}
+
+	packUint8(s.MessagingInterruptType, out, 13)
+	packUint8(s.EventMessageBufferInterruptType, out, 14)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.12 SDR Type C0h - OEM Record
 type SDROEM struct {
 	//
@@ -697,22 +993,34 @@ type SDROEM struct {
 	OEMData        []byte
 }
 
+const sdrOEMMinSize = 8
+const sdrOEMMaxSize = 64 // OEM defined records are limited to a maximum of 64 bytes, including the header
+
 func parseSDROEM(data []byte, sdr *SDR) error {
-	const SDROEMMinSize = 8
-	const SDROEMMaxSize = 64 // OEM defined records are limited to a maximum of 64 bytes, including the header
 
-	if len(data) < SDROEMMinSize {
-		return fmt.Errorf("sdr (bmc message channel info) data must be longer than %d", SDROEMMinSize)
+	if len(data) < sdrOEMMinSize {
+		return fmt.Errorf("sdr (bmc message channel info) data must be longer than %d", sdrOEMMinSize)
 	}
 
 	s := &SDROEM{}
 	sdr.OEM = s
 
 	s.ManufacturerID, _, _ = unpackUint24L(data, 5)
-	s.OEMData, _, _ = unpackBytesMost(data, 8, SDROEMMaxSize-8)
+	s.OEMData, _, _ = unpackBytesMost(data, 8, sdrOEMMaxSize-8)
 	return nil
 }
 
+// Pack encodes the OEM Record to raw data (the record body, without the record header),
+// it is the reverse of parseSDROEM.
+func (s *SDROEM) Pack() []byte {
+	out := make([]byte, sdrOEMMinSize+len(s.OEMData))
+
+	packUint24L(s.ManufacturerID, out, 5)
+	packBytes(s.OEMData, out, sdrOEMMinSize)
+
+	return out[sdrRecordHeaderSize:]
+}
+
 // 43.6 SDR Type 0Ah:0Fh - Reserved Records
 type SDRReserved struct {
 }
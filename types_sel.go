@@ -46,6 +46,18 @@ func (sel *SEL) Pack() []byte {
 	return msg
 }
 
+// Timestamp returns the time when the record was logged,
+// zero time for non-timestamped OEM records.
+func (sel *SEL) Timestamp() time.Time {
+	switch {
+	case sel.Standard != nil:
+		return sel.Standard.Timestamp
+	case sel.OEMTimestamped != nil:
+		return sel.OEMTimestamped.Timestamp
+	}
+	return time.Time{}
+}
+
 func ParseSEL(msg []byte) (*SEL, error) {
 	if len(msg) != 16 {
 		return nil, fmt.Errorf("SEL Record msg should be 16 bytes in length")
@@ -146,6 +158,13 @@ func (sel *SELStandard) EventString() string {
 	return sel.EventReadingType.EventString(sel.SensorType, sel.SensorNumber, sel.EventData)
 }
 
+// EventDetail decodes the event with Event Data 2 and Event Data 3.
+// The sdr is the SDR of the sensor which generated the event, it is optional,
+// and used to convert the trigger reading and threshold of threshold events.
+func (sel *SELStandard) EventDetail(sdr *SDR) *EventDetail {
+	return sel.EventReadingType.EventDetail(sel.SensorType, sel.SensorNumber, sel.EventData, sdr)
+}
+
 func (sel *SELStandard) EventSeverity() EventSeverity {
 	return sel.EventReadingType.EventSeverity(sel.SensorType, sel.SensorNumber, sel.EventData, sel.EventDir)
 }
@@ -216,7 +235,7 @@ func parseSELOEMNonTimestamped(msg []byte, sel *SEL) error {
 
 	s.OEM = [13]byte{}
 	b, _, _ := unpackBytes(msg, 3, 13)
-	for i := 0; i < 6; i++ {
+	for i := 0; i < 13; i++ {
 		s.OEM[i] = b[i]
 	}
 
@@ -225,9 +244,17 @@ func parseSELOEMNonTimestamped(msg []byte, sel *SEL) error {
 
 // FormatSELs print sel records in table format.
 // The second sdrMap is optional. If the sdrMap is not nil,
-// it will also print sensor number, entity id and instance, and asserted discrete states.
+// it will also print sensor name, and the event description decoded from event data 2 and 3,
+// see SELStandard.EventDetail.
 // sdrMap can be get by client GetSDRsMap method.
 func FormatSELs(records []*SEL, sdrMap SDRMapBySensorNumber) string {
+	return FormatSELsWithDecoder(records, sdrMap, nil)
+}
+
+// FormatSELsWithDecoder print sel records in table format like FormatSELs,
+// and the OEM information of the records is decoded by the OEM decoder, see DecodeOEMSEL.
+// The decoder for the BMC can be get by client GetSELOEMDecoder method.
+func FormatSELsWithDecoder(records []*SEL, sdrMap SDRMapBySensorNumber, decoder SELOEMDecoder) string {
 	var elistMode bool
 	if sdrMap != nil {
 		elistMode = true
@@ -267,6 +294,15 @@ func FormatSELs(records []*SEL, sdrMap SDRMapBySensorNumber) string {
 		case SELRecordTypeRangeStandard:
 			s := sel.Standard
 
+			eventDesc := s.EventString()
+			sdr, sdrFound := sdrMap[s.GeneratorID][s.SensorNumber]
+			if elistMode {
+				eventDesc = s.EventDetail(sdr).String()
+			}
+			if oemDesc, ok := DecodeOEMSEL(sel, decoder); ok {
+				eventDesc = fmt.Sprintf("%s, %s", eventDesc, oemDesc)
+			}
+
 			content := []string{
 				fmt.Sprintf("%#04x", sel.RecordID),
 				sel.RecordType.String(),
@@ -278,7 +314,7 @@ func FormatSELs(records []*SEL, sdrMap SDRMapBySensorNumber) string {
 				s.SensorType.String(),
 				fmt.Sprintf("%#02x", uint8(s.EventReadingType)),
 				s.EventReadingType.String(),
-				s.EventString(),
+				eventDesc,
 				s.EventDir.String(),
 				string(s.EventSeverity()),
 				s.EventData.String(),
@@ -286,8 +322,7 @@ func FormatSELs(records []*SEL, sdrMap SDRMapBySensorNumber) string {
 
 			if elistMode {
 				var sensorName string
-				sdr, ok := sdrMap[s.GeneratorID][s.SensorNumber]
-				if !ok {
+				if !sdrFound {
 					sensorName = fmt.Sprintf("N/A %#04x, %#02x", s.GeneratorID, s.SensorNumber)
 				} else {
 					sensorName = sdr.SensorName()
@@ -298,7 +333,48 @@ func FormatSELs(records []*SEL, sdrMap SDRMapBySensorNumber) string {
 			table.Append(content)
 
 		case SELRecordTypeRangeTimestampedOEM:
+			s := sel.OEMTimestamped
+
+			eventDesc, ok := DecodeOEMSEL(sel, decoder)
+			if !ok {
+				eventDesc = fmt.Sprintf("OEM record, manufacturer %s (%d)", OEM(s.ManufacturerID), s.ManufacturerID)
+			}
+
+			content := []string{
+				fmt.Sprintf("%#04x", sel.RecordID),
+				sel.RecordType.String(),
+				"",
+				fmt.Sprintf("%v", s.Timestamp),
+				"", "", "", "", "", "",
+				eventDesc,
+				"", "",
+				fmt.Sprintf("%x", s.OEMDefined),
+			}
+			if elistMode {
+				content = append(content, "")
+			}
+			table.Append(content)
+
 		case SELRecordTypeRangeNonTimestampedOEM:
+			s := sel.OEMNonTimestamped
+
+			eventDesc, ok := DecodeOEMSEL(sel, decoder)
+			if !ok {
+				eventDesc = "OEM record"
+			}
+
+			content := []string{
+				fmt.Sprintf("%#04x", sel.RecordID),
+				sel.RecordType.String(),
+				"", "", "", "", "", "", "", "",
+				eventDesc,
+				"", "",
+				fmt.Sprintf("%x", s.OEM),
+			}
+			if elistMode {
+				content = append(content, "")
+			}
+			table.Append(content)
 		}
 	}
 
@@ -0,0 +1,229 @@
+package ipmi
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// max times to restart clearing SEL when the reservation is canceled or SEL is changed.
+	selClearMaxRestarts int = 8
+
+	// max times to poll the erasure status of SEL.
+	selErasePollMaxTimes int = 60
+)
+
+// selErasePollInterval is the interval to poll the erasure status of SEL.
+var selErasePollInterval = 500 * time.Millisecond
+
+// selEraser is the commands used to clear SEL, it is implemented by Client.
+type selEraser interface {
+	selSource
+	ReserveSEL() (*ReserveSELResponse, error)
+	ClearSEL(reservationID uint16) (*ClearSELResponse, error)
+	GetSELErasureStatus(reservationID uint16) (*ClearSELResponse, error)
+	DeleteSELEntry(recordID uint16, reservationID uint16) (*DeleteSELEntryResponse, error)
+}
+
+// ClearSELSafely archives all the SEL records to archiveWriter, then clears SEL and waits for the erasure to complete.
+//
+// The archive is the JSON array of SELOutput, the sensor names are resolved by the SDRs, and the OEM records
+// are decoded by the OEM decoder of the BMC. The archive is written once before the erasure is initiated,
+// and the archiving is skipped if archiveWriter is nil.
+//
+// ClearSELSafely takes the ownership of archiveWriter, it is closed after the archive is written,
+// or when ClearSELSafely returns if the archive is not written. SEL is not cleared if writing or closing
+// the archive failed, so the Close method should make the archive durable, like syncing a file before closing it.
+//
+// The SDRs are read before SEL. SEL is read again if it changed while reading, and SEL Info is checked again
+// right before the erasure is initiated, SEL is not cleared if it changed after the archive was written.
+// If the reservation is canceled (0xC5), a new reservation is obtained and the erasure is initiated again.
+// If the reservation is canceled while polling the erasure status, a new reservation is obtained to keep polling.
+//
+// The archived records are returned.
+func (c *Client) ClearSELSafely(archiveWriter io.WriteCloser) ([]*SEL, error) {
+	var archive func(records []*SEL) error
+	if archiveWriter != nil {
+		var closed bool
+		defer func() {
+			if !closed {
+				archiveWriter.Close()
+			}
+		}()
+
+		// Read the SDRs before SEL, walking the SDR Repository takes a while,
+		// and the records added meanwhile must not be erased without being archived.
+		sdrsMap, err := c.GetSDRsMap()
+		if err != nil {
+			c.Debugf("GetSDRsMap failed, the sensor names are not archived, err: %s\n", err)
+		}
+		decoder, err := c.GetSELOEMDecoder()
+		if err != nil {
+			c.Debugf("GetSELOEMDecoder failed, the OEM records are not decoded, err: %s\n", err)
+		}
+
+		archive = func(records []*SEL) error {
+			closed = true
+			return writeSELArchive(archiveWriter, NewSELOutputs(records, sdrsMap, decoder))
+		}
+	}
+
+	return clearSELSafely(c, archive)
+}
+
+// writeSELArchive writes the records to the archive as a JSON array and closes it.
+func writeSELArchive(w io.WriteCloser, records []OutputRecord) error {
+	encoder := NewOutputEncoder(OutputFormatJSON)
+	if err := encoder.Encode(w, records...); err != nil {
+		w.Close()
+		return fmt.Errorf("write SEL archive failed, err: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close SEL archive failed, err: %s", err)
+	}
+	return nil
+}
+
+func clearSELSafely(source selEraser, archive func(records []*SEL) error) ([]*SEL, error) {
+	// the SEL Info when the records were archived
+	var archived *GetSELInfoResponse
+	var records []*SEL
+
+	for i := 0; i <= selClearMaxRestarts; i++ {
+		reserveRes, err := source.ReserveSEL()
+		if err != nil {
+			return nil, fmt.Errorf("ReserveSEL failed, err: %s", err)
+		}
+		reservationID := reserveRes.ReservationID
+
+		info, err := source.GetSELInfo()
+		if err != nil {
+			return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+		}
+
+		if archived == nil {
+			records, err = readSELEntries(source, info)
+			if err != nil {
+				return nil, err
+			}
+
+			infoAfter, err := source.GetSELInfo()
+			if err != nil {
+				return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+			}
+			if !sameSELInfo(info, infoAfter) {
+				// SEL changed while reading, read again
+				continue
+			}
+
+			if archive != nil {
+				if err := archive(records); err != nil {
+					return nil, err
+				}
+			}
+			archived = info
+
+			// SEL may change while the archive is written
+			info, err = source.GetSELInfo()
+			if err != nil {
+				return records, fmt.Errorf("GetSELInfo failed, err: %s", err)
+			}
+		}
+
+		if !sameSELInfo(archived, info) {
+			if archive != nil {
+				return records, fmt.Errorf("SEL changed after the records were archived, SEL is not cleared")
+			}
+			// nothing is written yet, read again
+			archived = nil
+			continue
+		}
+
+		clearRes, err := source.ClearSEL(reservationID)
+		if err != nil {
+			if isReservationCanceled(err) {
+				continue
+			}
+			return records, fmt.Errorf("ClearSEL failed, err: %s", err)
+		}
+
+		for j := 0; !clearRes.Completed(); j++ {
+			if j >= selErasePollMaxTimes {
+				return records, fmt.Errorf("wait for SEL erasure to complete timeout")
+			}
+			time.Sleep(selErasePollInterval)
+
+			clearRes, err = source.GetSELErasureStatus(reservationID)
+			if err != nil {
+				if !isReservationCanceled(err) {
+					return records, fmt.Errorf("GetSELErasureStatus failed, err: %s", err)
+				}
+				// the erasure has been initiated, only the reservation is needed to get the status
+				reserveRes, err := source.ReserveSEL()
+				if err != nil {
+					return records, fmt.Errorf("ReserveSEL failed, err: %s", err)
+				}
+				reservationID = reserveRes.ReservationID
+				clearRes = &ClearSELResponse{}
+			}
+		}
+		return records, nil
+	}
+
+	return records, fmt.Errorf("reservation canceled or SEL changed %d times, SEL is not cleared", selClearMaxRestarts+1)
+}
+
+// DeleteSELEntryReserved deletes the SEL record with a new reservation,
+// the reservation is obtained again if it is canceled (0xC5) before the deletion.
+func (c *Client) DeleteSELEntryReserved(recordID uint16) (*DeleteSELEntryResponse, error) {
+	return deleteSELEntryReserved(c, recordID)
+}
+
+func deleteSELEntryReserved(source selEraser, recordID uint16) (*DeleteSELEntryResponse, error) {
+	for i := 0; i <= selClearMaxRestarts; i++ {
+		reserveRes, err := source.ReserveSEL()
+		if err != nil {
+			return nil, fmt.Errorf("ReserveSEL failed, err: %s", err)
+		}
+
+		res, err := source.DeleteSELEntry(recordID, reserveRes.ReservationID)
+		if err != nil {
+			if isReservationCanceled(err) {
+				continue
+			}
+			return nil, fmt.Errorf("DeleteSELEntry failed, err: %s", err)
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("reservation canceled %d times", selClearMaxRestarts+1)
+}
+
+// readSELEntries reads all the SEL records, it returns empty if the SEL Info reports no entries.
+func readSELEntries(source selSource, info *GetSELInfoResponse) ([]*SEL, error) {
+	records := make([]*SEL, 0)
+	if info.Entries == 0 {
+		return records, nil
+	}
+
+	err := walkSEL(source, 0x0000, func(sel *SEL) bool {
+		records = append(records, sel)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// sameSELInfo reports whether SEL is not changed between the two SEL Info.
+func sameSELInfo(a *GetSELInfoResponse, b *GetSELInfoResponse) bool {
+	return a.Entries == b.Entries &&
+		a.RecentAdditionTime.Equal(b.RecentAdditionTime) &&
+		a.RecentEraseTime.Equal(b.RecentEraseTime)
+}
+
+func isReservationCanceled(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	return ok && resErr.CompletionCode() == CompletionCodeReservationCanceled
+}
@@ -0,0 +1,216 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeSELEraser is an in-memory SEL implementing selEraser.
+type fakeSELEraser struct {
+	*fakeSEL
+
+	reservationID uint16
+	// cancel the reservations of the next n ClearSEL or DeleteSELEntry commands
+	cancelReservations int
+	// cancel the reservations of the next n GetSELErasureStatus commands
+	cancelPollReservations int
+	// the erasure completes after n polls
+	erasePolls int
+	// called after the records are read, to change SEL while reading
+	onRead func()
+}
+
+func (s *fakeSELEraser) ReserveSEL() (*ReserveSELResponse, error) {
+	s.reservationID++
+	return &ReserveSELResponse{ReservationID: s.reservationID}, nil
+}
+
+func (s *fakeSELEraser) checkReservation(reservationID uint16) error {
+	if s.cancelReservations > 0 || reservationID != s.reservationID {
+		s.cancelReservations--
+		return &ResponseError{completionCode: CompletionCodeReservationCanceled}
+	}
+	return nil
+}
+
+func (s *fakeSELEraser) GetSELEntry(reservationID uint16, recordID uint16) (*GetSELEntryResponse, error) {
+	res, err := s.fakeSEL.GetSELEntry(reservationID, recordID)
+	if err == nil && res.NextRecordID == 0xffff && s.onRead != nil {
+		onRead := s.onRead
+		s.onRead = nil
+		onRead()
+	}
+	return res, err
+}
+
+func (s *fakeSELEraser) ClearSEL(reservationID uint16) (*ClearSELResponse, error) {
+	if err := s.checkReservation(reservationID); err != nil {
+		return nil, err
+	}
+	s.clear(200)
+	return s.erasureStatus(), nil
+}
+
+func (s *fakeSELEraser) GetSELErasureStatus(reservationID uint16) (*ClearSELResponse, error) {
+	if s.cancelPollReservations > 0 || reservationID != s.reservationID {
+		s.cancelPollReservations--
+		// the reservation is canceled by another party
+		s.reservationID++
+		return nil, &ResponseError{completionCode: CompletionCodeReservationCanceled}
+	}
+	return s.erasureStatus(), nil
+}
+
+func (s *fakeSELEraser) erasureStatus() *ClearSELResponse {
+	if s.erasePolls > 0 {
+		s.erasePolls--
+		return &ClearSELResponse{ErasureProgressStatus: 0x00}
+	}
+	return &ClearSELResponse{ErasureProgressStatus: 0x01}
+}
+
+func (s *fakeSELEraser) DeleteSELEntry(recordID uint16, reservationID uint16) (*DeleteSELEntryResponse, error) {
+	if err := s.checkReservation(reservationID); err != nil {
+		return nil, err
+	}
+	for i, sel := range s.records {
+		if sel.RecordID == recordID {
+			s.records = append(s.records[:i], s.records[i+1:]...)
+			return &DeleteSELEntryResponse{RecordID: recordID}, nil
+		}
+	}
+	return nil, &ResponseError{completionCode: CompletionCodeRequestedDataNotPresent}
+}
+
+func Test_clearSELSafely(t *testing.T) {
+	selErasePollInterval = time.Millisecond
+
+	tests := []struct {
+		name                   string
+		records                int
+		cancelReservations     int
+		cancelPollReservations int
+		onRead                 bool
+		archived               []uint16
+		cleared                bool
+	}{
+		{"empty", 0, 0, 0, false, []uint16{}, true},
+		{"cleared", 3, 0, 0, false, []uint16{1, 2, 3}, true},
+		{"reservation canceled", 2, 2, 0, false, []uint16{1, 2}, true},
+		{"reservation canceled while polling", 2, 0, 2, false, []uint16{1, 2}, true},
+		{"changed while reading", 2, 0, 0, true, []uint16{1, 2, 3}, true},
+		{"reservation always canceled", 2, 100, 0, false, []uint16{1, 2}, false},
+	}
+
+	for _, test := range tests {
+		sel := &fakeSEL{}
+		for i := 1; i <= test.records; i++ {
+			sel.add(uint16(i), int64(100+i))
+		}
+		eraser := &fakeSELEraser{
+			fakeSEL:                sel,
+			cancelReservations:     test.cancelReservations,
+			cancelPollReservations: test.cancelPollReservations,
+			erasePolls:             2,
+		}
+		if test.onRead {
+			eraser.onRead = func() { sel.add(3, 110) }
+		}
+
+		archives := 0
+		records, err := clearSELSafely(eraser, func(records []*SEL) error {
+			archives++
+			return nil
+		})
+		if (err == nil) != test.cleared {
+			t.Errorf("test %s failed, err: %v", test.name, err)
+			continue
+		}
+
+		ids := make([]uint16, 0)
+		for _, record := range records {
+			ids = append(ids, record.RecordID)
+		}
+		if !reflect.DeepEqual(ids, test.archived) || archives != 1 {
+			t.Errorf("test %s archive not matched, got: %v (%d times), expected: %v", test.name, ids, archives, test.archived)
+		}
+		if cleared := len(sel.records) == 0; cleared != test.cleared {
+			t.Errorf("test %s cleared not matched, got: %v, expected: %v", test.name, cleared, test.cleared)
+		}
+	}
+}
+
+func Test_clearSELSafely_ChangedAfterArchived(t *testing.T) {
+	tests := []struct {
+		name               string
+		cancelReservations int
+	}{
+		{"changed while archiving", 0},
+		{"changed while archiving and reservation canceled", 1},
+	}
+
+	for _, test := range tests {
+		sel := &fakeSEL{}
+		sel.add(1, 100)
+		eraser := &fakeSELEraser{fakeSEL: sel, cancelReservations: test.cancelReservations}
+
+		_, err := clearSELSafely(eraser, func(records []*SEL) error {
+			// a record is added after the records are read, while the archive is written
+			sel.add(2, 101)
+			return nil
+		})
+		if err == nil || len(sel.records) != 2 {
+			t.Errorf("test %s SEL should not be cleared, err: %v, records: %d", test.name, err, len(sel.records))
+		}
+	}
+}
+
+func Test_deleteSELEntryReserved(t *testing.T) {
+	sel := &fakeSEL{}
+	sel.add(1, 100)
+	sel.add(2, 101)
+	eraser := &fakeSELEraser{fakeSEL: sel, cancelReservations: 1}
+
+	res, err := deleteSELEntryReserved(eraser, 2)
+	if err != nil {
+		t.Fatalf("test deleteSELEntryReserved failed, err: %s", err)
+	}
+	if res.RecordID != 2 || len(sel.records) != 1 || sel.records[0].RecordID != 1 {
+		t.Errorf("test deleteSELEntryReserved not matched, got: %d, records: %d", res.RecordID, len(sel.records))
+	}
+
+	if _, err := deleteSELEntryReserved(eraser, 3); err == nil {
+		t.Errorf("test deleteSELEntryReserved of not present record should fail")
+	}
+}
+
+// fakeArchiveFile records the Close calls, and fails them by the error.
+type fakeArchiveFile struct {
+	bytes.Buffer
+	closeErr error
+	closed   int
+}
+
+func (f *fakeArchiveFile) Close() error {
+	f.closed++
+	return f.closeErr
+}
+
+func Test_writeSELArchive(t *testing.T) {
+	sel := &SEL{RecordID: 1, RecordType: 0x02, Standard: &SELStandard{Timestamp: time.Unix(100, 0)}}
+
+	f := &fakeArchiveFile{}
+	if err := writeSELArchive(f, NewSELOutputs([]*SEL{sel}, nil, nil)); err != nil || f.closed != 1 {
+		t.Errorf("test archive not written and closed once, closed: %d, err: %v", f.closed, err)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(f.Bytes()), []byte("[")) {
+		t.Errorf("test archive is not a JSON array, got: %s", f.String())
+	}
+
+	if err := writeSELArchive(&fakeArchiveFile{closeErr: fmt.Errorf("input/output error")}, nil); err == nil {
+		t.Errorf("test close error expected error")
+	}
+}
@@ -0,0 +1,589 @@
+package ipmi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const DefaultSELFollowInterval time.Duration = 5 * time.Second
+
+// SELCursor is the position of SELFollower in SEL.
+//
+// SEL Record IDs are handles, they are not ordered and can be reused after SEL is cleared,
+// so the cursor also records the timestamp of the last delivered record to verify the record,
+// and the most recent addition and erase timestamps of SEL to detect changes.
+type SELCursor struct {
+	// RecordID and Timestamp of the last delivered record, RecordID is 0 if no record delivered.
+	RecordID  uint16    `json:"record_id"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// The SEL Info timestamps when the records till the cursor are delivered.
+	AdditionTime time.Time `json:"addition_time"`
+	EraseTime    time.Time `json:"erase_time"`
+
+	// The SEL Info entries and the ID of the last record of SEL when the records till the cursor are delivered,
+	// they detect the records added in the same second as the most recent addition timestamp.
+	Entries      uint16 `json:"entries"`
+	LastRecordID uint16 `json:"last_record_id"`
+
+	// The last update timestamps of the auxiliary logs, like the MCA log, reported by Get Auxiliary Log Status.
+	// Only the supported log types are recorded, and only if the follower has an aux log handler.
+	AuxLogTimes map[AuxLogType]time.Time `json:"aux_log_times,omitempty"`
+}
+
+// SELCursorStore persists the cursor of SELFollower, so the follower can resume after restart.
+type SELCursorStore interface {
+	// Load returns the saved cursor, or nil if no cursor was saved.
+	Load() (*SELCursor, error)
+	Save(cursor *SELCursor) error
+}
+
+// MemorySELCursorStore keeps the cursor in memory.
+type MemorySELCursorStore struct {
+	cursor *SELCursor
+}
+
+func (s *MemorySELCursorStore) Load() (*SELCursor, error) {
+	if s.cursor == nil {
+		return nil, nil
+	}
+	cursor := *s.cursor
+	return &cursor, nil
+}
+
+func (s *MemorySELCursorStore) Save(cursor *SELCursor) error {
+	c := *cursor
+	s.cursor = &c
+	return nil
+}
+
+// FileSELCursorStore saves the cursor as a JSON file.
+type FileSELCursorStore struct {
+	File string
+}
+
+func NewFileSELCursorStore(file string) *FileSELCursorStore {
+	return &FileSELCursorStore{
+		File: file,
+	}
+}
+
+func (s *FileSELCursorStore) Load() (*SELCursor, error) {
+	b, err := os.ReadFile(s.File)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read cursor file failed, err: %s", err)
+	}
+
+	cursor := &SELCursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, fmt.Errorf("unmarshal cursor file failed, err: %s", err)
+	}
+	return cursor, nil
+}
+
+// Save writes the cursor to a temporary file and renames it to the cursor file,
+// so the cursor file is never partially written.
+func (s *FileSELCursorStore) Save(cursor *SELCursor) error {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return fmt.Errorf("marshal cursor failed, err: %s", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.File), filepath.Base(s.File)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temp cursor file failed, err: %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp cursor file failed, err: %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp cursor file failed, err: %s", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.File); err != nil {
+		return fmt.Errorf("rename temp cursor file failed, err: %s", err)
+	}
+	return nil
+}
+
+// selSource is the commands used by SELFollower, it is implemented by Client.
+type selSource interface {
+	GetSELInfo() (*GetSELInfoResponse, error)
+	GetSELEntry(reservationID uint16, recordID uint16) (*GetSELEntryResponse, error)
+}
+
+// SELFollower polls SEL and streams the newly added SEL records, each record is delivered at least once.
+//
+// It polls the Get SEL Info command and the last SEL entry, and only reads the SEL entries when the most recent
+// addition or erase timestamp, the number of entries or the last record ID changed.
+// New records are normally read from the cursor record onwards.
+// If SEL was erased (the erase timestamp changed), all the records in SEL are delivered in SEL order.
+// If the cursor record is gone or replaced otherwise, that is SEL wrapped around,
+// SEL is read from the first record, and only the records logged after the cursor record are delivered,
+// non-timestamped OEM records are not delivered in this case.
+//
+// Each record must be acknowledged by Ack after it is handled, the cursor is saved to the store
+// on the ack and the next record is delivered after it. A record delivered but not acknowledged
+// is delivered again after restart. The delivery is not exactly once, a record handled but not yet
+// acknowledged when the process stops is handled twice, so the handlers should be idempotent,
+// for example by skipping the records of the same record ID and timestamp.
+//
+// With an aux log handler, the follower also polls the status of the auxiliary logs, and reports the
+// logs whose last update timestamp changed, so the fresh machine check data can be collected.
+type SELFollower struct {
+	source selSource
+
+	interval      time.Duration
+	store         SELCursorStore
+	fromBeginning bool
+	lastRecords   int
+	errorHandler  func(err error)
+
+	auxLogHandler func(status *GetAuxLogStatusResponse)
+	// the aux log types not supported by the BMC, they are not polled again
+	auxLogUnsupported map[AuxLogType]bool
+
+	cursor  *SELCursor
+	records chan *SEL
+	acks    chan *SEL
+	done    chan struct{}
+}
+
+// NewSELFollower creates a SELFollower which polls SEL every interval.
+// The cursor is loaded from and saved to store, if store is nil, the cursor is kept in memory.
+// Call Run to start following, and receive the records from Records.
+func (c *Client) NewSELFollower(interval time.Duration, store SELCursorStore) *SELFollower {
+	return newSELFollower(c, interval, store)
+}
+
+func newSELFollower(source selSource, interval time.Duration, store SELCursorStore) *SELFollower {
+	if interval <= 0 {
+		interval = DefaultSELFollowInterval
+	}
+	if store == nil {
+		store = &MemorySELCursorStore{}
+	}
+
+	return &SELFollower{
+		source:            source,
+		interval:          interval,
+		store:             store,
+		auxLogUnsupported: make(map[AuxLogType]bool),
+		records:           make(chan *SEL),
+		acks:              make(chan *SEL),
+		done:              make(chan struct{}),
+	}
+}
+
+// WithStartFromBeginning makes the follower deliver all the existing records when there's no saved cursor.
+// By default, only the records added after the follower started are delivered.
+func (f *SELFollower) WithStartFromBeginning(fromBeginning bool) *SELFollower {
+	f.fromBeginning = fromBeginning
+	return f
+}
+
+// WithStartFromLast makes the follower deliver the last n existing records when there's no saved cursor,
+// like "tail -f -n". All the existing records are delivered if SEL has no more than n records.
+func (f *SELFollower) WithStartFromLast(n int) *SELFollower {
+	f.lastRecords = n
+	return f
+}
+
+// WithErrorHandler sets the handler of the errors occurred when polling SEL and the aux logs.
+// The failed poll is retried at the next interval.
+func (f *SELFollower) WithErrorHandler(handler func(err error)) *SELFollower {
+	f.errorHandler = handler
+	return f
+}
+
+// WithAuxLogHandler sets the handler of the auxiliary log status, it is called when the last update
+// timestamp of an auxiliary log changed. The first status of each log is only recorded in the cursor,
+// unless the follower starts from the beginning.
+// The aux logs are not polled if the BMC does not support the Get Auxiliary Log Status command.
+func (f *SELFollower) WithAuxLogHandler(handler func(status *GetAuxLogStatusResponse)) *SELFollower {
+	f.auxLogHandler = handler
+	return f
+}
+
+// Records returns the channel of the new SEL records, it is closed when Run returns.
+// Each received record must be acknowledged by Ack.
+func (f *SELFollower) Records() <-chan *SEL {
+	return f.records
+}
+
+// Ack acknowledges the record received from Records is handled, so the cursor is moved past it and saved.
+// Do not ack a record which failed to be handled, stop the follower instead, so it is delivered again.
+func (f *SELFollower) Ack(sel *SEL) {
+	select {
+	case f.acks <- sel:
+	case <-f.done:
+	}
+}
+
+// Run polls SEL until ctx is done. It can only be called once.
+// It returns error if the cursor can not be loaded or saved.
+func (f *SELFollower) Run(ctx context.Context) error {
+	defer close(f.done)
+	defer close(f.records)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.poll(ctx); err != nil {
+			if _, ok := err.(*selCursorStoreError); ok {
+				return err
+			}
+			if f.errorHandler != nil {
+				f.errorHandler(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type selCursorStoreError struct {
+	err error
+}
+
+func (e *selCursorStoreError) Error() string {
+	return fmt.Sprintf("SEL cursor store failed, err: %s", e.err)
+}
+
+func (f *SELFollower) save() error {
+	if err := f.store.Save(f.cursor); err != nil {
+		return &selCursorStoreError{err}
+	}
+	return nil
+}
+
+// poll delivers the records added since the cursor, and reports the changed aux logs.
+// The aux logs are polled even if the SEL poll failed, the errors of both are returned.
+func (f *SELFollower) poll(ctx context.Context) error {
+	selErr := f.pollSEL(ctx)
+	if _, ok := selErr.(*selCursorStoreError); ok {
+		return selErr
+	}
+	if ctx.Err() != nil {
+		return selErr
+	}
+
+	auxLogErr := f.pollAuxLogs()
+	if _, ok := auxLogErr.(*selCursorStoreError); ok {
+		return auxLogErr
+	}
+	switch {
+	case selErr == nil:
+		return auxLogErr
+	case auxLogErr == nil:
+		return selErr
+	}
+	return fmt.Errorf("%s; %s", selErr, auxLogErr)
+}
+
+// pollSEL delivers the records added since the cursor.
+func (f *SELFollower) pollSEL(ctx context.Context) error {
+	info, err := f.source.GetSELInfo()
+	if err != nil {
+		return fmt.Errorf("GetSELInfo failed, err: %s", err)
+	}
+
+	// the timestamps have a resolution of one second, the records added in the same second
+	// after the last poll are detected by the number of entries and the last record.
+	var last *SEL
+	var lastRecordID uint16
+	if info.Entries > 0 {
+		sel, _, found, err := f.readRecord(0xffff)
+		if err != nil {
+			return err
+		}
+		if found {
+			last, lastRecordID = sel, sel.RecordID
+		}
+	}
+
+	if f.cursor == nil {
+		if err := f.initCursor(info, last); err != nil {
+			return err
+		}
+	}
+
+	if info.RecentAdditionTime.Equal(f.cursor.AdditionTime) && info.RecentEraseTime.Equal(f.cursor.EraseTime) &&
+		info.Entries == f.cursor.Entries && lastRecordID == f.cursor.LastRecordID {
+		return nil
+	}
+
+	// SEL was erased since the cursor, every record in SEL is logged after the erasure whatever
+	// its record ID and timestamp are, so SEL is delivered from the first record.
+	// The erase timestamp only changes on erasure, it is not compared by order as SEL Time may be set backwards.
+	// A zero erase timestamp of a cursor with a record is unknown (the cursor was saved without SEL Info),
+	// the erasure is then detected by the cursor record in newRecords.
+	eraseUnknown := f.cursor.EraseTime.IsZero() && f.cursor.RecordID != 0
+	if !eraseUnknown && !info.RecentEraseTime.Equal(f.cursor.EraseTime) {
+		f.cursor.RecordID = 0
+		f.cursor.Timestamp = time.Time{}
+		f.cursor.EraseTime = info.RecentEraseTime
+		if err := f.save(); err != nil {
+			return err
+		}
+	}
+
+	records, err := f.newRecords(info)
+	if err != nil {
+		return err
+	}
+
+	for _, sel := range records {
+		select {
+		case f.records <- sel:
+		case <-ctx.Done():
+			return nil
+		}
+
+		select {
+		case acked := <-f.acks:
+			if acked != sel {
+				return fmt.Errorf("acked record (%#04x) is not the delivered record (%#04x)", acked.RecordID, sel.RecordID)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+
+		f.cursor.RecordID = sel.RecordID
+		f.cursor.Timestamp = sel.Timestamp()
+		if err := f.save(); err != nil {
+			return err
+		}
+	}
+
+	f.cursor.AdditionTime = info.RecentAdditionTime
+	f.cursor.EraseTime = info.RecentEraseTime
+	f.cursor.Entries = info.Entries
+	f.cursor.LastRecordID = lastRecordID
+	return f.save()
+}
+
+// pollAuxLogs calls the aux log handler for the aux logs updated since the cursor.
+func (f *SELFollower) pollAuxLogs() error {
+	if f.auxLogHandler == nil {
+		return nil
+	}
+	source, ok := f.source.(AuxLogSource)
+	if !ok {
+		return nil
+	}
+
+	// the cursor is shared with the store by shallow copies, so the map is replaced instead of modified
+	times := make(map[AuxLogType]time.Time, len(f.cursor.AuxLogTimes))
+	for logType, t := range f.cursor.AuxLogTimes {
+		times[logType] = t
+	}
+
+	var changed bool
+	var lastErr error
+	for _, logType := range AuxLogTypes {
+		if f.auxLogUnsupported[logType] {
+			continue
+		}
+		status, err := source.GetAuxLogStatus(logType)
+		if err != nil {
+			if IsAuxLogUnsupported(err) {
+				f.auxLogUnsupported[logType] = true
+				continue
+			}
+			lastErr = fmt.Errorf("GetAuxLogStatus for %s failed, err: %s", logType, err)
+			continue
+		}
+
+		last, found := times[logType]
+		if found && last.Equal(status.Timestamp) {
+			continue
+		}
+		times[logType] = status.Timestamp
+		changed = true
+
+		if found || (f.fromBeginning && !status.Timestamp.IsZero()) {
+			f.auxLogHandler(status)
+		}
+	}
+
+	if changed {
+		f.cursor.AuxLogTimes = times
+		if err := f.save(); err != nil {
+			return err
+		}
+	}
+	return lastErr
+}
+
+// initCursor loads the cursor from store, or creates the cursor at the last record of SEL,
+// or before the last records of SEL if lastRecords is set, or at the beginning of SEL if fromBeginning is set.
+func (f *SELFollower) initCursor(info *GetSELInfoResponse, last *SEL) error {
+	cursor, err := f.store.Load()
+	if err != nil {
+		return &selCursorStoreError{err}
+	}
+	if cursor != nil {
+		f.cursor = cursor
+		return nil
+	}
+
+	cursor = &SELCursor{}
+	if !f.fromBeginning && f.lastRecords > 0 && info.Entries > 0 {
+		records, err := f.readRecords(0x0000, func(sel *SEL) bool {
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		// The cursor is at the record before the last records. The SEL Info is recorded, so the cursor
+		// is not taken as erased, and the last record ID is the cursor record, so the last records
+		// are delivered by the first poll. If SEL has no more records, the cursor has no record.
+		cursor.AdditionTime = info.RecentAdditionTime
+		cursor.EraseTime = info.RecentEraseTime
+		cursor.Entries = info.Entries
+		if len(records) > f.lastRecords {
+			before := records[len(records)-f.lastRecords-1]
+			cursor.RecordID = before.RecordID
+			cursor.Timestamp = before.Timestamp()
+			cursor.LastRecordID = before.RecordID
+		} else {
+			cursor.Entries = 0
+		}
+	} else if !f.fromBeginning {
+		if last != nil {
+			cursor.RecordID = last.RecordID
+			cursor.Timestamp = last.Timestamp()
+			cursor.LastRecordID = last.RecordID
+		}
+		cursor.AdditionTime = info.RecentAdditionTime
+		cursor.EraseTime = info.RecentEraseTime
+		cursor.Entries = info.Entries
+	}
+
+	f.cursor = cursor
+	return f.save()
+}
+
+// newRecords returns the records added since the cursor.
+func (f *SELFollower) newRecords(info *GetSELInfoResponse) ([]*SEL, error) {
+	if info.Entries == 0 {
+		return nil, nil
+	}
+
+	if f.cursor.RecordID == 0 {
+		// no record delivered yet
+		return f.readRecords(0x0000, func(sel *SEL) bool {
+			return true
+		})
+	}
+
+	records, found, err := f.readAfterCursor()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cursor record is in place, and new records are found after it,
+	// or SEL is not added but some records are deleted.
+	if found && (len(records) > 0 || info.RecentAdditionTime.Equal(f.cursor.AdditionTime)) {
+		return records, nil
+	}
+
+	// SEL was cleared or wrapped around, or the records were not added after the cursor record.
+	records, err = f.readRecords(0x0000, func(sel *SEL) bool {
+		if sel.RecordType.Range() == SELRecordTypeRangeNonTimestampedOEM {
+			return false
+		}
+		return selRecordAfter(sel, f.cursor.Timestamp, f.cursor.RecordID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// deliver in the logged order, so the cursor ends at the latest record.
+	sort.SliceStable(records, func(i, j int) bool {
+		return selRecordAfter(records[j], records[i].Timestamp(), records[i].RecordID)
+	})
+	return records, nil
+}
+
+// selRecordAfter reports whether the record is logged after the record of timestamp and recordID.
+// The records logged in the same second are ordered by the record ID.
+func selRecordAfter(sel *SEL, timestamp time.Time, recordID uint16) bool {
+	t := sel.Timestamp()
+	if t.Equal(timestamp) {
+		return sel.RecordID > recordID
+	}
+	return t.After(timestamp)
+}
+
+// readAfterCursor returns the records after the cursor record.
+// The found is false if the cursor record does not exist or is not the same record.
+func (f *SELFollower) readAfterCursor() (records []*SEL, found bool, err error) {
+	sel, nextRecordID, found, err := f.readRecord(f.cursor.RecordID)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	if sel.RecordID != f.cursor.RecordID || !sel.Timestamp().Equal(f.cursor.Timestamp) {
+		return nil, false, nil
+	}
+
+	if nextRecordID == 0xffff {
+		return nil, true, nil
+	}
+
+	records, err = f.readRecords(nextRecordID, func(sel *SEL) bool {
+		return true
+	})
+	return records, true, err
+}
+
+// readRecord returns the record of recordID and the next record ID,
+// the found is false if the record does not exist.
+func (f *SELFollower) readRecord(recordID uint16) (sel *SEL, nextRecordID uint16, found bool, err error) {
+	entry, err := f.source.GetSELEntry(0, recordID)
+	if err != nil {
+		if resErr, ok := err.(*ResponseError); ok && resErr.CompletionCode() == CompletionCodeRequestedDataNotPresent {
+			return nil, 0, false, nil
+		}
+		return nil, 0, false, fmt.Errorf("GetSELEntry for record (%#04x) failed, err: %s", recordID, err)
+	}
+
+	sel, err = ParseSEL(entry.Data)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("ParseSEL failed, err: %s", err)
+	}
+	return sel, entry.NextRecordID, true, nil
+}
+
+// readRecords returns the records starting from startRecordID which passed the filter.
+func (f *SELFollower) readRecords(startRecordID uint16, filter func(sel *SEL) bool) ([]*SEL, error) {
+	out := make([]*SEL, 0)
+	err := walkSEL(f.source, startRecordID, func(sel *SEL) bool {
+		if filter(sel) {
+			out = append(out, sel)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,430 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSEL is an in-memory SEL implementing selSource.
+type fakeSEL struct {
+	records      []*SEL
+	additionTime time.Time
+	eraseTime    time.Time
+	// infoErr fails GetSELInfo
+	infoErr error
+}
+
+func (s *fakeSEL) add(recordID uint16, ts int64) {
+	s.records = append(s.records, &SEL{
+		RecordID:   recordID,
+		RecordType: 0x02,
+		Standard: &SELStandard{
+			Timestamp: time.Unix(ts, 0),
+		},
+	})
+	s.additionTime = time.Unix(ts, 0)
+}
+
+func (s *fakeSEL) clear(ts int64) {
+	s.records = nil
+	s.eraseTime = time.Unix(ts, 0)
+}
+
+func (s *fakeSEL) GetSELInfo() (*GetSELInfoResponse, error) {
+	if s.infoErr != nil {
+		return nil, s.infoErr
+	}
+	return &GetSELInfoResponse{
+		Entries:            uint16(len(s.records)),
+		RecentAdditionTime: s.additionTime,
+		RecentEraseTime:    s.eraseTime,
+	}, nil
+}
+
+func (s *fakeSEL) GetSELEntry(reservationID uint16, recordID uint16) (*GetSELEntryResponse, error) {
+	notPresent := &ResponseError{completionCode: CompletionCodeRequestedDataNotPresent}
+	if len(s.records) == 0 {
+		return nil, notPresent
+	}
+
+	index := -1
+	switch recordID {
+	case 0x0000:
+		index = 0
+	case 0xffff:
+		index = len(s.records) - 1
+	default:
+		for i, sel := range s.records {
+			if sel.RecordID == recordID {
+				index = i
+			}
+		}
+	}
+	if index < 0 {
+		return nil, notPresent
+	}
+
+	var next uint16 = 0xffff
+	if index < len(s.records)-1 {
+		next = s.records[index+1].RecordID
+	}
+	return &GetSELEntryResponse{
+		NextRecordID: next,
+		Data:         s.records[index].Pack(),
+	}, nil
+}
+
+func Test_SELFollower(t *testing.T) {
+	sel := &fakeSEL{}
+	sel.add(1, 100)
+	sel.add(2, 101)
+
+	store := &MemorySELCursorStore{}
+	f := newSELFollower(sel, time.Second, store)
+	ctx := context.Background()
+
+	poll := func() []uint16 {
+		done := make(chan error)
+		go func() {
+			done <- f.poll(ctx)
+		}()
+		ids := make([]uint16, 0)
+		for {
+			select {
+			case record := <-f.records:
+				ids = append(ids, record.RecordID)
+				f.Ack(record)
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("poll failed, err: %s", err)
+				}
+				return ids
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		change   func()
+		expected []uint16
+	}{
+		{"start at the end", func() {}, []uint16{}},
+		{"no change", func() {}, []uint16{}},
+		{"append", func() { sel.add(3, 102); sel.add(4, 103) }, []uint16{3, 4}},
+		{"append in the same second", func() { sel.add(5, 103) }, []uint16{5}},
+		{"delete and append in the same second", func() {
+			// the number of entries and the addition timestamp are unchanged
+			sel.records = sel.records[1:]
+			sel.add(6, 103)
+		}, []uint16{6}},
+		{"clear", func() { sel.clear(104) }, []uint16{}},
+		{"append after clear with reused id", func() { sel.add(1, 105) }, []uint16{1}},
+		{"wrap around", func() {
+			// the oldest record (the cursor record) is overwritten
+			sel.records = nil
+			sel.add(2, 106)
+			sel.add(1, 107)
+		}, []uint16{2, 1}},
+		{"wrap around in the same second", func() {
+			sel.records = nil
+			sel.add(2, 107)
+			sel.add(3, 108)
+		}, []uint16{2, 3}},
+		{"clear and append with lower id and earlier timestamp", func() {
+			// SEL Time was set backwards
+			sel.clear(109)
+			sel.add(1, 50)
+			sel.add(2, 50)
+		}, []uint16{1, 2}},
+		{"clear", func() { sel.clear(110) }, []uint16{}},
+		{"append after clear with earlier timestamp", func() { sel.add(1, 40) }, []uint16{1}},
+	}
+
+	for _, test := range tests {
+		test.change()
+		if got := poll(); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("test %s records not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+	}
+
+	cursor, _ := store.Load()
+	if cursor.RecordID != 1 || cursor.Timestamp.Unix() != 40 {
+		t.Errorf("test cursor not saved, got: %+v", cursor)
+	}
+
+	// resume from the saved cursor
+	sel.add(4, 109)
+	f = newSELFollower(sel, time.Second, store)
+	if got := poll(); !reflect.DeepEqual(got, []uint16{4}) {
+		t.Errorf("test resume records not matched, got: %v", got)
+	}
+}
+
+// pollSELFollower polls once and returns the IDs of the delivered records, each record is acked.
+func pollSELFollower(t *testing.T, f *SELFollower) []uint16 {
+	done := make(chan error)
+	go func() {
+		done <- f.poll(context.Background())
+	}()
+	ids := make([]uint16, 0)
+	for {
+		select {
+		case record := <-f.records:
+			ids = append(ids, record.RecordID)
+			f.Ack(record)
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("poll failed, err: %s", err)
+			}
+			return ids
+		}
+	}
+}
+
+func Test_SELFollower_StartFromLast(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		expected []uint16
+	}{
+		{"last 2", 2, []uint16{3, 4}},
+		{"last 0", 0, []uint16{}},
+		{"more than entries", 10, []uint16{1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		sel := &fakeSEL{}
+		// the BMC reports an erase timestamp, as parseTimestamp never returns the zero time
+		sel.eraseTime = time.Unix(50, 0)
+		for i := 1; i <= 4; i++ {
+			sel.add(uint16(i), int64(100+i))
+		}
+
+		f := newSELFollower(sel, time.Second, nil).WithStartFromLast(test.n)
+		if got := pollSELFollower(t, f); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("test %s records not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+		if got := pollSELFollower(t, f); len(got) != 0 {
+			t.Errorf("test %s second poll should deliver nothing, got: %v", test.name, got)
+		}
+		sel.add(5, 110)
+		if got := pollSELFollower(t, f); !reflect.DeepEqual(got, []uint16{5}) {
+			t.Errorf("test %s appended records not matched, got: %v", test.name, got)
+		}
+	}
+}
+
+func Test_SELFollower_CursorWithoutSELInfo(t *testing.T) {
+	sel := &fakeSEL{}
+	sel.eraseTime = time.Unix(50, 0)
+	for i := 1; i <= 4; i++ {
+		sel.add(uint16(i), int64(100+i))
+	}
+
+	// a cursor saved with the record only, the zero erase timestamp is not taken as an erasure
+	store := &MemorySELCursorStore{}
+	_ = store.Save(&SELCursor{RecordID: 3, Timestamp: time.Unix(103, 0)})
+	f := newSELFollower(sel, time.Second, store)
+	if got := pollSELFollower(t, f); !reflect.DeepEqual(got, []uint16{4}) {
+		t.Errorf("test records not matched, got: %v, expected: [4]", got)
+	}
+
+	// the erasure is still detected once the cursor records the erase timestamp
+	sel.clear(120)
+	sel.add(1, 121)
+	if got := pollSELFollower(t, f); !reflect.DeepEqual(got, []uint16{1}) {
+		t.Errorf("test records after erase not matched, got: %v, expected: [1]", got)
+	}
+}
+
+func Test_SELFollower_Ack(t *testing.T) {
+	sel := &fakeSEL{}
+	sel.add(1, 100)
+
+	store := &MemorySELCursorStore{}
+	f := newSELFollower(sel, time.Second, store).WithStartFromBeginning(true)
+
+	// the record is delivered but not acked before the follower stops
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f.Run(ctx)
+	}()
+	record := <-f.Records()
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatalf("test run failed, err: %s", err)
+	}
+	// ack after the follower stopped does not block
+	f.Ack(record)
+
+	cursor, _ := store.Load()
+	if cursor.RecordID != 0 {
+		t.Errorf("test cursor moved without ack, got: %+v", cursor)
+	}
+
+	// the record is delivered again after restart
+	f = newSELFollower(sel, time.Second, store)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		errCh <- f.Run(ctx)
+	}()
+	record = <-f.Records()
+	if record.RecordID != 1 {
+		t.Errorf("test redelivered record not matched, got: %#04x", record.RecordID)
+	}
+	f.Ack(record)
+	cancel()
+	for range f.Records() {
+	}
+	<-errCh
+
+	cursor, _ = store.Load()
+	if cursor.RecordID != 1 {
+		t.Errorf("test cursor not saved on ack, got: %+v", cursor)
+	}
+}
+
+func Test_FileSELCursorStore(t *testing.T) {
+	store := NewFileSELCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+
+	cursor, err := store.Load()
+	if err != nil || cursor != nil {
+		t.Fatalf("test load absent cursor expected nil, got: %v, err: %v", cursor, err)
+	}
+
+	expected := &SELCursor{
+		RecordID:     0x10,
+		Timestamp:    time.Unix(100, 0),
+		AdditionTime: time.Unix(100, 0),
+		EraseTime:    time.Unix(50, 0),
+		AuxLogTimes:  map[AuxLogType]time.Time{AuxLogTypeMCA: time.Unix(80, 0)},
+	}
+	if err := store.Save(expected); err != nil {
+		t.Fatalf("test save cursor failed, err: %s", err)
+	}
+
+	cursor, err = store.Load()
+	if err != nil {
+		t.Fatalf("test load cursor failed, err: %s", err)
+	}
+	if cursor.RecordID != expected.RecordID || !cursor.Timestamp.Equal(expected.Timestamp) ||
+		!cursor.AdditionTime.Equal(expected.AdditionTime) || !cursor.EraseTime.Equal(expected.EraseTime) ||
+		!cursor.AuxLogTimes[AuxLogTypeMCA].Equal(expected.AuxLogTimes[AuxLogTypeMCA]) {
+		t.Errorf("test cursor not matched, got: %+v, expected: %+v", cursor, expected)
+	}
+}
+
+// fakeAuxLogSEL is a SEL with the MCA log, the OEM logs are not supported.
+type fakeAuxLogSEL struct {
+	*fakeSEL
+	mcaTime    time.Time
+	mcaEntries uint32
+	// mcaErr fails GetAuxLogStatus of the MCA log
+	mcaErr error
+}
+
+func (s *fakeAuxLogSEL) GetAuxLogStatus(logType AuxLogType) (*GetAuxLogStatusResponse, error) {
+	if logType == AuxLogTypeMCA && s.mcaErr != nil {
+		return nil, s.mcaErr
+	}
+	if logType != AuxLogTypeMCA {
+		return nil, &ResponseError{completionCode: CompletionCodeParameterOutOfRange}
+	}
+	return &GetAuxLogStatusResponse{LogType: logType, Timestamp: s.mcaTime, MCAEntries: s.mcaEntries}, nil
+}
+
+func Test_SELFollower_AuxLogs(t *testing.T) {
+	sel := &fakeAuxLogSEL{fakeSEL: &fakeSEL{}, mcaTime: time.Unix(100, 0), mcaEntries: 1}
+	sel.add(1, 100)
+
+	store := &MemorySELCursorStore{}
+	updates := make([]uint32, 0)
+	f := newSELFollower(sel, time.Second, store).WithAuxLogHandler(func(status *GetAuxLogStatusResponse) {
+		updates = append(updates, status.MCAEntries)
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		change   func()
+		expected []uint32
+	}{
+		{"first status is recorded", func() {}, []uint32{}},
+		{"no change", func() {}, []uint32{}},
+		{"MCA log updated", func() { sel.mcaTime = time.Unix(200, 0); sel.mcaEntries = 3 }, []uint32{3}},
+		{"MCA log cleared", func() { sel.mcaTime = time.Time{}; sel.mcaEntries = 0 }, []uint32{0}},
+	}
+
+	for _, test := range tests {
+		test.change()
+		updates = updates[:0]
+		if err := f.poll(ctx); err != nil {
+			t.Fatalf("test %s poll failed, err: %s", test.name, err)
+		}
+		if !reflect.DeepEqual(updates, test.expected) {
+			t.Errorf("test %s updates not matched, got: %v, expected: %v", test.name, updates, test.expected)
+		}
+	}
+
+	if !f.auxLogUnsupported[AuxLogTypeOEM1] || !f.auxLogUnsupported[AuxLogTypeOEM2] || f.auxLogUnsupported[AuxLogTypeMCA] {
+		t.Errorf("test unsupported aux logs not matched, got: %v", f.auxLogUnsupported)
+	}
+
+	// resume from the saved cursor, the update while stopped is reported
+	sel.mcaTime = time.Unix(300, 0)
+	sel.mcaEntries = 5
+	updates = updates[:0]
+	f = newSELFollower(sel, time.Second, store).WithAuxLogHandler(func(status *GetAuxLogStatusResponse) {
+		updates = append(updates, status.MCAEntries)
+	})
+	if err := f.poll(ctx); err != nil {
+		t.Fatalf("test resume poll failed, err: %s", err)
+	}
+	if !reflect.DeepEqual(updates, []uint32{5}) {
+		t.Errorf("test resume updates not matched, got: %v", updates)
+	}
+
+	cursor, _ := store.Load()
+	if len(cursor.AuxLogTimes) != 1 || cursor.AuxLogTimes[AuxLogTypeMCA].Unix() != 300 {
+		t.Errorf("test cursor aux log times not matched, got: %v", cursor.AuxLogTimes)
+	}
+}
+
+func Test_SELFollower_AuxLogsAfterSELFailed(t *testing.T) {
+	sel := &fakeAuxLogSEL{fakeSEL: &fakeSEL{}, mcaTime: time.Unix(100, 0), mcaEntries: 1}
+	sel.add(1, 100)
+
+	updates := make([]uint32, 0)
+	f := newSELFollower(sel, time.Second, &MemorySELCursorStore{}).WithAuxLogHandler(func(status *GetAuxLogStatusResponse) {
+		updates = append(updates, status.MCAEntries)
+	})
+	ctx := context.Background()
+	if err := f.poll(ctx); err != nil {
+		t.Fatalf("test first poll failed, err: %s", err)
+	}
+
+	// the aux logs are still reported when the SEL poll failed
+	sel.infoErr = errors.New("sel timeout")
+	sel.mcaTime, sel.mcaEntries = time.Unix(200, 0), 3
+	err := f.poll(ctx)
+	if err == nil || !strings.Contains(err.Error(), "sel timeout") {
+		t.Errorf("test SEL poll failed expected error, got: %v", err)
+	}
+	if !reflect.DeepEqual(updates, []uint32{3}) {
+		t.Errorf("test updates not matched, got: %v, expected: [3]", updates)
+	}
+
+	// both errors are returned
+	sel.mcaErr = errors.New("mca timeout")
+	err = f.poll(ctx)
+	if err == nil || !strings.Contains(err.Error(), "sel timeout") || !strings.Contains(err.Error(), "mca timeout") {
+		t.Errorf("test both poll failed expected both errors, got: %v", err)
+	}
+}
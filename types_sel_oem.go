@@ -0,0 +1,167 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SELOEMDecoder decodes the OEM information of SEL records for a manufacturer.
+//
+// For OEM timestamped and non-timestamped records, the description is the full description of the record.
+// For standard records, the description is appended to the event description,
+// like the DIMM location carried in the OEM codes of Event Data 2 and Event Data 3.
+//
+// The ok is false if the record is not decoded by the decoder.
+type SELOEMDecoder interface {
+	DecodeSEL(sel *SEL) (description string, ok bool)
+}
+
+// SELOEMDeviceDecoder is implemented by the decoders depending on the BMC, like the IPMI version,
+// GetSELOEMDecoder uses the decoder returned by ForDevice for the Get Device ID response of the BMC.
+type SELOEMDeviceDecoder interface {
+	ForDevice(res *GetDeviceIDResponse) SELOEMDecoder
+}
+
+// SELOEMDecoderFunc is an adapter to use the function as SELOEMDecoder.
+type SELOEMDecoderFunc func(sel *SEL) (description string, ok bool)
+
+func (f SELOEMDecoderFunc) DecodeSEL(sel *SEL) (string, bool) {
+	return f(sel)
+}
+
+var (
+	selOEMDecodersMutex sync.RWMutex
+	selOEMDecoders      = map[OEM]SELOEMDecoder{}
+)
+
+// RegisterSELOEMDecoder registers the decoder for the manufacturer,
+// it replaces the decoder registered before for the same manufacturer.
+func RegisterSELOEMDecoder(oem OEM, decoder SELOEMDecoder) {
+	selOEMDecodersMutex.Lock()
+	defer selOEMDecodersMutex.Unlock()
+
+	selOEMDecoders[oem] = decoder
+}
+
+// LookupSELOEMDecoder returns the decoder registered for the manufacturer, or nil if not registered.
+func LookupSELOEMDecoder(oem OEM) SELOEMDecoder {
+	selOEMDecodersMutex.RLock()
+	defer selOEMDecodersMutex.RUnlock()
+
+	return selOEMDecoders[oem]
+}
+
+// DecodeOEMSEL decodes the OEM information of the SEL record by decoder.
+//
+// The OEM timestamped records carry the Manufacturer ID of their own,
+// they are decoded by the decoder registered for that manufacturer if there is one.
+// The kernel panic records logged by the Linux IPMI driver are decoded whatever the manufacturer is.
+// The decoder can be nil.
+func DecodeOEMSEL(sel *SEL, decoder SELOEMDecoder) (description string, ok bool) {
+	if desc, ok := linuxPanicDescription(sel); ok {
+		return desc, true
+	}
+
+	if sel.OEMTimestamped != nil {
+		if d := LookupSELOEMDecoder(OEM(sel.OEMTimestamped.ManufacturerID)); d != nil {
+			decoder = d
+		}
+	}
+
+	if decoder == nil {
+		return "", false
+	}
+	return decoder.DecodeSEL(sel)
+}
+
+// WithSELOEMDecoder sets the OEM SEL decoder, instead of the one selected by the Manufacturer ID of the BMC.
+func (c *Client) WithSELOEMDecoder(decoder SELOEMDecoder) *Client {
+	c.selOEMDecoder = decoder
+	return c
+}
+
+// GetSELOEMDecoder returns the OEM SEL decoder registered for the manufacturer of the BMC,
+// the manufacturer is got by the Get Device ID command. The decoder is nil if there's no decoder
+// registered for the manufacturer. If the decoder is a SELOEMDeviceDecoder, the decoder for the BMC is returned.
+func (c *Client) GetSELOEMDecoder() (SELOEMDecoder, error) {
+	if c.selOEMDecoder != nil || c.selOEMDecoderLoaded {
+		return c.selOEMDecoder, nil
+	}
+
+	res, err := c.GetDeviceID()
+	if err != nil {
+		return nil, fmt.Errorf("GetDeviceID failed, err: %s", err)
+	}
+
+	decoder := LookupSELOEMDecoder(OEM(res.ManufacturerID))
+	if deviceDecoder, ok := decoder.(SELOEMDeviceDecoder); ok {
+		decoder = deviceDecoder.ForDevice(res)
+	}
+	c.selOEMDecoder = decoder
+	c.selOEMDecoderLoaded = true
+	return c.selOEMDecoder, nil
+}
+
+// pciLocation returns the PCI location carried in Event Data 2 and Event Data 3 of bus error events,
+// Event Data 2 is the bus number, Event Data 3 [7:3] is the device number and [2:0] is the function number.
+func pciLocation(ed2 uint8, ed3 uint8) string {
+	return fmt.Sprintf("Bus %02x Device %02x Function %x", ed2, ed3>>3, ed3&0x07)
+}
+
+// selRecordTypeLinuxPanic is the OEM non-timestamped record type used by the Linux IPMI driver to log
+// the kernel panic string (send_panic_events in drivers/char/ipmi/ipmi_msghandler.c), ipmitool decodes it too.
+const selRecordTypeLinuxPanic SELRecordType = 0xf0
+
+// linuxPanicDescription decodes the kernel panic string record of the Linux IPMI driver, like
+// "Linux kernel panic #0: Kernel panic".
+//
+// The panic string is split into records of 11 characters:
+//
+//	OEM byte 0      - the slave address of the interface which logged the record
+//	OEM byte 1      - the sequence number of the record in the panic string, from 0
+//	OEM bytes 2-12  - the characters of the panic string, padded by NUL
+//
+// The record is not decoded if the characters are not printable ASCII, as other software may use the record type.
+func linuxPanicDescription(sel *SEL) (string, bool) {
+	if sel.OEMNonTimestamped == nil || sel.RecordType != selRecordTypeLinuxPanic {
+		return "", false
+	}
+
+	oem := sel.OEMNonTimestamped.OEM
+	text := strings.TrimRight(string(oem[2:]), "\x00")
+	if text == "" {
+		return "", false
+	}
+	for _, c := range []byte(text) {
+		if c < 0x20 || c > 0x7e {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("Linux kernel panic #%d: %s", oem[1], text), true
+}
+
+// oemRecordDescription describes the OEM timestamped record of the manufacturers, or the OEM non-timestamped
+// record logged by the BMC of the manufacturer, by the vendor name and the record type.
+//
+// The layouts of the OEM defined bytes of the Dell and Supermicro OEM records are not publicly documented,
+// and no captured records are available to verify one, so the bytes are not interpreted,
+// they are printed in hex by FormatSELs.
+func oemRecordDescription(sel *SEL, vendor string, oems ...OEM) (string, bool) {
+	switch {
+	case sel.OEMTimestamped != nil:
+		var found bool
+		for _, oem := range oems {
+			if OEM(sel.OEMTimestamped.ManufacturerID) == oem {
+				found = true
+			}
+		}
+		if !found {
+			return "", false
+		}
+		return fmt.Sprintf("%s OEM record (type %#02x)", vendor, uint8(sel.RecordType)), true
+	case sel.OEMNonTimestamped != nil:
+		return fmt.Sprintf("%s OEM record (type %#02x, non-timestamped)", vendor, uint8(sel.RecordType)), true
+	}
+	return "", false
+}
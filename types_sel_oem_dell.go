@@ -0,0 +1,106 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterSELOEMDecoder(OEM_DELL, &DellSELDecoder{})
+}
+
+// DellSELDecoder decodes the OEM codes carried in Event Data 2 and Event Data 3 of the events logged
+// by Dell BMCs like ipmitool (get_dell_evt_desc in lib/ipmi_sel.c), and names the Dell OEM records.
+//
+// The memory events carry the location of the DIMMs:
+//
+//	Event Data 2 [7:4] - memory card (0h-7h), or the DIMMs per node (8h-Eh), Fh if unspecified
+//	Event Data 2 [3:0] - the bank of IPMI 1.5 BMCs, or the group of 8 DIMMs of Event Data 3, Fh if unspecified
+//	Event Data 3       - the DIMM letter of IPMI 1.5 BMCs, or the bitmap of the DIMMs in the group
+type DellSELDecoder struct {
+	// IPMIv15 is set for the BMCs of IPMI 1.5, it is set by the IPMI version of Get Device ID.
+	IPMIv15 bool
+}
+
+// ForDevice returns the decoder for the IPMI version of the BMC.
+func (d *DellSELDecoder) ForDevice(res *GetDeviceIDResponse) SELOEMDecoder {
+	return &DellSELDecoder{IPMIv15: res.MajorIPMIVersion == 1 && res.MinorIPMIVersion == 5}
+}
+
+func (d *DellSELDecoder) DecodeSEL(sel *SEL) (string, bool) {
+	if sel.Standard == nil {
+		return oemRecordDescription(sel, "Dell", OEM_DELL)
+	}
+
+	s := sel.Standard
+	switch s.EventReadingType {
+	case EventReadingTypeSensorSpecific, 0x70, 0x71, 0x7e, 0x7f:
+	default:
+		return "", false
+	}
+
+	ed := s.EventData
+	switch s.SensorType {
+	case SensorTypeCriticalInterrupt:
+		// PCI PERR, PCI SERR and the bus errors
+		if ed.ED2Usage() != EventDataUsageOEM || ed.ED3Usage() != EventDataUsageOEM {
+			return "", false
+		}
+		switch ed.EventReadingOffset() {
+		case 0x04, 0x05, 0x07, 0x08, 0x0a, 0x0b:
+			return pciLocation(ed.EventData2, ed.EventData3), true
+		}
+
+	case SensorTypeMemory, SensorTypeEventLoggingDisabled:
+		desc := d.dimmLocation(ed)
+		return desc, desc != ""
+	}
+
+	return "", false
+}
+
+// dimmLocation returns the memory card, bank and DIMMs of the memory event, like "Card A DIMM1,DIMM3".
+func (d *DellSELDecoder) dimmLocation(ed EventData) string {
+	parts := []string{}
+
+	card := ed.EventData2 >> 4
+	var group uint8
+	if ed.EventData1&0x80 != 0 {
+		if card != 0x0f && card < 0x08 {
+			parts = append(parts, fmt.Sprintf("Card %c", 'A'+card))
+		}
+		if bank := ed.EventData2 & 0x0f; bank != 0x0f {
+			if d.IPMIv15 {
+				parts = append(parts, fmt.Sprintf("Bank %d", bank+1))
+			} else {
+				group = bank
+			}
+		}
+	}
+
+	if ed.EventData1&0x20 != 0 {
+		if d.IPMIv15 {
+			parts = append(parts, fmt.Sprintf("DIMM %c", 'A'+ed.EventData3))
+			return strings.Join(parts, " ")
+		}
+
+		dimmsPerNode := map[uint8]int{0x08: 4, 0x09: 6, 0x0a: 8, 0x0b: 9, 0x0c: 12, 0x0d: 24, 0x0e: 3}[card]
+		dimms := []string{}
+		for i := 0; i < 8; i++ {
+			if ed.EventData3&(1<<i) == 0 {
+				continue
+			}
+			n := int(group)*8 + i
+			if dimmsPerNode > 0 {
+				dimms = append(dimms, fmt.Sprintf("DIMM%c%d", 'A'+n/dimmsPerNode, n%dimmsPerNode+1))
+			} else {
+				dimms = append(dimms, fmt.Sprintf("DIMM%d", n+1))
+			}
+		}
+		if len(dimms) > 0 {
+			parts = append(parts, strings.Join(dimms, ","))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,105 @@
+package ipmi
+
+import "fmt"
+
+func init() {
+	RegisterSELOEMDecoder(OEM_SUPERMICRO, &SupermicroSELDecoder{Chipset: SupermicroChipsetRomley})
+	RegisterSELOEMDecoder(OEM_SUPERMICRO_47488, &SupermicroSELDecoder{Chipset: SupermicroChipsetRomley})
+}
+
+// SupermicroChipset is the chipset generation of Supermicro boards, the DIMM location of memory events
+// is encoded by the chipset.
+type SupermicroChipset uint8
+
+const (
+	SupermicroChipsetX8 SupermicroChipset = iota
+	SupermicroChipsetRomley
+	SupermicroChipsetX9
+	SupermicroChipsetBrickland
+	// X10QRH and X10QBL boards
+	SupermicroChipsetX10QRH
+	SupermicroChipsetX10OBi
+)
+
+// supermicroSensorTypeBMCStatus is the OEM sensor type of the BMC reset events of Supermicro BMCs.
+const supermicroSensorTypeBMCStatus SensorType = 0xd0
+
+// SupermicroSELDecoder decodes the OEM codes carried in Event Data 2 and Event Data 3 of the events logged
+// by Supermicro BMCs like ipmitool (get_supermicro_evt_desc in lib/ipmi_sel.c), and names the Supermicro OEM records.
+//
+// ipmitool selects the chipset by the board ID of the BMC, the boards not known to it are decoded as Romley,
+// which is the chipset of the registered decoders. Set the decoder of the chipset of the board by
+// Client.WithSELOEMDecoder for the other boards.
+type SupermicroSELDecoder struct {
+	Chipset SupermicroChipset
+}
+
+func (d *SupermicroSELDecoder) DecodeSEL(sel *SEL) (string, bool) {
+	if sel.Standard == nil {
+		return oemRecordDescription(sel, "Supermicro", OEM_SUPERMICRO, OEM_SUPERMICRO_47488)
+	}
+
+	s := sel.Standard
+	if s.EventReadingType != EventReadingTypeSensorSpecific {
+		return "", false
+	}
+
+	ed := s.EventData
+	switch s.SensorType {
+	case SensorTypeMemory:
+		// the DIMM location is only carried if Event Data 1 marks Event Data 2 and 3 as OEM codes
+		if ed.ED2Usage() != EventDataUsageOEM || ed.ED3Usage() != EventDataUsageOEM {
+			return "", false
+		}
+		desc := d.dimmLocation(ed.EventData2, ed.EventData3)
+		return desc, desc != ""
+
+	case supermicroSensorTypeBMCStatus:
+		if ed.EventData1 != 0x80 || ed.EventData3 != 0xff {
+			return "", false
+		}
+		switch ed.EventData2 {
+		case 0x00:
+			return "BMC unexpected reset", true
+		case 0x01:
+			return "BMC cold reset", true
+		case 0x02:
+			return "BMC warm reset", true
+		}
+	}
+
+	return "", false
+}
+
+// dimmLocation returns the DIMM and the processor of the memory event, like "DIMMA1(CPU1)".
+//
+// Event Data 1 [7:4] is 1010b, Event Data 2 [7:4] is the channel (1h for A), Event Data 2 [3:0] is the DIMM
+// in the channel (Ah for 1),
+// and Event Data 3 is the processor (0h for CPU1), the channels of the Romley and X9 chipsets are counted
+// across the processors.
+func (d *SupermicroSELDecoder) dimmLocation(ed2 uint8, ed3 uint8) string {
+	channel := ed2 >> 4
+	dimm := ed2 & 0x0f
+
+	switch d.Chipset {
+	case SupermicroChipsetX8:
+		return fmt.Sprintf("DIMM%2X(CPU%x)", ed2, ed3&0x03+1)
+	case SupermicroChipsetRomley:
+		return fmt.Sprintf("DIMM%c%c(CPU%x)", '@'+channel+ed3&0x03*4, '\''+dimm, ed3&0x03+1)
+	case SupermicroChipsetX9:
+		return fmt.Sprintf("DIMM%c%c(CPU%x)", '@'+channel+ed3&0x03*3, '\''+dimm, ed3&0x03+1)
+	case SupermicroChipsetBrickland:
+		// the channels 5h-8h are of the second memory controller
+		memoryController := 1
+		if channel > 4 {
+			channel -= 4
+			memoryController = 2
+		}
+		return fmt.Sprintf("DIMM%c%d(P%dM%d)", '@'+channel, int(dimm)-9, ed3&0x0f+1, memoryController)
+	case SupermicroChipsetX10QRH:
+		return fmt.Sprintf("DIMM%c%c(CPU%x)", '@'+channel, '\''+dimm, ed3&0x03+1)
+	case SupermicroChipsetX10OBi:
+		return fmt.Sprintf("DIMM%c%c(CPU%x)", '@'+channel, '\''+dimm, ed3&0x07+1)
+	}
+	return ""
+}
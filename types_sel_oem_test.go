@@ -0,0 +1,250 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+// All the SEL records of the tests are synthetic, none is captured from a real BMC.
+// They are built by the field layouts of the decoders of ipmitool (get_dell_evt_desc and get_supermicro_evt_desc
+// in lib/ipmi_sel.c) and the Linux IPMI driver (send_panic_events), and the expected descriptions are worked out
+// from the output formats of ipmitool, without the "@" and " | " separators.
+func Test_DecodeOEMSEL(t *testing.T) {
+	tests := []struct {
+		name     string
+		decoder  SELOEMDecoder
+		msg      []byte
+		ok       bool
+		expected string
+	}{
+		{
+			name:    "dell system board dimms",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Memory, Correctable ECC, DIMM bitmap of group 0
+			msg:      []byte{0x01, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x01, 0x6f, 0xa0, 0xf0, 0x05},
+			ok:       true,
+			expected: "DIMM1,DIMM3",
+		},
+		{
+			name:    "dell dimms per node",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Memory, Uncorrectable ECC, 12 DIMMs per node, the 5th DIMM of group 1
+			msg:      []byte{0x02, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x01, 0x6f, 0xa1, 0xc1, 0x10},
+			ok:       true,
+			expected: "DIMMB1",
+		},
+		{
+			name:    "dell memory card",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Memory, Correctable ECC, memory card B
+			msg:      []byte{0x03, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x01, 0x6f, 0xa0, 0x1f, 0x04},
+			ok:       true,
+			expected: "Card B DIMM3",
+		},
+		{
+			name:    "dell ipmi 1.5",
+			decoder: &DellSELDecoder{IPMIv15: true},
+			// Memory, Correctable ECC, card C, bank 2, DIMM C
+			msg:      []byte{0x04, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x01, 0x6f, 0xa0, 0x21, 0x02},
+			ok:       true,
+			expected: "Card C Bank 2 DIMM C",
+		},
+		{
+			name:    "dell pci serr",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Critical Interrupt, PCI SERR
+			msg:      []byte{0x05, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x13, 0x05, 0x6f, 0xa5, 0x3b, 0x08},
+			ok:       true,
+			expected: "Bus 3b Device 01 Function 0",
+		},
+		{
+			name:    "dell no oem codes",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Memory, Correctable ECC, event data 2 and 3 unspecified
+			msg: []byte{0x06, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x01, 0x6f, 0x00, 0xff, 0xff},
+			ok:  false,
+		},
+		{
+			name:    "supermicro romley",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// Memory, Correctable ECC, channel 1, DIMM Ah, CPU 0
+			msg:      []byte{0x07, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa0, 0x1a, 0x00},
+			ok:       true,
+			expected: "DIMMA1(CPU1)",
+		},
+		{
+			name:    "supermicro 47488 romley second cpu",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO_47488),
+			// Memory, Uncorrectable ECC, channel 2, DIMM Bh, CPU 1
+			msg:      []byte{0x08, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa1, 0x2b, 0x01},
+			ok:       true,
+			expected: "DIMMF2(CPU2)",
+		},
+		{
+			name:     "supermicro x9",
+			decoder:  &SupermicroSELDecoder{Chipset: SupermicroChipsetX9},
+			msg:      []byte{0x09, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa1, 0x2b, 0x01},
+			ok:       true,
+			expected: "DIMME2(CPU2)",
+		},
+		{
+			name:     "supermicro x10qrh",
+			decoder:  &SupermicroSELDecoder{Chipset: SupermicroChipsetX10QRH},
+			msg:      []byte{0x0a, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa1, 0x2b, 0x01},
+			ok:       true,
+			expected: "DIMMB2(CPU2)",
+		},
+		{
+			name:     "supermicro x8",
+			decoder:  &SupermicroSELDecoder{Chipset: SupermicroChipsetX8},
+			msg:      []byte{0x0b, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa0, 0x1a, 0x01},
+			ok:       true,
+			expected: "DIMM1A(CPU2)",
+		},
+		{
+			name:    "supermicro brickland",
+			decoder: &SupermicroSELDecoder{Chipset: SupermicroChipsetBrickland},
+			// channel 6 is channel B of the second memory controller
+			msg:      []byte{0x0c, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0xa0, 0x6b, 0x02},
+			ok:       true,
+			expected: "DIMMB2(P3M2)",
+		},
+		{
+			name:    "supermicro memory event without oem codes",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// Memory, Correctable ECC, Event Data 1 marks Event Data 2 and 3 unspecified
+			msg: []byte{0x13, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x0c, 0x08, 0x6f, 0x00, 0x1a, 0x00},
+			ok:  false,
+		},
+		{
+			name:    "supermicro bmc cold reset",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// OEM sensor type D0h
+			msg:      []byte{0x0d, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0xd0, 0xff, 0x6f, 0x80, 0x01, 0xff},
+			ok:       true,
+			expected: "BMC cold reset",
+		},
+		{
+			name:    "supermicro threshold event",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// Temperature, Upper Critical going high
+			msg: []byte{0x0e, 0x00, 0x02, 0x00, 0xe1, 0xf5, 0x65, 0x20, 0x00, 0x04, 0x01, 0x01, 0x01, 0x59, 0x5f, 0x5a},
+			ok:  false,
+		},
+		{
+			name:    "dell timestamped oem record",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// Manufacturer ID 674
+			msg:      []byte{0x0f, 0x00, 0xc1, 0x00, 0xe1, 0xf5, 0x65, 0xa2, 0x02, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			ok:       true,
+			expected: "Dell OEM record (type 0xc1)",
+		},
+		{
+			name:     "dell non-timestamped oem record",
+			decoder:  LookupSELOEMDecoder(OEM_DELL),
+			msg:      []byte{0x10, 0x00, 0xe0, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d},
+			ok:       true,
+			expected: "Dell OEM record (type 0xe0, non-timestamped)",
+		},
+		{
+			name:    "supermicro timestamped oem record",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// Manufacturer ID 47488
+			msg:      []byte{0x11, 0x00, 0xd0, 0x00, 0xe1, 0xf5, 0x65, 0x80, 0xb9, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			ok:       true,
+			expected: "Supermicro OEM record (type 0xd0)",
+		},
+		{
+			name:    "linux kernel panic",
+			decoder: LookupSELOEMDecoder(OEM_DELL),
+			// the second record of the panic string, logged by the interface of slave address 20h
+			msg:      append([]byte{0x14, 0x00, 0xf0, 0x20, 0x01}, "Fatal excep"...),
+			ok:       true,
+			expected: "Linux kernel panic #1: Fatal excep",
+		},
+		{
+			name:     "linux kernel panic padded",
+			decoder:  nil,
+			msg:      append([]byte{0x15, 0x00, 0xf0, 0x20, 0x02}, 't', 'i', 'o', 'n', 0, 0, 0, 0, 0, 0, 0),
+			ok:       true,
+			expected: "Linux kernel panic #2: tion",
+		},
+		{
+			name:     "oem record f0 not a panic string",
+			decoder:  LookupSELOEMDecoder(OEM_SUPERMICRO),
+			msg:      []byte{0x16, 0x00, 0xf0, 0x20, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b},
+			ok:       true,
+			expected: "Supermicro OEM record (type 0xf0, non-timestamped)",
+		},
+		{
+			name:    "timestamped oem record of other manufacturer",
+			decoder: LookupSELOEMDecoder(OEM_SUPERMICRO),
+			// Manufacturer ID 343 (Intel) has no decoder, the Supermicro decoder does not name the records of others
+			msg: []byte{0x12, 0x00, 0xc0, 0x00, 0xe1, 0xf5, 0x65, 0x57, 0x01, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			ok:  false,
+		},
+	}
+
+	for _, test := range tests {
+		sel, err := ParseSEL(test.msg)
+		if err != nil {
+			t.Errorf("test %s ParseSEL failed, err: %s", test.name, err)
+			continue
+		}
+
+		got, ok := DecodeOEMSEL(sel, test.decoder)
+		if ok != test.ok || got != test.expected {
+			t.Errorf("test %s not matched, got: %q (%v), expected: %q (%v)", test.name, got, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func Test_DellSELDecoder_ForDevice(t *testing.T) {
+	decoder := LookupSELOEMDecoder(OEM_DELL).(SELOEMDeviceDecoder)
+
+	for _, test := range []struct {
+		major, minor uint8
+		ipmiV15      bool
+	}{
+		{1, 5, true},
+		{2, 0, false},
+	} {
+		got := decoder.ForDevice(&GetDeviceIDResponse{MajorIPMIVersion: test.major, MinorIPMIVersion: test.minor})
+		if got.(*DellSELDecoder).IPMIv15 != test.ipmiV15 {
+			t.Errorf("test IPMI %d.%d not matched, expected IPMIv15: %v", test.major, test.minor, test.ipmiV15)
+		}
+	}
+}
+
+func Test_DecodeOEMSEL_Timestamped(t *testing.T) {
+	const oem OEM = 0x0fff00
+	RegisterSELOEMDecoder(oem, SELOEMDecoderFunc(func(sel *SEL) (string, bool) {
+		return "decoded by record manufacturer", true
+	}))
+	defer func() {
+		selOEMDecodersMutex.Lock()
+		delete(selOEMDecoders, oem)
+		selOEMDecodersMutex.Unlock()
+	}()
+
+	msg := []byte{0x01, 0x00, 0xc1, 0x00, 0xe1, 0xf5, 0x65, 0x00, 0xff, 0x0f, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	sel, err := ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+
+	// the timestamped OEM record is decoded by the decoder of its own manufacturer
+	got, ok := DecodeOEMSEL(sel, LookupSELOEMDecoder(OEM_DELL))
+	if !ok || got != "decoded by record manufacturer" {
+		t.Errorf("test timestamped OEM record not matched, got: %q (%v)", got, ok)
+	}
+
+	// the non-timestamped OEM record has no manufacturer
+	msg = []byte{0x02, 0x00, 0xe1, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c}
+	sel, err = ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+	if _, ok := DecodeOEMSEL(sel, nil); ok {
+		t.Errorf("test non-timestamped OEM record expected not decoded without decoder")
+	}
+}
@@ -0,0 +1,303 @@
+package ipmi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// selPreInitTimestampMax is the max timestamp relative to the BMC initialization,
+// the timestamps above it are absolute.
+//
+// see: 37.1 Timestamp Format
+const selPreInitTimestampMax uint32 = 0x20000000
+
+// SELQuery is the filters of SEL records used by QuerySEL, the empty filters match all records.
+//
+// The filters of sensors and events only match the standard records, and the time filters
+// don't match the non-timestamped OEM records or the records of unspecified timestamp (FFFFFFFFh).
+type SELQuery struct {
+	// Only the records logged in [Since, Until], the zero time is unbounded.
+	// SEL is read forward from the oldest record, so the walk stops at the first record logged after Until,
+	// if the timestamps read so far never went backwards. The walk is avoided if the SEL Info shows no records
+	// could be in the time range.
+	Since time.Time
+	Until time.Time
+	// FullWalk reads the whole SEL even if a record after Until is found, for the SELs whose timestamps
+	// may go backwards later, like after the SEL Time is set backwards.
+	FullWalk bool
+
+	// The event severities of the records, like EventSeverityCritical, case-insensitive.
+	Severities []EventSeverity
+	// The sensor types of the records.
+	SensorTypes []SensorType
+	// The shell patterns (see path.Match) of the sensor names, like "PSU*", case-insensitive.
+	// The sensor names are resolved by the SDRs, the records of the sensors without SDR don't match.
+	SensorNames []string
+	// The event directions, EventDirAssertion or EventDirDeassertion.
+	EventDirs []EventDir
+	// The record type ranges of the records, like SELRecordTypeRangeTimestampedOEM.
+	RecordTypeRanges []SELRecordTypeRange
+	// The record types in [MinRecordType, MaxRecordType] if MaxRecordType is not zero,
+	// like C0h-DFh for the timestamped OEM records.
+	MinRecordType SELRecordType
+	MaxRecordType SELRecordType
+
+	// The max number of records returned, the newest ones are kept, zero is unlimited.
+	Limit int
+}
+
+// Validate checks the severities, the patterns of the sensor names and the time range.
+func (q *SELQuery) Validate() error {
+	for _, severity := range q.Severities {
+		if !isKnownEventSeverity(severity) {
+			return fmt.Errorf("unknown event severity (%s), valid: %v", severity, eventSeverities)
+		}
+	}
+	for _, pattern := range q.SensorNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid sensor name pattern (%s), err: %s", pattern, err)
+		}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return fmt.Errorf("until (%s) is before since (%s)", q.Until, q.Since)
+	}
+	return nil
+}
+
+// Match reports whether the record matches all the filters of the query, the sdr of the sensor is optional.
+func (q *SELQuery) Match(sel *SEL, sdr *SDR) bool {
+	if len(q.RecordTypeRanges) > 0 {
+		var found bool
+		for _, r := range q.RecordTypeRanges {
+			if sel.RecordType.Range() == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.MaxRecordType != 0 && (sel.RecordType < q.MinRecordType || sel.RecordType > q.MaxRecordType) {
+		return false
+	}
+
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		ts := sel.Timestamp()
+		if ts.IsZero() || isUnspecifiedTimestamp(ts) {
+			return false
+		}
+		if !q.Since.IsZero() && ts.Before(q.Since) {
+			return false
+		}
+		if !q.Until.IsZero() && ts.After(q.Until) {
+			return false
+		}
+	}
+
+	if !q.hasStandardFilters() {
+		return true
+	}
+	s := sel.Standard
+	if s == nil {
+		return false
+	}
+
+	if len(q.Severities) > 0 {
+		severity := s.EventReadingType.EventSeverity(s.SensorType, s.SensorNumber, s.EventData, s.EventDir)
+		var found bool
+		for _, v := range q.Severities {
+			if strings.EqualFold(string(v), string(severity)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.SensorTypes) > 0 {
+		var found bool
+		for _, v := range q.SensorTypes {
+			if v == s.SensorType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.EventDirs) > 0 {
+		var found bool
+		for _, v := range q.EventDirs {
+			if v == s.EventDir {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.SensorNames) > 0 {
+		if sdr == nil {
+			return false
+		}
+		name := strings.ToLower(sdr.SensorName())
+		var found bool
+		for _, pattern := range q.SensorNames {
+			if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q *SELQuery) hasStandardFilters() bool {
+	return len(q.Severities) > 0 || len(q.SensorTypes) > 0 || len(q.SensorNames) > 0 || len(q.EventDirs) > 0
+}
+
+// skip reports whether no records could match the time filters by the SEL Info,
+// that is no records were added since Since, or SEL was erased after Until.
+func (q *SELQuery) skip(info *GetSELInfoResponse) bool {
+	if info.Entries == 0 {
+		return true
+	}
+	if !q.Since.IsZero() && isAbsoluteTimestamp(info.RecentAdditionTime) && info.RecentAdditionTime.Before(q.Since) {
+		return true
+	}
+	if !q.Until.IsZero() && isAbsoluteTimestamp(info.RecentEraseTime) && info.RecentEraseTime.After(q.Until) {
+		return true
+	}
+	return false
+}
+
+// isAbsoluteTimestamp reports whether t is an absolute time,
+// not relative to the BMC initialization and not unspecified.
+func isAbsoluteTimestamp(t time.Time) bool {
+	return t.Unix() > int64(selPreInitTimestampMax) && !isUnspecifiedTimestamp(t)
+}
+
+var eventSeverities = []EventSeverity{
+	EventSeverityInfo,
+	EventSeverityOK,
+	EventSeverityWarning,
+	EventSeverityCritical,
+	EventSeverityDegraded,
+	EventSeverityNonFatal,
+}
+
+// isKnownEventSeverity reports whether severity is one of the EventSeverity values, case-insensitive.
+func isKnownEventSeverity(severity EventSeverity) bool {
+	for _, v := range eventSeverities {
+		if strings.EqualFold(string(v), string(severity)) {
+			return true
+		}
+	}
+	return false
+}
+
+// QuerySEL returns the SEL records matched by the query, in the order of SEL.
+//
+// SEL can only be walked forward from the oldest record, the walk is avoided by the SEL Info timestamps
+// if no records could be logged in the time range, and stops early at the first record after the Until
+// of the query, see SELQuery. The SDRs are read only if the query has sensor name filters.
+func (c *Client) QuerySEL(query *SELQuery) ([]*SEL, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	var sdrsMap SDRMapBySensorNumber
+	if len(query.SensorNames) > 0 {
+		m, err := c.GetSDRsMap()
+		if err != nil {
+			return nil, fmt.Errorf("GetSDRsMap failed, err: %s", err)
+		}
+		sdrsMap = m
+	}
+
+	return querySEL(c, query, sdrsMap)
+}
+
+// QuerySELWithSDRs is like QuerySEL, but the sensor names are resolved by the given SDRs,
+// so the SDRs already read by the caller are not read again.
+func (c *Client) QuerySELWithSDRs(query *SELQuery, sdrsMap SDRMapBySensorNumber) ([]*SEL, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return querySEL(c, query, sdrsMap)
+}
+
+func querySEL(source selSource, query *SELQuery, sdrsMap SDRMapBySensorNumber) ([]*SEL, error) {
+	out := make([]*SEL, 0)
+
+	info, err := source.GetSELInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+	}
+	if query.skip(info) {
+		return out, nil
+	}
+
+	// the matched records are kept in a ring of Limit records, so the newest ones are returned
+	var next int
+	var wrapped bool
+
+	// the last absolute timestamp read, the walk only stops early if the timestamps never went backwards
+	var last time.Time
+	var monotonic = true
+
+	err = walkSEL(source, 0x0000, func(sel *SEL) bool {
+		var sdr *SDR
+		if sel.Standard != nil {
+			sdr = sdrsMap[sel.Standard.GeneratorID][sel.Standard.SensorNumber]
+		}
+		if query.Match(sel, sdr) {
+			if query.Limit <= 0 || len(out) < query.Limit {
+				out = append(out, sel)
+			} else {
+				out[next] = sel
+				wrapped = true
+			}
+			if query.Limit > 0 {
+				next = (next + 1) % query.Limit
+			}
+		}
+
+		ts := sel.Timestamp()
+		if ts.IsZero() || !isAbsoluteTimestamp(ts) {
+			return true
+		}
+		if ts.Before(last) {
+			monotonic = false
+		}
+		last = ts
+
+		stop := !query.Until.IsZero() && !query.FullWalk && monotonic && ts.After(query.Until)
+		return !stop
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !wrapped {
+		return out, nil
+	}
+	ordered := make([]*SEL, 0, len(out))
+	ordered = append(ordered, out[next:]...)
+	ordered = append(ordered, out[:next]...)
+	return ordered, nil
+}
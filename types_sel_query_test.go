@@ -0,0 +1,196 @@
+package ipmi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// countingSEL counts the GetSELEntry commands.
+type countingSEL struct {
+	*fakeSEL
+	reads int
+}
+
+func (s *countingSEL) GetSELEntry(reservationID uint16, recordID uint16) (*GetSELEntryResponse, error) {
+	s.reads++
+	return s.fakeSEL.GetSELEntry(reservationID, recordID)
+}
+
+func Test_querySEL(t *testing.T) {
+	const base int64 = 1700000000
+
+	sel := &fakeSEL{}
+	addStandard := func(recordID uint16, ts int64, sensorType SensorType, sensorNumber SensorNumber, ert EventReadingType, offset uint8, dir EventDir) {
+		sel.records = append(sel.records, &SEL{
+			RecordID:   recordID,
+			RecordType: 0x02,
+			Standard: &SELStandard{
+				Timestamp:        time.Unix(ts, 0),
+				GeneratorID:      GeneratorBMC,
+				EvMRev:           0x04,
+				SensorType:       sensorType,
+				SensorNumber:     sensorNumber,
+				EventDir:         dir,
+				EventReadingType: ert,
+				EventData:        EventData{EventData1: offset, EventData2: 0xff, EventData3: 0xff},
+			},
+		})
+		sel.additionTime = time.Unix(ts, 0)
+	}
+
+	// PSU1 failure, PSU2 presence, CPU Temp UCR going high and its deassertion, OEM records
+	addStandard(1, base, SensorTypePowserSupply, 0x51, EventReadingTypeSensorSpecific, 0x01, EventDirAssertion)
+	addStandard(2, base+3600, SensorTypePowserSupply, 0x52, EventReadingTypeSensorSpecific, 0x00, EventDirAssertion)
+	addStandard(3, base+7200, SensorTypeTemperature, 0x30, EventReadingTypeThreshold, 0x09, EventDirAssertion)
+	addStandard(4, base+7260, SensorTypeTemperature, 0x30, EventReadingTypeThreshold, 0x09, EventDirDeassertion)
+	sel.records = append(sel.records,
+		&SEL{RecordID: 5, RecordType: 0xc1, OEMTimestamped: &SELOEMTimestamped{Timestamp: time.Unix(base+7300, 0)}},
+		&SEL{RecordID: 6, RecordType: 0xe0, OEMNonTimestamped: &SELOEMNonTimestamped{}},
+	)
+
+	compact := func(number SensorNumber, name string) *SDR {
+		return &SDR{
+			RecordHeader: &SDRHeader{RecordType: SDRRecordTypeCompactSensor},
+			Compact:      &SDRCompact{GeneratorID: GeneratorBMC, SensorNumber: number, IDStringBytes: []byte(name)},
+		}
+	}
+	sdrsMap := SDRMapBySensorNumber{
+		GeneratorBMC: {
+			0x51: compact(0x51, "PSU1 Status"),
+			0x52: compact(0x52, "PSU2 Status"),
+			0x30: compact(0x30, "CPU Temp"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    *SELQuery
+		expected []uint16
+		reads    int
+	}{
+		{"all", &SELQuery{}, []uint16{1, 2, 3, 4, 5, 6}, 6},
+		{"since", &SELQuery{Since: time.Unix(base+3600, 0)}, []uint16{2, 3, 4, 5}, 6},
+		{"since after the newest record", &SELQuery{Since: time.Unix(base+8000, 0)}, []uint16{}, 0},
+		{"until stops early", &SELQuery{Until: time.Unix(base+3600, 0)}, []uint16{1, 2}, 3},
+		{"until full walk", &SELQuery{Until: time.Unix(base+3600, 0), FullWalk: true}, []uint16{1, 2}, 6},
+		{"severity", &SELQuery{Severities: []EventSeverity{"critical"}}, []uint16{1, 4}, 6},
+		{"sensor name", &SELQuery{SensorNames: []string{"psu*"}}, []uint16{1, 2}, 6},
+		{"sensor type and deassertion", &SELQuery{SensorTypes: []SensorType{SensorTypeTemperature}, EventDirs: []EventDir{EventDirDeassertion}}, []uint16{4}, 6},
+		{"OEM range", &SELQuery{RecordTypeRanges: []SELRecordTypeRange{SELRecordTypeRangeTimestampedOEM, SELRecordTypeRangeNonTimestampedOEM}}, []uint16{5, 6}, 6},
+		{"OEM record types", &SELQuery{MinRecordType: 0xc0, MaxRecordType: 0xdf}, []uint16{5}, 6},
+		{"limit keeps the newest", &SELQuery{SensorTypes: []SensorType{SensorTypeTemperature}, Limit: 1}, []uint16{4}, 6},
+		{"limit keeps the newest in order", &SELQuery{Limit: 4}, []uint16{3, 4, 5, 6}, 6},
+		{"limit not reached", &SELQuery{Limit: 10}, []uint16{1, 2, 3, 4, 5, 6}, 6},
+		{"limit and until", &SELQuery{Until: time.Unix(base+3600, 0), Limit: 1}, []uint16{2}, 3},
+	}
+
+	for _, test := range tests {
+		if err := test.query.Validate(); err != nil {
+			t.Fatalf("test %s Validate failed, err: %s", test.name, err)
+		}
+
+		source := &countingSEL{fakeSEL: sel}
+		records, err := querySEL(source, test.query, sdrsMap)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+
+		ids := make([]uint16, 0)
+		for _, record := range records {
+			ids = append(ids, record.RecordID)
+		}
+		if !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, ids, test.expected)
+		}
+		if source.reads != test.reads {
+			t.Errorf("test %s reads not matched, got: %d, expected: %d", test.name, source.reads, test.reads)
+		}
+	}
+
+	if err := (&SELQuery{SensorNames: []string{"PSU["}}).Validate(); err == nil {
+		t.Errorf("test invalid pattern should fail")
+	}
+	if err := (&SELQuery{Severities: []EventSeverity{"fatal"}}).Validate(); err == nil {
+		t.Errorf("test unknown severity should fail")
+	}
+	if err := (&SELQuery{Severities: []EventSeverity{"non-fatal", "OK"}}).Validate(); err != nil {
+		t.Errorf("test known severities failed, err: %s", err)
+	}
+}
+
+func Test_querySEL_timestamps(t *testing.T) {
+	const base int64 = 1700000000
+	unspecified := int64(timestampUnspecified)
+
+	tests := []struct {
+		name       string
+		timestamps []int64
+		eraseTime  int64
+		query      *SELQuery
+		expected   []uint16
+	}{
+		{
+			name:       "unspecified erase time is not after until",
+			timestamps: []int64{base, base + 60},
+			eraseTime:  unspecified,
+			query:      &SELQuery{Until: time.Unix(base+3600, 0)},
+			expected:   []uint16{1, 2},
+		},
+		{
+			name:       "unspecified record does not stop the walk",
+			timestamps: []int64{base, unspecified, base + 60},
+			query:      &SELQuery{Until: time.Unix(base+3600, 0)},
+			expected:   []uint16{1, 3},
+		},
+		{
+			name:       "unspecified record does not match since",
+			timestamps: []int64{base, unspecified, base + 60},
+			query:      &SELQuery{Since: time.Unix(base+30, 0)},
+			expected:   []uint16{3},
+		},
+		{
+			name:       "records after the clock stepped backwards are not read",
+			timestamps: []int64{base, base + 7200, base + 60},
+			query:      &SELQuery{Until: time.Unix(base+3600, 0)},
+			expected:   []uint16{1},
+		},
+		{
+			name:       "records after the clock stepped backwards by full walk",
+			timestamps: []int64{base, base + 7200, base + 60},
+			query:      &SELQuery{Until: time.Unix(base+3600, 0), FullWalk: true},
+			expected:   []uint16{1, 3},
+		},
+		{
+			name:       "no early stop once the clock stepped backwards",
+			timestamps: []int64{base + 60, base, base + 7200, base + 30},
+			query:      &SELQuery{Until: time.Unix(base+3600, 0)},
+			expected:   []uint16{1, 2, 4},
+		},
+	}
+
+	for _, test := range tests {
+		sel := &fakeSEL{}
+		for i, ts := range test.timestamps {
+			sel.add(uint16(i+1), ts)
+		}
+		if test.eraseTime != 0 {
+			sel.eraseTime = time.Unix(test.eraseTime, 0)
+		}
+
+		records, err := querySEL(sel, test.query, nil)
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+
+		ids := make([]uint16, 0)
+		for _, record := range records {
+			ids = append(ids, record.RecordID)
+		}
+		if !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, ids, test.expected)
+		}
+	}
+}
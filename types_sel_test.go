@@ -0,0 +1,26 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_parseSELOEMNonTimestamped(t *testing.T) {
+	msg := []byte{0x02, 0x00, 0xe1, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d}
+	sel, err := ParseSEL(msg)
+	if err != nil {
+		t.Fatalf("test ParseSEL failed, err: %s", err)
+	}
+
+	// all the 13 OEM bytes are parsed, not only the first 6
+	if got := sel.OEMNonTimestamped.OEM[:]; !bytes.Equal(got, msg[3:]) {
+		t.Errorf("test OEM bytes not matched, got: % x, expected: % x", got, msg[3:])
+	}
+	if got := sel.Pack(); !bytes.Equal(got, msg) {
+		t.Errorf("test pack not matched, got: % x, expected: % x", got, msg)
+	}
+
+	if _, err := ParseSEL(msg[:15]); err == nil {
+		t.Errorf("test short record expected error")
+	}
+}
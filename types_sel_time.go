@@ -0,0 +1,278 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultSELTimeTolerance is the default max drift of SEL Time allowed by SyncSELTime.
+	DefaultSELTimeTolerance time.Duration = 2 * time.Second
+
+	// SELTimeUTCOffsetUnspecified is the SEL Time UTC Offset value of unspecified.
+	SELTimeUTCOffsetUnspecified int16 = 0x07ff
+
+	// number of Get SEL Time samples to measure the drift, the sample of the shortest round-trip is used.
+	selTimeDriftSamples int = 3
+)
+
+// selTimeSource is the commands used by SyncSELTime, it is implemented by Client.
+type selTimeSource interface {
+	GetSELTime() (*GetSELTimeResponse, error)
+	SetSELTime(t time.Time) (*SetSELTimeResponse, error)
+	GetSELTimeUTCOffset() (*GetSELTimeUTCOffsetResponse, error)
+	SetSELTimeUTCOffset(minutesOffset int16) (*SetSELTimeUTCOffsetResponse, error)
+}
+
+// selClock is the local clock used to measure and sync the SEL Time, it is systemClock except in tests.
+type selClock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// SELTimeDrift is the offset of the SEL Time of the BMC relative to the local clock.
+//
+// The SEL Time is UTC plus the SEL Time UTC Offset, see GetSELTimeUTCOffsetResponse,
+// so the offset is subtracted from the SEL Time to compare with the local clock.
+type SELTimeDrift struct {
+	// The SEL Time read from the BMC, it has a resolution of one second.
+	SELTime time.Time `json:"sel_time"`
+	// The SEL Time UTC Offset in minutes applied, 0 if unspecified or not supported.
+	UTCOffset int16 `json:"utc_offset"`
+	// The local time when the SEL Time was read, that is the midpoint of the request round-trip.
+	LocalTime time.Time     `json:"local_time"`
+	RoundTrip time.Duration `json:"round_trip_ns"`
+	// SEL Time minus UTC Offset minus local time, positive means the BMC clock is ahead.
+	// The SEL Time is counted as the middle of its second, so the error is within RoundTrip/2 + 0.5s.
+	Drift time.Duration `json:"drift_ns"`
+}
+
+// GetSELTimeDrift measures the drift of the SEL Time relative to the local clock,
+// the request round-trip time and the SEL Time UTC Offset are corrected.
+func (c *Client) GetSELTimeDrift() (*SELTimeDrift, error) {
+	offset, err := getSELTimeUTCOffset(c)
+	if err != nil {
+		return nil, err
+	}
+	return getSELTimeDrift(c, systemClock{}, effectiveUTCOffset(offset))
+}
+
+// getSELTimeUTCOffset returns the SEL Time UTC Offset, or nil if the BMC does not support the UTC Offset commands.
+func getSELTimeUTCOffset(source selTimeSource) (*int16, error) {
+	res, err := source.GetSELTimeUTCOffset()
+	if err != nil {
+		// UTC Offset commands are optional
+		if isSELTimeUTCOffsetUnsupported(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetSELTimeUTCOffset failed, err: %s", err)
+	}
+	offset := res.MinutesOffset
+	return &offset, nil
+}
+
+// isSELTimeUTCOffsetUnsupported reports whether the error means the BMC does not support the UTC Offset commands.
+func isSELTimeUTCOffsetUnsupported(err error) bool {
+	resErr, ok := err.(*ResponseError)
+	if !ok {
+		return false
+	}
+	switch resErr.CompletionCode() {
+	case CompletionCodeInvalidCommand, CompletionCodeIllegalCommand, CompletionCodeCannotExecuteCommandNotSupported:
+		return true
+	}
+	return false
+}
+
+// effectiveUTCOffset returns the UTC offset in minutes applied to the SEL Time,
+// it is 0 if the offset is unspecified or not supported.
+func effectiveUTCOffset(offset *int16) int16 {
+	if offset == nil || *offset == SELTimeUTCOffsetUnspecified {
+		return 0
+	}
+	return *offset
+}
+
+func getSELTimeDrift(source selTimeSource, clock selClock, utcOffset int16) (*SELTimeDrift, error) {
+	var best *SELTimeDrift
+	for i := 0; i < selTimeDriftSamples; i++ {
+		start := clock.Now()
+		res, err := source.GetSELTime()
+		if err != nil {
+			return nil, fmt.Errorf("GetSELTime failed, err: %s", err)
+		}
+		roundTrip := clock.Now().Sub(start)
+
+		if best != nil && roundTrip >= best.RoundTrip {
+			continue
+		}
+		localTime := start.Add(roundTrip / 2)
+		utcTime := res.Time.Add(-time.Duration(utcOffset) * time.Minute)
+		best = &SELTimeDrift{
+			SELTime:   res.Time,
+			UTCOffset: utcOffset,
+			LocalTime: localTime,
+			RoundTrip: roundTrip,
+			Drift:     utcTime.Add(500 * time.Millisecond).Sub(localTime).Round(time.Millisecond),
+		}
+	}
+	return best, nil
+}
+
+// SELTimeSyncOptions is the options of SyncSELTime.
+type SELTimeSyncOptions struct {
+	// The SEL Time is set if the absolute drift exceeds the tolerance, DefaultSELTimeTolerance if zero.
+	// A negative tolerance always sets the SEL Time.
+	Tolerance time.Duration
+
+	// The expected SEL Time UTC Offset in minutes, the current UTC offset of the BMC is kept if nil.
+	// It is ignored if the BMC does not support the UTC Offset commands, the SEL Time is set to UTC then.
+	UTCOffset *int16
+
+	// Report the drift only, the SEL Time and UTC Offset are not changed.
+	DryRun bool
+}
+
+// SELTimeSyncResult is the result of SyncSELTime.
+type SELTimeSyncResult struct {
+	Before *SELTimeDrift `json:"before"`
+	// The drift after the SEL Time was set, nil if the SEL Time was not set.
+	After *SELTimeDrift `json:"after,omitempty"`
+	// Whether the SEL Time was (or would be, for dry run) set, it is also set when the UTC offset is set.
+	TimeSet bool `json:"time_set"`
+
+	// The UTC offset in minutes before and after the sync, SELTimeUTCOffsetUnspecified if unspecified.
+	// Both are nil if the BMC does not support the SEL Time UTC Offset commands.
+	UTCOffsetBefore *int16 `json:"utc_offset_before,omitempty"`
+	UTCOffsetAfter  *int16 `json:"utc_offset_after,omitempty"`
+	// Whether the UTC offset was (or would be, for dry run) set.
+	UTCOffsetSet bool `json:"utc_offset_set"`
+
+	DryRun bool `json:"dry_run"`
+}
+
+func (r *SELTimeSyncResult) Format() string {
+	formatDrift := func(drift *SELTimeDrift) string {
+		return fmt.Sprintf("SEL Time %s, Drift %s (round-trip %s)",
+			drift.SELTime.Format(time.RFC3339), drift.Drift, drift.RoundTrip.Round(time.Millisecond))
+	}
+	formatOffset := func(offset *int16) string {
+		switch {
+		case offset == nil:
+			return "not supported"
+		case *offset == SELTimeUTCOffsetUnspecified:
+			return "unspecified"
+		}
+		return fmt.Sprintf("%d minutes", *offset)
+	}
+
+	out := fmt.Sprintf("Before          : %s\n", formatDrift(r.Before))
+	if r.After != nil {
+		out += fmt.Sprintf("After           : %s\n", formatDrift(r.After))
+	}
+	out += fmt.Sprintf("Time Set        : %s\n", formatBool(r.TimeSet, formatBool(r.DryRun, "needed (dry run)", "yes"), "no"))
+	out += fmt.Sprintf("UTC Offset      : %s\n", formatOffset(r.UTCOffsetBefore))
+	if r.UTCOffsetSet && !r.DryRun {
+		out += fmt.Sprintf("UTC Offset After: %s\n", formatOffset(r.UTCOffsetAfter))
+	}
+	out += fmt.Sprintf("UTC Offset Set  : %s", formatBool(r.UTCOffsetSet, formatBool(r.DryRun, "needed (dry run)", "yes"), "no"))
+	return out
+}
+
+// SyncSELTime measures the drift of the SEL Time relative to the local clock, and sets the SEL Time
+// to the local clock if the drift exceeds the tolerance. The SEL Time is set at the start of a second
+// of the local clock, corrected by half of the request round-trip time, as its resolution is one second.
+//
+// The SEL Time is UTC plus the SEL Time UTC Offset. The drift is measured by the current UTC offset of
+// the BMC. If the UTC offset differs from the expected one (opts.UTCOffset), it is set, and the SEL Time
+// is set to UTC plus the new offset, otherwise the current UTC offset is kept. The UTC offset is skipped if the BMC does not support the UTC Offset commands.
+//
+// The drift before and after the sync are reported, nothing is changed if opts.DryRun.
+func (c *Client) SyncSELTime(opts SELTimeSyncOptions) (*SELTimeSyncResult, error) {
+	return syncSELTime(c, systemClock{}, opts)
+}
+
+func syncSELTime(source selTimeSource, clock selClock, opts SELTimeSyncOptions) (*SELTimeSyncResult, error) {
+	tolerance := opts.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultSELTimeTolerance
+	}
+
+	result := &SELTimeSyncResult{
+		DryRun: opts.DryRun,
+	}
+
+	offset, err := getSELTimeUTCOffset(source)
+	if err != nil {
+		return nil, err
+	}
+	// the UTC offset applied to the SEL Time after the sync
+	expectedOffset := effectiveUTCOffset(offset)
+	if offset != nil {
+		result.UTCOffsetBefore = offset
+		result.UTCOffsetAfter = offset
+		if opts.UTCOffset != nil && *opts.UTCOffset != *offset {
+			result.UTCOffsetSet = true
+			expectedOffset = effectiveUTCOffset(opts.UTCOffset)
+		}
+	}
+
+	before, err := getSELTimeDrift(source, clock, effectiveUTCOffset(offset))
+	if err != nil {
+		return nil, err
+	}
+	result.Before = before
+
+	drift := before.Drift
+	if drift < 0 {
+		drift = -drift
+	}
+	// the SEL Time is UTC plus the offset, so it is set again when the offset changes
+	result.TimeSet = tolerance < 0 || drift > tolerance || result.UTCOffsetSet
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if result.UTCOffsetSet {
+		utcOffset := *opts.UTCOffset
+		if _, err := source.SetSELTimeUTCOffset(utcOffset); err != nil {
+			return nil, fmt.Errorf("SetSELTimeUTCOffset failed, err: %s", err)
+		}
+		result.UTCOffsetAfter = &utcOffset
+	}
+
+	if result.TimeSet {
+		// set the SEL Time when the request reaches the BMC at the start of the next second
+		now := clock.Now()
+		target := now.Truncate(time.Second).Add(time.Second)
+		wait := target.Sub(now) - before.RoundTrip/2
+		if wait < 0 {
+			target = target.Add(time.Second)
+			wait += time.Second
+		}
+		clock.Sleep(wait)
+
+		if _, err := source.SetSELTime(target.Add(time.Duration(expectedOffset) * time.Minute)); err != nil {
+			return nil, fmt.Errorf("SetSELTime failed, err: %s", err)
+		}
+
+		after, err := getSELTimeDrift(source, clock, expectedOffset)
+		if err != nil {
+			return nil, err
+		}
+		result.After = after
+	}
+
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeSELRoundTrip is the round-trip time of the requests to fakeSELClock.
+const fakeSELRoundTrip = 20 * time.Millisecond
+
+// fakeSELClock is a BMC clock implementing selTimeSource, its SEL Time is UTC plus the UTC offset.
+// It is also the local clock implementing selClock, the local time only advances by the requests and Sleep.
+type fakeSELClock struct {
+	// the local time
+	now time.Time
+
+	// the drift of the BMC clock from UTC
+	offset time.Duration
+	// nil if the UTC Offset commands are not supported
+	utcOffset *int16
+	// the error of Get SEL Time UTC Offset other than not supported
+	utcOffsetErr error
+	timeSets     int
+}
+
+func (c *fakeSELClock) utcOffsetDuration() time.Duration {
+	return time.Duration(effectiveUTCOffset(c.utcOffset)) * time.Minute
+}
+
+func (c *fakeSELClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeSELClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeSELClock) GetSELTime() (*GetSELTimeResponse, error) {
+	// the BMC handles the request in the middle of the round-trip
+	c.Sleep(fakeSELRoundTrip / 2)
+	selTime := c.now.Add(c.offset + c.utcOffsetDuration()).Truncate(time.Second)
+	c.Sleep(fakeSELRoundTrip / 2)
+	return &GetSELTimeResponse{Time: selTime}, nil
+}
+
+func (c *fakeSELClock) SetSELTime(t time.Time) (*SetSELTimeResponse, error) {
+	c.Sleep(fakeSELRoundTrip / 2)
+	c.offset = t.Sub(c.now) - c.utcOffsetDuration()
+	c.Sleep(fakeSELRoundTrip / 2)
+	c.timeSets++
+	return &SetSELTimeResponse{}, nil
+}
+
+func (c *fakeSELClock) GetSELTimeUTCOffset() (*GetSELTimeUTCOffsetResponse, error) {
+	if c.utcOffsetErr != nil {
+		return nil, c.utcOffsetErr
+	}
+	if c.utcOffset == nil {
+		return nil, &ResponseError{completionCode: CompletionCodeInvalidCommand}
+	}
+	return &GetSELTimeUTCOffsetResponse{MinutesOffset: *c.utcOffset}, nil
+}
+
+func (c *fakeSELClock) SetSELTimeUTCOffset(minutesOffset int16) (*SetSELTimeUTCOffsetResponse, error) {
+	// the SEL Time is kept, so the clock drifts from UTC by the change of the offset
+	c.offset -= time.Duration(effectiveUTCOffset(&minutesOffset))*time.Minute - c.utcOffsetDuration()
+	c.utcOffset = &minutesOffset
+	return &SetSELTimeUTCOffsetResponse{}, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func Test_syncSELTime(t *testing.T) {
+	utc := int16(0)
+	cst := int16(480)
+	unspecified := SELTimeUTCOffsetUnspecified
+
+	tests := []struct {
+		name           string
+		offset         time.Duration
+		utcOffset      *int16
+		expectedOffset *int16
+		dryRun         bool
+		timeSet        bool
+		utcOffsetSet   bool
+	}{
+		{"in tolerance", 500 * time.Millisecond, &utc, nil, false, false, false},
+		{"ahead", 5 * time.Minute, &utc, nil, false, true, false},
+		{"behind with unspecified UTC offset", -90 * time.Second, &unspecified, &utc, false, true, true},
+		{"behind with unspecified UTC offset kept", -90 * time.Second, &unspecified, nil, false, true, false},
+		{"UTC offset not supported", -time.Hour, nil, &cst, false, true, false},
+		{"dry run", 5 * time.Minute, &unspecified, &utc, true, true, true},
+		// the SEL Time is UTC+8 and correct
+		{"non-zero UTC offset in tolerance", 0, &cst, &cst, false, false, false},
+		{"non-zero UTC offset ahead", 5 * time.Minute, &cst, &cst, false, true, false},
+		// the offset is kept if not specified
+		{"non-zero UTC offset kept", 5 * time.Minute, &cst, nil, false, true, false},
+		// the offset is set to 0, and the SEL Time is set to UTC
+		{"non-zero UTC offset to UTC", 0, &cst, &utc, false, true, true},
+	}
+
+	for _, test := range tests {
+		clock := &fakeSELClock{now: time.Unix(1700000000, 300*int64(time.Millisecond)), offset: test.offset}
+		if test.utcOffset != nil {
+			v := *test.utcOffset
+			clock.utcOffset = &v
+		}
+
+		result, err := syncSELTime(clock, clock, SELTimeSyncOptions{UTCOffset: test.expectedOffset, DryRun: test.dryRun})
+		if err != nil {
+			t.Errorf("test %s failed, err: %s", test.name, err)
+			continue
+		}
+
+		// the drift is measured from UTC, regardless of the UTC offset
+		if absDuration(result.Before.Drift-test.offset) > time.Second {
+			t.Errorf("test %s drift not matched, got: %s, expected: %s", test.name, result.Before.Drift, test.offset)
+		}
+		if result.TimeSet != test.timeSet || result.UTCOffsetSet != test.utcOffsetSet {
+			t.Errorf("test %s not matched, got time set: %v, UTC offset set: %v", test.name, result.TimeSet, result.UTCOffsetSet)
+		}
+
+		if test.dryRun || !test.timeSet {
+			if clock.timeSets != 0 || result.After != nil {
+				t.Errorf("test %s SEL Time should not be set", test.name)
+			}
+		} else if result.After == nil || absDuration(result.After.Drift) > 500*time.Millisecond || clock.offset != 0 {
+			t.Errorf("test %s drift after sync not matched, got: %v, clock drift: %s", test.name, result.After, clock.offset)
+		}
+
+		if test.utcOffsetSet && !test.dryRun && (clock.utcOffset == nil || *clock.utcOffset != *test.expectedOffset) {
+			t.Errorf("test %s UTC offset not set", test.name)
+		}
+		if (test.dryRun || !test.utcOffsetSet) && test.utcOffset != nil && *clock.utcOffset != *test.utcOffset {
+			t.Errorf("test %s UTC offset should not be set", test.name)
+		}
+	}
+
+	// the errors other than not supported are not ignored
+	clock := &fakeSELClock{utcOffset: &utc, utcOffsetErr: &ResponseError{completionCode: CompletionCodeNodeBusy}}
+	if _, err := syncSELTime(clock, clock, SELTimeSyncOptions{}); err == nil {
+		t.Errorf("test GetSELTimeUTCOffset error expected error")
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/olekukonko/tablewriter"
 )
@@ -98,6 +101,32 @@ const (
 	// OEM Reserved: 0xC0 - 0xFF
 )
 
+// ParseSensorType parses the sensor type from its number (like "4" or "0x04")
+// or its name (like "fan" or "Power Supply"), the name is case insensitive
+// and spaces, dashes, slashes are ignored.
+func ParseSensorType(s string) (SensorType, error) {
+	if i, err := strconv.ParseUint(s, 0, 8); err == nil {
+		return SensorType(i), nil
+	}
+
+	normalize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, s)
+	}
+
+	name := normalize(s)
+	for sensorType, sensorTypeName := range sensorTypeMap {
+		if normalize(sensorTypeName) == name {
+			return sensorType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown sensor type (%s)", s)
+}
+
 var sensorTypeMap = map[SensorType]string{
 	0x00: "Reserved",
 	0x01: "Temperature",
@@ -379,6 +408,15 @@ func (sensorThresholdType SensorThresholdType) Abbr() string {
 
 type SensorThresholdTypes []SensorThresholdType
 
+func (types SensorThresholdTypes) Contains(thresholdType SensorThresholdType) bool {
+	for _, v := range types {
+		if v == thresholdType {
+			return true
+		}
+	}
+	return false
+}
+
 func (types SensorThresholdTypes) Strings() []string {
 	out := []string{}
 	for _, v := range types {
@@ -527,6 +565,36 @@ func (l LinearizationFunc) Apply(x float64) float64 {
 	return x
 }
 
+// Inverse applies the inverse function of linearization func (itself) to the input value and returns the result,
+// that is l.Inverse(l.Apply(x)) == x.
+func (l LinearizationFunc) Inverse(y float64) float64 {
+	switch l {
+	case LinearizationFunc_LN:
+		return math.Exp(y)
+	case LinearizationFunc_LOG10:
+		return math.Pow(10, y)
+	case LinearizationFunc_LOG2:
+		return math.Exp2(y)
+	case LinearizationFunc_E:
+		return math.Log(y)
+	case LinearizationFunc_EXP10:
+		return math.Log10(y)
+	case LinearizationFunc_EXP2:
+		return math.Log2(y)
+	case LinearizationFunc_1X:
+		return math.Pow(y, -1)
+	case LinearizationFunc_SQR:
+		return math.Sqrt(y)
+	case LinearizationFunc_CUBE:
+		return math.Cbrt(y)
+	case LinearizationFunc_SQRT:
+		return math.Pow(y, 2.0)
+	case LinearizationFunc_CUBERT:
+		return math.Pow(y, 3.0)
+	}
+	return y
+}
+
 type SensorUnit struct {
 	AnalogDataFormat SensorAnalogUnitFormat
 	RateUnit         SensorRateUnit
@@ -537,6 +605,15 @@ type SensorUnit struct {
 	ModifierUnit SensorUnitType
 }
 
+// pack returns the Sensor Units 1, 2, 3 bytes of Full/Compact SDR.
+func (unit SensorUnit) pack() (units1 uint8, units2 uint8, units3 uint8) {
+	units1 = uint8(unit.AnalogDataFormat)<<6 | (uint8(unit.RateUnit)&0x07)<<3 | (uint8(unit.ModifierRelation)&0x03)<<1
+	if unit.Percentage {
+		units1 = setBit0(units1)
+	}
+	return units1, uint8(unit.BaseUnit), uint8(unit.ModifierUnit)
+}
+
 func (unit SensorUnit) String() string {
 	if !unit.IsAnalog() {
 		return "discrete"
@@ -811,6 +888,60 @@ func ConvertReading(raw uint8, analogDataFormat SensorAnalogUnitFormat, factors
 	return linearizationFunc.Apply(y)
 }
 
+// ConvertReadingToRaw converts the real value in the desired units for the sensor to raw sensor reading or
+// raw sensor threshold value. It is the reverse of ConvertReading, the result is rounded to the nearest raw value.
+//
+// For non-linear sensors, the factors should be the ones for the raw value near the result,
+// see Client.SetSensorThresholdValues.
+func ConvertReadingToRaw(value float64, analogDataFormat SensorAnalogUnitFormat, factors ReadingFactors, linearizationFunc LinearizationFunc) (uint8, error) {
+	// x = ( L'(y) / 10^R_Exp - (B * 10^B_Exp) ) / M
+
+	if factors.M == 0 {
+		return 0, fmt.Errorf("the M factor is zero, can not convert")
+	}
+
+	y := linearizationFunc.Inverse(value)
+
+	M := float64(factors.M)
+	B := float64(factors.B)
+	Bexp := math.Pow(10, float64(factors.B_Exp))
+	Rexp := math.Pow(10, float64(factors.R_Exp))
+
+	x := math.Round((y/Rexp - B*Bexp) / M)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0, fmt.Errorf("value %v can not be converted", value)
+	}
+
+	return analogRaw(int64(x), analogDataFormat, value)
+}
+
+// analogRaw is the reverse of AnalogValue.
+func analogRaw(analog int64, format SensorAnalogUnitFormat, value float64) (uint8, error) {
+	var min, max int64
+	switch format {
+	case SensorAnalogUnitFormat_Unsigned:
+		min, max = 0, 255
+	case SensorAnalogUnitFormat_1sComplement:
+		min, max = -127, 127
+	case SensorAnalogUnitFormat_2sComplement:
+		min, max = -128, 127
+	default:
+		return 0, fmt.Errorf("sensor does not return analog reading")
+	}
+
+	if analog < min || analog > max {
+		return 0, fmt.Errorf("value %v is out of the range of the sensor (raw %d, should be in [%d, %d])", value, analog, min, max)
+	}
+
+	switch format {
+	case SensorAnalogUnitFormat_1sComplement:
+		return uint8(onesComplementEncode(int32(analog), 8)), nil
+	case SensorAnalogUnitFormat_2sComplement:
+		return uint8(twosComplementEncode(int32(analog), 8)), nil
+	}
+	return uint8(analog), nil
+}
+
 // ConvertSensorHysteresis converts raw sensor hysterresis value to real value in the desired units for the sensor.
 //
 // see: 36.3 Sensor Reading Conversion Formula
@@ -832,6 +963,43 @@ func ConvertSensorHysteresis(raw uint8, analogDataFormat SensorAnalogUnitFormat,
 	return linearizationFunc.Apply(y)
 }
 
+// ConvertSensorHysteresisFromRaw converts raw sensor hysterresis value to real value in the desired units for the sensor.
+//
+// Unlike ConvertSensorHysteresis, the hysteresis value is taken as raw counts of the reading (a delta),
+// so only the M factor (unsigned) and the R exponent are applied, the B offset, the analog data format and
+// the linearization are not. It is used by the Sensor and SDRFull methods, and reversed by ConvertSensorHysteresisToRaw.
+//
+// see: 36.3 Sensor Reading Conversion Formula
+func ConvertSensorHysteresisFromRaw(raw uint8, factors ReadingFactors) float64 {
+	// y = (M * x) * 10^R_Exp units
+
+	x := float64(raw)
+
+	M := math.Abs(float64(factors.M))
+	Rexp := math.Pow(10, float64(factors.R_Exp))
+
+	return M * x * Rexp
+}
+
+// ConvertSensorHysteresisToRaw converts the real hysteresis value to raw hysteresis value.
+// It is the reverse of ConvertSensorHysteresisFromRaw, the result is rounded to the nearest raw value.
+func ConvertSensorHysteresisToRaw(value float64, factors ReadingFactors) (uint8, error) {
+	// x = y / 10^R_Exp / M
+
+	if factors.M == 0 {
+		return 0, fmt.Errorf("the M factor is zero, can not convert")
+	}
+
+	M := math.Abs(float64(factors.M))
+	Rexp := math.Pow(10, float64(factors.R_Exp))
+
+	x := math.Round(value / Rexp / M)
+	if math.IsNaN(x) || x < 0 || x > 255 {
+		return 0, fmt.Errorf("hysteresis %v is out of the range of the sensor (raw %v, should be in [0, 255])", value, x)
+	}
+	return uint8(x), nil
+}
+
 // ConvertSensorTolerance converts raw sensor tolerance value to real value in the desired units for the sensor.
 //
 // see: 36.4.1 Tolerance
@@ -857,6 +1025,10 @@ type Sensor struct {
 	Number uint8
 	Name   string
 
+	GeneratorID    GeneratorID
+	EntityID       EntityID
+	EntityInstance EntityInstance
+
 	SensorType           SensorType
 	EventReadingType     EventReadingType
 	SensorUnit           SensorUnit
@@ -914,6 +1086,10 @@ type Sensor struct {
 	}
 
 	OccuredEvents []SensorEvent
+
+	// Err is the error occurred when reading the sensor,
+	// only set when the sensor error tolerance is enabled, see WithSensorErrorTolerance.
+	Err *SensorError
 }
 
 func FormatSensors(extended bool, sensors ...*Sensor) string {
@@ -1003,6 +1179,11 @@ func (sensor *Sensor) Status() string {
 	return fmt.Sprintf("0x%02x%02x", sensor.Discrete.optionalData1, sensor.Discrete.optionalData2)
 }
 
+// Key returns the key of the sensor, which identifies the sensor by the owner, the LUN and the number.
+func (sensor *Sensor) Key() SensorKey {
+	return sensor.GeneratorID.SensorKey(SensorNumber(sensor.Number))
+}
+
 // IsThreshold returns whether the sensor is threshold sensor class or not.
 func (sensor *Sensor) IsThreshold() bool {
 	return sensor.EventReadingType.IsThreshold()
@@ -1041,23 +1222,26 @@ func (sensor *Sensor) ThresholdStr(thresholdType SensorThresholdType) string {
 		return "N/A"
 	}
 
-	var value float64
+	return fmt.Sprintf("%.3f", sensor.ThresholdValue(thresholdType))
+}
+
+// ThresholdValue returns the converted value of the specified threshold type.
+func (sensor *Sensor) ThresholdValue(thresholdType SensorThresholdType) float64 {
 	switch thresholdType {
 	case SensorThresholdType_LCR:
-		value = sensor.Threshold.LCR
+		return sensor.Threshold.LCR
 	case SensorThresholdType_LNR:
-		value = sensor.Threshold.LNR
+		return sensor.Threshold.LNR
 	case SensorThresholdType_LNC:
-		value = sensor.Threshold.LNC
+		return sensor.Threshold.LNC
 	case SensorThresholdType_UCR:
-		value = sensor.Threshold.UCR
+		return sensor.Threshold.UCR
 	case SensorThresholdType_UNC:
-		value = sensor.Threshold.UNC
+		return sensor.Threshold.UNC
 	case SensorThresholdType_UNR:
-		value = sensor.Threshold.UNR
+		return sensor.Threshold.UNR
 	}
-
-	return fmt.Sprintf("%.3f", value)
+	return 0
 }
 
 // ConvertReading converts raw sensor reading or raw sensor threshold value to real value in the desired units for the sensor.
@@ -1065,8 +1249,48 @@ func (sensor *Sensor) ConvertReading(raw uint8) float64 {
 	return ConvertReading(raw, sensor.SensorUnit.AnalogDataFormat, sensor.Threshold.ReadingFactors, sensor.Threshold.LinearizationFunc)
 }
 
+// ConvertSensorHysteresis converts raw sensor hysteresis value to real value by ConvertSensorHysteresisFromRaw,
+// so the value read can be set back by SetSensorHysteresisValues unchanged.
 func (sensor *Sensor) ConvertSensorHysteresis(raw uint8) float64 {
-	return ConvertSensorHysteresis(raw, sensor.SensorUnit.AnalogDataFormat, sensor.Threshold.ReadingFactors, sensor.Threshold.LinearizationFunc)
+	return ConvertSensorHysteresisFromRaw(raw, sensor.Threshold.ReadingFactors)
+}
+
+// ConvertReadingToRaw converts real reading or threshold value to raw value by the current reading factors of the sensor.
+func (sensor *Sensor) ConvertReadingToRaw(value float64) (uint8, error) {
+	return ConvertReadingToRaw(value, sensor.SensorUnit.AnalogDataFormat, sensor.Threshold.ReadingFactors, sensor.Threshold.LinearizationFunc)
+}
+
+// hasSDRThresholds reports whether the threshold values in the SDR of the sensor are meaningful,
+// that is a Full SDR whose thresholds are initialized from the SDR or fixed in the sensor.
+func (sensor *Sensor) hasSDRThresholds() bool {
+	return sensor.SDRRecordType == SDRRecordTypeFullSensor &&
+		(sensor.SensorInitialization.InitThresholds || sensor.SensorCapabilitites.ThresholdAccess == SensorThresholdAccess_Fixed)
+}
+
+// hasSDRHysteresis reports whether the hysteresis values in the SDR of the sensor are meaningful,
+// that is a Full SDR whose hysteresis is initialized from the SDR or fixed in the sensor.
+func (sensor *Sensor) hasSDRHysteresis() bool {
+	return sensor.SDRRecordType == SDRRecordTypeFullSensor &&
+		(sensor.SensorInitialization.InitHysteresis || sensor.SensorCapabilitites.HysteresisAccess == SensorHysteresisAccess_Fixed)
+}
+
+// setThresholdsUnavailable marks all the thresholds of the sensor not readable.
+func (sensor *Sensor) setThresholdsUnavailable() {
+	sensor.Threshold.Mask.LNC.Readable = false
+	sensor.Threshold.Mask.LCR.Readable = false
+	sensor.Threshold.Mask.LNR.Readable = false
+	sensor.Threshold.Mask.UNC.Readable = false
+	sensor.Threshold.Mask.UCR.Readable = false
+	sensor.Threshold.Mask.UNR.Readable = false
+}
+
+// SettableThresholds returns the threshold types those can be set by Set Sensor Thresholds command.
+func (sensor *Sensor) SettableThresholds() SensorThresholdTypes {
+	if sensor.SensorCapabilitites.ThresholdAccess != SensorThresholdAccess_ReadableSettable {
+		return SensorThresholdTypes{}
+	}
+	mask := &Mask{Threshold: sensor.Threshold.Mask}
+	return mask.SettableThresholds()
 }
 
 func (sensor *Sensor) ConvertSensorTolerance(raw uint8) float64 {
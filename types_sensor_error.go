@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SensorError is the error of the command which failed when reading a sensor.
+type SensorError struct {
+	SensorNumber uint8
+	SensorName   string
+	GeneratorID  GeneratorID
+
+	// Command is the failed command
+	Command Command
+	Err     error
+}
+
+func newSensorError(sensor *Sensor, command Command, err error) *SensorError {
+	return &SensorError{
+		SensorNumber: sensor.Number,
+		SensorName:   sensor.Name,
+		GeneratorID:  sensor.GeneratorID,
+		Command:      command,
+		Err:          err,
+	}
+}
+
+func (e *SensorError) Error() string {
+	return fmt.Sprintf("%s for sensor [%s](%#02x) failed, err: %s", e.Command.Name, e.SensorName, e.SensorNumber, e.Err)
+}
+
+func (e *SensorError) Unwrap() error {
+	return e.Err
+}
+
+// CompletionCode returns the completion code of the failed command.
+// The ok is false if the command failed without a completion code, like timeout.
+func (e *SensorError) CompletionCode() (cc CompletionCode, ok bool) {
+	if resErr, ok := e.Err.(*ResponseError); ok {
+		return resErr.CompletionCode(), true
+	}
+	return 0, false
+}
+
+// SensorsError is the aggregate error of the sensors failed to read,
+// returned by GetSensors when the sensor error tolerance is enabled, see WithSensorErrorTolerance.
+type SensorsError struct {
+	// Total number of the sensors tried to read
+	Total  int
+	Errors []*SensorError
+}
+
+// Error summarizes the failed sensors by the failed command and completion code.
+func (e *SensorsError) Error() string {
+	keys := make([]string, 0)
+	counts := make(map[string]int)
+
+	for _, sensorErr := range e.Errors {
+		key := sensorErr.Command.Name
+		if cc, ok := sensorErr.CompletionCode(); ok {
+			key = fmt.Sprintf("%s (%#02x)", key, uint8(cc))
+		}
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+
+	summary := make([]string, 0, len(keys))
+	for _, key := range keys {
+		summary = append(summary, fmt.Sprintf("%s: %d", key, counts[key]))
+	}
+
+	return fmt.Sprintf("%d of %d sensors failed, %s", len(e.Errors), e.Total, strings.Join(summary, ", "))
+}
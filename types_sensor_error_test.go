@@ -0,0 +1,33 @@
+package ipmi
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_SensorsError(t *testing.T) {
+	sensor := &Sensor{Number: 0x01, Name: "CPU1 Temp"}
+	ccErr := &ResponseError{completionCode: CompletionCodeProcessTimeout}
+
+	sensorsErr := &SensorsError{
+		Total: 10,
+		Errors: []*SensorError{
+			newSensorError(sensor, CommandGetSensorThresholds, ccErr),
+			newSensorError(sensor, CommandGetSensorThresholds, ccErr),
+			newSensorError(sensor, CommandGetSensorHysteresis, errors.New("timeout")),
+		},
+	}
+
+	expected := "3 of 10 sensors failed, Get Sensor Threshold (0xc3): 2, Get Sensor Hysteresis: 1"
+	if got := sensorsErr.Error(); got != expected {
+		t.Errorf("test SensorsError not matched, got: %s, expected: %s", got, expected)
+	}
+
+	cc, ok := sensorsErr.Errors[0].CompletionCode()
+	if !ok || cc != CompletionCodeProcessTimeout {
+		t.Errorf("test SensorError CompletionCode not matched, got: %#02x, %v", cc, ok)
+	}
+	if !errors.Is(sensorsErr.Errors[0], ccErr) {
+		t.Errorf("test SensorError expected to wrap the command error")
+	}
+}
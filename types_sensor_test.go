@@ -0,0 +1,102 @@
+package ipmi
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_ConvertReadingToRaw(t *testing.T) {
+	tests := []struct {
+		name              string
+		format            SensorAnalogUnitFormat
+		factors           ReadingFactors
+		linearizationFunc LinearizationFunc
+	}{
+		{"temperature", SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 1}, LinearizationFunc_Linear},
+		{"fan", SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 75}, LinearizationFunc_Linear},
+		{"voltage with B and R_Exp", SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 63, B: 5, B_Exp: 1, R_Exp: -3}, LinearizationFunc_Linear},
+		{"negative M", SensorAnalogUnitFormat_2sComplement, ReadingFactors{M: -272, B: -1, B_Exp: 2, R_Exp: -3}, LinearizationFunc_Linear},
+		{"1's complement", SensorAnalogUnitFormat_1sComplement, ReadingFactors{M: 2}, LinearizationFunc_Linear},
+		{"sqr", SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 1, R_Exp: -1}, LinearizationFunc_SQR},
+		{"1/x", SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 1}, LinearizationFunc_1X},
+	}
+
+	for _, test := range tests {
+		for _, raw := range []uint8{0x01, 0x20, 0x7f, 0x81, 0xfe} {
+			value := ConvertReading(raw, test.format, test.factors, test.linearizationFunc)
+			got, err := ConvertReadingToRaw(value, test.format, test.factors, test.linearizationFunc)
+			if err != nil {
+				t.Errorf("test %s raw %#02x failed, err: %s", test.name, raw, err)
+				continue
+			}
+			if got != raw {
+				t.Errorf("test %s not matched, value: %v, got: %#02x, expected: %#02x", test.name, value, got, raw)
+			}
+		}
+	}
+
+	if _, err := ConvertReadingToRaw(300, SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 1}, LinearizationFunc_Linear); err == nil {
+		t.Errorf("test out of range expected error")
+	}
+	if _, err := ConvertReadingToRaw(10, SensorAnalogUnitFormat_Unsigned, ReadingFactors{M: 0}, LinearizationFunc_Linear); err == nil {
+		t.Errorf("test zero M expected error")
+	}
+}
+
+func Test_ConvertSensorHysteresisToRaw(t *testing.T) {
+	factors := ReadingFactors{M: 63, B: 5, B_Exp: 1, R_Exp: -3}
+	for _, raw := range []uint8{0, 1, 2, 10, 255} {
+		value := ConvertSensorHysteresisFromRaw(raw, factors)
+		if math.Abs(value-float64(raw)*0.063) > 1e-9 {
+			t.Errorf("test hysteresis raw %d not matched, got: %v", raw, value)
+		}
+		got, err := ConvertSensorHysteresisToRaw(value, factors)
+		if err != nil || got != raw {
+			t.Errorf("test hysteresis raw %d not matched, got: %d, err: %v", raw, got, err)
+		}
+	}
+
+	// the hysteresis read is a delta, the B offset and the linearization are not applied,
+	// so it is set back to the same raw value
+	sensor := &Sensor{SensorUnit: SensorUnit{AnalogDataFormat: SensorAnalogUnitFormat_2sComplement}}
+	sensor.Threshold.ReadingFactors = factors
+	sensor.Threshold.LinearizationFunc = LinearizationFunc_LOG10
+	full := &SDRFull{SensorUnit: sensor.SensorUnit, ReadingFactors: factors, LinearizationFunc: LinearizationFunc_LOG10}
+	for _, got := range []float64{sensor.ConvertSensorHysteresis(10), full.ConvertSensorHysteresis(10)} {
+		if math.Abs(got-0.63) > 1e-9 {
+			t.Errorf("test hysteresis read not matched, got: %v, expected: 0.63", got)
+		}
+		if raw, err := ConvertSensorHysteresisToRaw(got, factors); err != nil || raw != 10 {
+			t.Errorf("test hysteresis read not set back unchanged, got: %d, err: %v", raw, err)
+		}
+	}
+}
+
+// fakeReadingFactors returns the reading factors of the raw value by factors.
+type fakeReadingFactors func(raw uint8) ReadingFactors
+
+func (f fakeReadingFactors) GetSensorReadingFactors(sensorNumber uint8, reading uint8) (*GetSensorReadingFactorsResponse, error) {
+	return &GetSensorReadingFactorsResponse{ReadingFactors: f(reading)}, nil
+}
+
+func Test_convertReadingToRaw(t *testing.T) {
+	sensor := &Sensor{Number: 0x10}
+	sensor.SensorUnit.AnalogDataFormat = SensorAnalogUnitFormat_Unsigned
+	sensor.Threshold.LinearizationFunc = LinearizationFunc(0x70)
+
+	got, err := convertReadingToRaw(fakeReadingFactors(func(raw uint8) ReadingFactors { return ReadingFactors{M: 2} }), sensor, 100)
+	if err != nil || got != 50 {
+		t.Errorf("test converged not matched, got: %d, err: %v", got, err)
+	}
+
+	// the factors of the raw values below 80 convert the value to 100, the others to 50
+	oscillating := fakeReadingFactors(func(raw uint8) ReadingFactors {
+		if raw < 80 {
+			return ReadingFactors{M: 1}
+		}
+		return ReadingFactors{M: 2}
+	})
+	if _, err := convertReadingToRaw(oscillating, sensor, 100); err == nil {
+		t.Errorf("test not converged expected error")
+	}
+}
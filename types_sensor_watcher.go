@@ -0,0 +1,384 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultSensorWatchInterval   time.Duration = 10 * time.Second
+	DefaultSensorWatchMaxBackoff time.Duration = 5 * time.Minute
+)
+
+type SensorWatchEventType string
+
+const (
+	// the threshold status of a threshold sensor changed
+	SensorWatchEventThresholdStatus SensorWatchEventType = "threshold-status"
+
+	// a discrete state bit became active
+	SensorWatchEventStateAsserted SensorWatchEventType = "state-asserted"
+
+	// a discrete state bit became inactive
+	SensorWatchEventStateDeasserted SensorWatchEventType = "state-deasserted"
+
+	// the sensor reading became unavailable
+	SensorWatchEventReadingUnavailable SensorWatchEventType = "reading-unavailable"
+
+	// the sensor reading became available again
+	SensorWatchEventReadingAvailable SensorWatchEventType = "reading-available"
+
+	// failed to read the sensor, the sensor will be polled with backoff
+	SensorWatchEventError SensorWatchEventType = "error"
+)
+
+// SensorWatchEvent is a change of sensor detected by SensorWatcher.
+type SensorWatchEvent struct {
+	Time time.Time
+	Type SensorWatchEventType
+
+	SensorNumber uint8
+	SensorName   string
+
+	// The sensor as read by this poll, nil for SensorWatchEventError.
+	Sensor *Sensor
+
+	// Only for SensorWatchEventThresholdStatus
+	PreviousStatus SensorThresholdStatus
+	Status         SensorThresholdStatus
+
+	// Only for SensorWatchEventStateAsserted and SensorWatchEventStateDeasserted
+	State int
+
+	// Only for SensorWatchEventError
+	Err error
+}
+
+func (e *SensorWatchEvent) String() string {
+	prefix := fmt.Sprintf("%s [%s](%#02x) %s", e.Time.Format(time.RFC3339), e.SensorName, e.SensorNumber, e.Type)
+
+	switch e.Type {
+	case SensorWatchEventThresholdStatus:
+		return fmt.Sprintf("%s: %s -> %s, reading: %s %s", prefix, e.PreviousStatus, e.Status, e.Sensor.ReadingStr(), e.Sensor.SensorUnit.String())
+	case SensorWatchEventStateAsserted, SensorWatchEventStateDeasserted:
+		return fmt.Sprintf("%s: state %d", prefix, e.State)
+	case SensorWatchEventError:
+		return fmt.Sprintf("%s: %s", prefix, e.Err)
+	}
+	return prefix
+}
+
+// SensorWatcher polls sensors periodically and emits SensorWatchEvent for the changes
+// of threshold status, discrete states and reading availability.
+//
+// The first successful poll of a sensor only records its state, no event is emitted for it.
+type SensorWatcher struct {
+	client *Client
+
+	interval         time.Duration
+	intervalRules    []sensorWatchIntervalRule
+	maxBackoff       time.Duration
+	sdrFilterOptions []SDRFilterOption
+
+	events chan *SensorWatchEvent
+}
+
+type sensorWatchIntervalRule struct {
+	interval      time.Duration
+	filterOptions []SDRFilterOption
+}
+
+// sensorWatchEntry holds the watch state of a single sensor.
+type sensorWatchEntry struct {
+	sdr      *SDR
+	interval time.Duration
+	next     time.Time
+	failures int
+
+	// last polled sensor, nil before the first successful poll
+	last *Sensor
+	// the threshold status after debounce
+	status SensorThresholdStatus
+}
+
+// NewSensorWatcher creates a SensorWatcher which polls the sensors whose SDRs passed ALL sdrFilterOptions
+// every interval. Call Run to start watching, and receive the events from Events.
+func (c *Client) NewSensorWatcher(interval time.Duration, sdrFilterOptions ...SDRFilterOption) *SensorWatcher {
+	if interval <= 0 {
+		interval = DefaultSensorWatchInterval
+	}
+
+	return &SensorWatcher{
+		client:           c,
+		interval:         interval,
+		intervalRules:    make([]sensorWatchIntervalRule, 0),
+		maxBackoff:       DefaultSensorWatchMaxBackoff,
+		sdrFilterOptions: sdrFilterOptions,
+		events:           make(chan *SensorWatchEvent, 64),
+	}
+}
+
+// WithInterval sets the poll interval for the sensors whose SDRs passed ALL filterOptions.
+// If a sensor matches multiple rules, the last one wins.
+// The rule of a non-positive interval is ignored.
+func (w *SensorWatcher) WithInterval(interval time.Duration, filterOptions ...SDRFilterOption) *SensorWatcher {
+	if interval <= 0 {
+		return w
+	}
+	w.intervalRules = append(w.intervalRules, sensorWatchIntervalRule{
+		interval:      interval,
+		filterOptions: filterOptions,
+	})
+	return w
+}
+
+// WithMaxBackoff sets the max poll interval of a sensor when reading it keeps failing.
+// A non-positive maxBackoff is ignored, and a failing sensor is never polled faster than its interval.
+func (w *SensorWatcher) WithMaxBackoff(maxBackoff time.Duration) *SensorWatcher {
+	if maxBackoff <= 0 {
+		return w
+	}
+	w.maxBackoff = maxBackoff
+	return w
+}
+
+// Events returns the channel of the detected events, it is closed when Run returns.
+func (w *SensorWatcher) Events() <-chan *SensorWatchEvent {
+	return w.events
+}
+
+// Run polls the sensors until ctx is done. It can only be called once.
+// The sensors are polled one by one, so Client must not be used by others while Run is running.
+func (w *SensorWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	sdrs, err := w.client.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
+	if err != nil {
+		return fmt.Errorf("GetSDRs failed, err: %s", err)
+	}
+
+	entries := make([]*sensorWatchEntry, 0)
+	now := time.Now()
+	for _, sdr := range FilterSDRs(sdrs, w.sdrFilterOptions...) {
+		entries = append(entries, &sensorWatchEntry{
+			sdr:      sdr,
+			interval: w.intervalOf(sdr),
+			next:     now,
+		})
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no sensors to watch")
+	}
+
+	for {
+		next := entries[0].next
+		for _, entry := range entries[1:] {
+			if entry.next.Before(next) {
+				next = entry.next
+			}
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		for _, entry := range entries {
+			if entry.next.After(time.Now()) {
+				continue
+			}
+			for _, event := range w.poll(entry) {
+				select {
+				case w.events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (w *SensorWatcher) intervalOf(sdr *SDR) time.Duration {
+	interval := w.interval
+	for _, rule := range w.intervalRules {
+		if len(FilterSDRs([]*SDR{sdr}, rule.filterOptions...)) == 1 {
+			interval = rule.interval
+		}
+	}
+	return interval
+}
+
+// poll reads the sensor of entry, and returns the detected events.
+func (w *SensorWatcher) poll(entry *sensorWatchEntry) []*SensorWatchEvent {
+	now := time.Now()
+
+	sensor, err := w.client.sdrToSensor(entry.sdr)
+	if err != nil {
+		entry.failures++
+		entry.next = now.Add(backoff(entry.interval, entry.failures, w.maxBackoff))
+		return []*SensorWatchEvent{
+			{
+				Time:         now,
+				Type:         SensorWatchEventError,
+				SensorNumber: uint8(entry.sdr.SensorNumber()),
+				SensorName:   entry.sdr.SensorName(),
+				Err:          err,
+			},
+		}
+	}
+
+	entry.failures = 0
+	entry.next = now.Add(entry.interval)
+	return entry.update(now, sensor)
+}
+
+// update records the newly polled sensor, and returns the changes against the last polled sensor.
+func (entry *sensorWatchEntry) update(now time.Time, sensor *Sensor) []*SensorWatchEvent {
+	last := entry.last
+	entry.last = sensor
+
+	if last == nil {
+		entry.status = sensor.Threshold.ThresholdStatus
+		return nil
+	}
+
+	events := make([]*SensorWatchEvent, 0)
+	newEvent := func(eventType SensorWatchEventType) *SensorWatchEvent {
+		event := &SensorWatchEvent{
+			Time:         now,
+			Type:         eventType,
+			SensorNumber: sensor.Number,
+			SensorName:   sensor.Name,
+			Sensor:       sensor,
+		}
+		events = append(events, event)
+		return event
+	}
+
+	if last.IsReadingValid() && !sensor.IsReadingValid() {
+		newEvent(SensorWatchEventReadingUnavailable)
+		return events
+	}
+	if !last.IsReadingValid() && sensor.IsReadingValid() {
+		newEvent(SensorWatchEventReadingAvailable)
+	}
+	if !sensor.IsReadingValid() {
+		return events
+	}
+
+	if sensor.IsThreshold() {
+		status := debounceThresholdStatus(entry.status, sensor)
+		if status != entry.status {
+			event := newEvent(SensorWatchEventThresholdStatus)
+			event.PreviousStatus = entry.status
+			event.Status = status
+			entry.status = status
+		}
+		return events
+	}
+
+	lastStates := last.Discrete.ActiveStates.TrueEvents()
+	states := sensor.Discrete.ActiveStates.TrueEvents()
+	for _, state := range states {
+		if !containsInt(lastStates, state) {
+			newEvent(SensorWatchEventStateAsserted).State = state
+		}
+	}
+	for _, state := range lastStates {
+		if !containsInt(states, state) {
+			newEvent(SensorWatchEventStateDeasserted).State = state
+		}
+	}
+
+	return events
+}
+
+// debounceThresholdStatus returns the threshold status to be reported for sensor, given the previous reported status.
+//
+// A status going to a more severe level is always accepted, but a status recovering to a less severe
+// level is only accepted after the reading has gone back over the threshold by the hysteresis,
+// that is, below (threshold - negative-going hysteresis) for upper thresholds,
+// and above (threshold + positive-going hysteresis) for lower thresholds.
+// Otherwise the previous status is held.
+//
+// see: 36.4 Threshold Hysteresis
+func debounceThresholdStatus(previous SensorThresholdStatus, sensor *Sensor) SensorThresholdStatus {
+	status := sensor.Threshold.ThresholdStatus
+	if status == previous {
+		return status
+	}
+
+	previousLevel, previousUpper, thresholdType := thresholdStatusLevel(previous)
+	level, upper, _ := thresholdStatusLevel(status)
+
+	if level > previousLevel || (level != 0 && upper != previousUpper) {
+		return status
+	}
+
+	// recovering
+	if !sensor.IsThresholdReadable(thresholdType) {
+		return status
+	}
+	threshold := sensor.ThresholdValue(thresholdType)
+
+	if previousUpper {
+		if sensor.Value > threshold-sensor.Threshold.NegativeHysteresis {
+			return previous
+		}
+		return status
+	}
+
+	if sensor.Value < threshold+sensor.Threshold.PositiveHysteresis {
+		return previous
+	}
+	return status
+}
+
+// thresholdStatusLevel returns the severity level (0 for ok, 1 for non-critical, 2 for critical, 3 for non-recoverable),
+// whether it is an upper threshold status, and the threshold type crossed for the status.
+func thresholdStatusLevel(status SensorThresholdStatus) (level int, upper bool, thresholdType SensorThresholdType) {
+	switch status {
+	case SensorThresholdStatus_UNC:
+		return 1, true, SensorThresholdType_UNC
+	case SensorThresholdStatus_UCR:
+		return 2, true, SensorThresholdType_UCR
+	case SensorThresholdStatus_UNR:
+		return 3, true, SensorThresholdType_UNR
+	case SensorThresholdStatus_LNC:
+		return 1, false, SensorThresholdType_LNC
+	case SensorThresholdStatus_LCR:
+		return 2, false, SensorThresholdType_LCR
+	case SensorThresholdStatus_LNR:
+		return 3, false, SensorThresholdType_LNR
+	}
+	return 0, false, ""
+}
+
+// backoff returns interval doubled for each failure, but no more than maxBackoff.
+// It never returns less than interval, even if maxBackoff is less than interval.
+func backoff(interval time.Duration, failures int, maxBackoff time.Duration) time.Duration {
+	if maxBackoff < interval {
+		return interval
+	}
+	d := interval
+	for i := 0; i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+func containsInt(list []int, v int) bool {
+	for _, i := range list {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
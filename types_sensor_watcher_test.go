@@ -0,0 +1,125 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_SensorWatchEntryThreshold(t *testing.T) {
+	newSensor := func(value float64, status SensorThresholdStatus) *Sensor {
+		sensor := &Sensor{
+			Number:           0x01,
+			Name:             "CPU1 Temp",
+			EventReadingType: EventReadingTypeThreshold,
+			Value:            value,
+		}
+		sensor.Threshold.ThresholdStatus = status
+		sensor.Threshold.Mask.UNC.Readable = true
+		sensor.Threshold.Mask.UCR.Readable = true
+		sensor.Threshold.UNC = 85
+		sensor.Threshold.UCR = 95
+		sensor.Threshold.NegativeHysteresis = 2
+		sensor.Threshold.PositiveHysteresis = 2
+		return sensor
+	}
+
+	tests := []struct {
+		name     string
+		value    float64
+		status   SensorThresholdStatus
+		expected SensorThresholdStatus // empty for no event
+	}{
+		{"baseline", 80, SensorThresholdStatus_OK, ""},
+		{"cross unc", 86, SensorThresholdStatus_UNC, SensorThresholdStatus_UNC},
+		{"cross ucr", 96, SensorThresholdStatus_UCR, SensorThresholdStatus_UCR},
+		{"recover within hysteresis", 94, SensorThresholdStatus_UNC, ""},
+		{"recover over hysteresis", 92, SensorThresholdStatus_UNC, SensorThresholdStatus_UNC},
+		{"recover to ok within hysteresis", 84, SensorThresholdStatus_OK, ""},
+		{"recover to ok over hysteresis", 82, SensorThresholdStatus_OK, SensorThresholdStatus_OK},
+	}
+
+	entry := &sensorWatchEntry{}
+	for _, test := range tests {
+		events := entry.update(time.Now(), newSensor(test.value, test.status))
+		if test.expected == "" {
+			if len(events) != 0 {
+				t.Errorf("test %s expected no event, got: %s", test.name, events[0])
+			}
+			continue
+		}
+		if len(events) != 1 || events[0].Type != SensorWatchEventThresholdStatus || events[0].Status != test.expected {
+			t.Errorf("test %s expected status event to %s, got: %v", test.name, test.expected, events)
+		}
+	}
+}
+
+func Test_SensorWatchEntryDiscrete(t *testing.T) {
+	newSensor := func(readingUnavailable bool, states ...int) *Sensor {
+		sensor := &Sensor{
+			Number:             0x50,
+			Name:               "PS1 Status",
+			EventReadingType:   EventReadingTypeSensorSpecific,
+			readingUnavailable: readingUnavailable,
+		}
+		for _, state := range states {
+			switch state {
+			case 0:
+				sensor.Discrete.ActiveStates.State_0 = true
+			case 1:
+				sensor.Discrete.ActiveStates.State_1 = true
+			}
+		}
+		return sensor
+	}
+
+	tests := []struct {
+		name     string
+		sensor   *Sensor
+		expected []SensorWatchEventType
+	}{
+		{"baseline", newSensor(false, 0), nil},
+		{"assert", newSensor(false, 0, 1), []SensorWatchEventType{SensorWatchEventStateAsserted}},
+		{"deassert", newSensor(false, 1), []SensorWatchEventType{SensorWatchEventStateDeasserted}},
+		{"unavailable", newSensor(true), []SensorWatchEventType{SensorWatchEventReadingUnavailable}},
+		{"available", newSensor(false, 1), []SensorWatchEventType{SensorWatchEventReadingAvailable, SensorWatchEventStateAsserted}},
+	}
+
+	entry := &sensorWatchEntry{}
+	for _, test := range tests {
+		events := entry.update(time.Now(), test.sensor)
+		if len(events) != len(test.expected) {
+			t.Errorf("test %s events not matched, got: %v, expected: %v", test.name, events, test.expected)
+			continue
+		}
+		for i, event := range events {
+			if event.Type != test.expected[i] {
+				t.Errorf("test %s event not matched, got: %s, expected: %s", test.name, event.Type, test.expected[i])
+			}
+		}
+	}
+}
+
+func Test_backoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 20 * time.Second},
+		{3, 80 * time.Second},
+		{10, 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := backoff(10*time.Second, test.failures, 5*time.Minute); got != test.expected {
+			t.Errorf("test %d failures not matched, got: %s, expected: %s", test.failures, got, test.expected)
+		}
+	}
+
+	// never faster than the interval
+	for _, maxBackoff := range []time.Duration{0, 5 * time.Second} {
+		if got := backoff(10*time.Second, 3, maxBackoff); got != 10*time.Second {
+			t.Errorf("test max backoff %s not matched, got: %s, expected: 10s", maxBackoff, got)
+		}
+	}
+}
@@ -58,6 +58,108 @@ const (
 	SOLConfigParamSelector_PayloadPort        SOLConfigParamSelector = 0x08
 )
 
+func (paramSelector SOLConfigParamSelector) String() string {
+	m := map[SOLConfigParamSelector]string{
+		0x00: "set-in-progress",
+		0x01: "enabled",
+		0x02: "authentication",
+		0x03: "character",
+		0x04: "retry",
+		0x05: "non-volatile-bit-rate",
+		0x06: "volatile-bit-rate",
+		0x07: "payload-channel",
+		0x08: "payload-port",
+	}
+	s, ok := m[paramSelector]
+	if ok {
+		return s
+	}
+	return fmt.Sprintf("%#02x", uint8(paramSelector))
+}
+
+// Selectors returns the param selectors of the non-nil fields of the SOLConfigParam,
+// SetInProgress is not included.
+func (p *SOLConfigParam) Selectors() []SOLConfigParamSelector {
+	out := make([]SOLConfigParamSelector, 0)
+	if p.SOLEnable != nil {
+		out = append(out, SOLConfigParamSelector_SOLEnable)
+	}
+	if p.SOLAuthentication != nil {
+		out = append(out, SOLConfigParamSelector_SOLAuthentication)
+	}
+	if p.Character != nil {
+		out = append(out, SOLConfigParamSelector_Character)
+	}
+	if p.SOLRetry != nil {
+		out = append(out, SOLConfigParamSelector_SOLRetry)
+	}
+	if p.NonVolatileBitRate != nil {
+		out = append(out, SOLConfigParamSelector_NonVolatileBitRate)
+	}
+	if p.VolatileBitRate != nil {
+		out = append(out, SOLConfigParamSelector_VolatileBitRate)
+	}
+	if p.PayloadChannel != nil {
+		out = append(out, SOLConfigParamSelector_PayloadChannel)
+	}
+	if p.PayloadPort != nil {
+		out = append(out, SOLConfigParamSelector_PayloadPort)
+	}
+	return out
+}
+
+// PackSOLParamData is the counterpart of ParseSOLParamData,
+// it encodes the field of solConfigParam selected by paramSelector to paramData.
+func PackSOLParamData(paramSelector SOLConfigParamSelector, solConfigParam *SOLConfigParam) ([]byte, error) {
+	var packer interface{ Pack() []byte }
+
+	switch paramSelector {
+	case SOLConfigParamSelector_SetInProgress:
+		if solConfigParam.SetInProgress != nil {
+			packer = solConfigParam.SetInProgress
+		}
+	case SOLConfigParamSelector_SOLEnable:
+		if solConfigParam.SOLEnable != nil {
+			packer = solConfigParam.SOLEnable
+		}
+	case SOLConfigParamSelector_SOLAuthentication:
+		if solConfigParam.SOLAuthentication != nil {
+			packer = solConfigParam.SOLAuthentication
+		}
+	case SOLConfigParamSelector_Character:
+		if solConfigParam.Character != nil {
+			packer = solConfigParam.Character
+		}
+	case SOLConfigParamSelector_SOLRetry:
+		if solConfigParam.SOLRetry != nil {
+			packer = solConfigParam.SOLRetry
+		}
+	case SOLConfigParamSelector_NonVolatileBitRate:
+		if solConfigParam.NonVolatileBitRate != nil {
+			packer = solConfigParam.NonVolatileBitRate
+		}
+	case SOLConfigParamSelector_VolatileBitRate:
+		if solConfigParam.VolatileBitRate != nil {
+			packer = solConfigParam.VolatileBitRate
+		}
+	case SOLConfigParamSelector_PayloadChannel:
+		if solConfigParam.PayloadChannel != nil {
+			packer = solConfigParam.PayloadChannel
+		}
+	case SOLConfigParamSelector_PayloadPort:
+		if solConfigParam.PayloadPort != nil {
+			packer = solConfigParam.PayloadPort
+		}
+	default:
+		return nil, fmt.Errorf("unknown paramSelector (%d)", paramSelector)
+	}
+
+	if packer == nil {
+		return nil, fmt.Errorf("the param for paramSelector (%d) is not set", paramSelector)
+	}
+	return packer.Pack(), nil
+}
+
 func ParseSOLParamData(paramSelector SOLConfigParamSelector, paramData []byte, solConfigParam *SOLConfigParam) error {
 	var err error
 
@@ -149,6 +251,12 @@ func ParseSOLParamData(paramSelector SOLConfigParamSelector, paramData []byte, s
 
 type SOLConfigParam_SetInProgress uint8
 
+const (
+	SOLSetComplete   SOLConfigParam_SetInProgress = 0x00
+	SOLSetInProgress SOLConfigParam_SetInProgress = 0x01
+	SOLCommitWrite   SOLConfigParam_SetInProgress = 0x02 // optional
+)
+
 func (p *SOLConfigParam_SetInProgress) Unpack(paramData []byte) error {
 	if len(paramData) != 1 {
 		return fmt.Errorf("the parameter data length must be 1 byte")
@@ -221,7 +329,7 @@ func (p *SOLConfigParam_SOLAuthentication) Pack() []byte {
 	if p.ForceAuthentication {
 		b = setBit6(b)
 	}
-	b |= p.PrivilegeLevel
+	b |= p.PrivilegeLevel & 0x0f
 	return []byte{b}
 }
 
@@ -260,6 +368,7 @@ Send Threshold      : %d`, p.AccumulateInterval5Millis, p.SendThreshold)
 type SOLConfigParam_SOLRetry struct {
 	// 1-based. 0 = no retries after packet is transmitted. Packet will be
 	// dropped if no ACK/NACK received by time retries expire.
+	// Only 3 bits [2:0], 0-7, the reserved bits [7:3] are not sent.
 	RetryCount uint8
 
 	// 1-based. Retry Interval in 10 ms increments. Sets the time that
@@ -274,14 +383,14 @@ func (p *SOLConfigParam_SOLRetry) Unpack(paramData []byte) error {
 	if len(paramData) != 2 {
 		return fmt.Errorf("the parameter data length must be 2 byte")
 	}
-	p.RetryCount = paramData[0]
+	p.RetryCount = paramData[0] & 0x07
 	p.RetryInterval10Millis = paramData[1]
 
 	return nil
 }
 
 func (p *SOLConfigParam_SOLRetry) Pack() []byte {
-	return []byte{p.RetryCount, p.RetryInterval10Millis}
+	return []byte{p.RetryCount & 0x07, p.RetryInterval10Millis}
 }
 
 func (p *SOLConfigParam_SOLRetry) Format() string {
@@ -309,6 +418,24 @@ func (p *SOLConfigParam_NonVolatileBitRate) Format() string {
 	return fmt.Sprintf("%d", *p)
 }
 
+// ParseSOLBitRate returns the bit rate setting (as used by NonVolatileBitRate and VolatileBitRate) of kbps.
+// kbps 0 means "serial", that is to use the setting used by the IPMI over serial channel.
+func ParseSOLBitRate(kbps float64) (uint8, error) {
+	m := map[float64]uint8{
+		0:     0x00,
+		9.6:   0x06,
+		19.2:  0x07,
+		38.4:  0x08,
+		57.6:  0x09,
+		115.2: 0x0a,
+	}
+	b, ok := m[kbps]
+	if !ok {
+		return 0, fmt.Errorf("unsupported bit rate %.1f kbps, valid values are 9.6, 19.2, 38.4, 57.6, 115.2", kbps)
+	}
+	return b, nil
+}
+
 func (p *SOLConfigParam_NonVolatileBitRate) KBPS() float64 {
 	m := map[SOLConfigParam_NonVolatileBitRate]float64{
 		0x06: 9.6,
@@ -0,0 +1,139 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SyslogFacility is the facility of syslog messages, see RFC 5424 6.2.1.
+type SyslogFacility uint8
+
+const (
+	SyslogFacilityKern   SyslogFacility = 0
+	SyslogFacilityUser   SyslogFacility = 1
+	SyslogFacilityDaemon SyslogFacility = 3
+	SyslogFacilityAuth   SyslogFacility = 4
+	SyslogFacilityLocal0 SyslogFacility = 16
+	SyslogFacilityLocal1 SyslogFacility = 17
+	SyslogFacilityLocal2 SyslogFacility = 18
+	SyslogFacilityLocal3 SyslogFacility = 19
+	SyslogFacilityLocal4 SyslogFacility = 20
+	SyslogFacilityLocal5 SyslogFacility = 21
+	SyslogFacilityLocal6 SyslogFacility = 22
+	SyslogFacilityLocal7 SyslogFacility = 23
+)
+
+// SyslogSeverity is the severity of syslog messages, see RFC 5424 6.2.1.
+type SyslogSeverity uint8
+
+const (
+	SyslogSeverityEmergency     SyslogSeverity = 0
+	SyslogSeverityAlert         SyslogSeverity = 1
+	SyslogSeverityCritical      SyslogSeverity = 2
+	SyslogSeverityError         SyslogSeverity = 3
+	SyslogSeverityWarning       SyslogSeverity = 4
+	SyslogSeverityNotice        SyslogSeverity = 5
+	SyslogSeverityInformational SyslogSeverity = 6
+	SyslogSeverityDebug         SyslogSeverity = 7
+)
+
+// SyslogSDID is the SD-ID of the structured data element of the syslog messages,
+// the enterprise number is the IANA Private Enterprise Number of the IPMI forum.
+const SyslogSDID = "ipmi@7154"
+
+// SyslogParam is a SD-PARAM of the structured data element.
+type SyslogParam struct {
+	Name  string
+	Value string
+}
+
+// SyslogMessage is a syslog message in RFC 5424 format.
+type SyslogMessage struct {
+	Facility SyslogFacility
+	Severity SyslogSeverity
+
+	// The NILVALUE "-" is used for the zero Timestamp and the empty header fields.
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	MsgID     string
+
+	// Params are written as the structured data element of SyslogSDID.
+	Params []SyslogParam
+
+	Message string
+}
+
+// Priority returns the PRI value of the message.
+func (m *SyslogMessage) Priority() uint8 {
+	return uint8(m.Facility)*8 + uint8(m.Severity)
+}
+
+// String returns the message as a RFC 5424 syslog line, without the trailing newline.
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [ipmi@7154 name="value" ...] MSG
+func (m *SyslogMessage) String() string {
+	timestamp := "-"
+	if !m.Timestamp.IsZero() {
+		timestamp = m.Timestamp.Format(time.RFC3339)
+	}
+
+	structuredData := "-"
+	if len(m.Params) > 0 {
+		params := make([]string, 0, len(m.Params))
+		for _, param := range m.Params {
+			params = append(params, fmt.Sprintf(`%s="%s"`, param.Name, syslogParamValueEscaper.Replace(param.Value)))
+		}
+		structuredData = fmt.Sprintf("[%s %s]", SyslogSDID, strings.Join(params, " "))
+	}
+
+	line := fmt.Sprintf("<%d>1 %s %s %s %s %s %s",
+		m.Priority(),
+		timestamp,
+		syslogHeaderField(m.Hostname, 255),
+		syslogHeaderField(m.AppName, 48),
+		syslogHeaderField(m.ProcID, 128),
+		syslogHeaderField(m.MsgID, 32),
+		structuredData,
+	)
+	if m.Message != "" {
+		line += " " + m.Message
+	}
+	return line
+}
+
+// The characters '"', '\' and ']' must be escaped in PARAM-VALUE.
+var syslogParamValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// syslogHeaderField returns the header field of printable US-ASCII characters without spaces,
+// truncated to the max length, or the NILVALUE if the field is empty.
+func syslogHeaderField(s string, maxLen int) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s) && len(out) < maxLen; i++ {
+		if s[i] > 32 && s[i] < 127 {
+			out = append(out, s[i])
+		}
+	}
+	if len(out) == 0 {
+		return "-"
+	}
+	return string(out)
+}
+
+// eventSeverityToSyslog maps the SEL event severity to the syslog severity.
+func eventSeverityToSyslog(severity EventSeverity) SyslogSeverity {
+	switch severity {
+	case EventSeverityCritical:
+		return SyslogSeverityCritical
+	case EventSeverityNonFatal:
+		return SyslogSeverityError
+	case EventSeverityWarning, EventSeverityDegraded:
+		return SyslogSeverityWarning
+	case EventSeverityOK:
+		return SyslogSeverityNotice
+	default:
+		return SyslogSeverityInformational
+	}
+}
@@ -70,7 +70,7 @@ func (c *UDPClient) RemoteIP() string {
 }
 
 func (c *UDPClient) LocalIPPort() (string, int) {
-	conn, err := net.Dial("udp", fmt.Sprintf("%s:%d", c.Host, c.Port))
+	conn, err := net.Dial("udp", net.JoinHostPort(c.Host, strconv.Itoa(c.Port)))
 	if err != nil {
 		return "", 0
 	}
@@ -142,3 +142,44 @@ func (c *UDPClient) Exchange(ctx context.Context, reader io.Reader) ([]byte, err
 		return recvBuffer[:recvCount], nil
 	}
 }
+
+// Receive waits for a reply without sending anything.
+// It is used to read the messages which follow the reply of the previous query,
+// like the bridged response following the Send Message response.
+func (c *UDPClient) Receive(ctx context.Context) ([]byte, error) {
+	if err := c.initConn(); err != nil {
+		return nil, fmt.Errorf("init udp connection failed, err: %s", err)
+	}
+
+	recvBuffer := make([]byte, c.bufferSize)
+
+	doneChan := make(chan error, 1)
+	recvChan := make(chan int, 1)
+	go func() {
+		deadline := time.Now().Add(c.timeout)
+		if err := c.conn.SetReadDeadline(deadline); err != nil {
+			doneChan <- fmt.Errorf("set conn read deadline failed, err: %s", err)
+			return
+		}
+
+		nRead, err := c.conn.Read(recvBuffer)
+		if err != nil {
+			doneChan <- fmt.Errorf("read from conn failed, err: %s", err)
+			return
+		}
+
+		doneChan <- nil
+		recvChan <- nRead
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("canceled from caller")
+	case err := <-doneChan:
+		if err != nil {
+			return nil, err
+		}
+		recvCount := <-recvChan
+		return recvBuffer[:recvCount], nil
+	}
+}